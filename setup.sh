@@ -39,6 +39,7 @@ go test -cover                                   \
   tsai.eu/solar/controller/internalController    \
   tsai.eu/solar/controller                       \
   tsai.eu/solar/engine                           \
+  tsai.eu/solar/store                            \
   tsai.eu/solar/monitor                          \
   tsai.eu/solar/cli                              \
   tsai.eu/solar/api
@@ -1,15 +1,13 @@
 package api
 
 import (
-  "io"
-  "io/ioutil"
+  "errors"
   "net/http"
 
   "github.com/gorilla/mux"
 
   "tsai.eu/solar/model"
   "tsai.eu/solar/engine"
-  "tsai.eu/solar/util"
 )
 
 //------------------------------------------------------------------------------
@@ -22,7 +20,7 @@ func ArchitectureListHandler(w http.ResponseWriter, r *http.Request) {
   // determine domain
   domain, err := model.GetDomain(domainName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
     return
   }
 
@@ -31,7 +29,7 @@ func ArchitectureListHandler(w http.ResponseWriter, r *http.Request) {
 
   aNameVersions, err := domain.ListArchitectures()
   if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
+    writeError(w, r, http.StatusInternalServerError, "architectures can not be listed", err)
     return
   }
 
@@ -39,15 +37,48 @@ func ArchitectureListHandler(w http.ResponseWriter, r *http.Request) {
     architectures = append(architectures, aNameVersion[0] + " - " + aNameVersion[1])
   }
 
-  // convert to yaml
-  result, err := util.ConvertToYAML(architectures)
+  // return the result
+  writeEntity(w, r, architectures)
+}
+
+//------------------------------------------------------------------------------
+
+// ArchitectureValidateHandler validates an architecture without adding it to the domain.
+func ArchitectureValidateHandler(w http.ResponseWriter, r *http.Request) {
+  vars       := mux.Vars(r)
+  domainName := vars["domain"]
+
+  // get yaml
+  body, err := readBody(r)
   if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
+    writeError(w, r, http.StatusBadRequest, "unable to read architecture", err)
     return
   }
 
-  // return the result
-  io.WriteString(w, result)
+  // determine domain
+  domain, err := model.GetDomain(domainName)
+  if err != nil {
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
+    return
+  }
+
+  // create new architecture
+  architecture, _ := model.NewArchitecture("","","")
+
+  err = architecture.Load2(body)
+  if err != nil {
+    writeError(w, r, http.StatusBadRequest, "unable to parse architecture", err)
+    return
+  }
+
+  // return the result of the validation
+  result, err := model.ValidateArchitecture(domain, architecture).Show()
+  if err != nil {
+    writeError(w, r, http.StatusInternalServerError, "unable to display validation", err)
+    return
+  }
+
+  writeResult(w, r, result)
 }
 
 //------------------------------------------------------------------------------
@@ -58,36 +89,40 @@ func ArchitectureSetHandler(w http.ResponseWriter, r *http.Request) {
   domainName := vars["domain"]
 
   // get yaml
-  body, err := ioutil.ReadAll(r.Body)
+  body, err := readBody(r)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
-    io.WriteString(w, "unable to read architecture:\n" + err.Error())
+    writeError(w, r, http.StatusBadRequest, "unable to read architecture", err)
     return
   }
 
   // determine domain
   domain, err := model.GetDomain(domainName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
-    io.WriteString(w, "domain can not be identified")
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
     return
   }
 
   // create new architecture
   architecture, _ := model.NewArchitecture("","","")
 
-  err = architecture.Load2(string(body))
+  err = architecture.Load2(body)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
-    io.WriteString(w, "unable to parse architecture:\n" + err.Error())
+    writeError(w, r, http.StatusBadRequest, "unable to parse architecture", err)
+    return
+  }
+
+  // validate architecture
+  validation := model.ValidateArchitecture(domain, architecture)
+  if !validation.OK() {
+    result, _ := validation.Show()
+    writeError(w, r, http.StatusBadRequest, "architecture is invalid", errors.New(result))
     return
   }
 
   // add architecture to domain
   err = domain.AddArchitecture(architecture)
   if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
-    io.WriteString(w, "unable to add architecture:\n" + err.Error())
+    writeError(w, r, http.StatusInternalServerError, "unable to add architecture", err)
     return
   }
 }
@@ -104,20 +139,19 @@ func ArchitectureGetHandler(w http.ResponseWriter, r *http.Request) {
   // determine architecture
   architecture, err := model.GetArchitecture(domainName, architectureName, architectureVersion)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "architecture can not be identified", err)
     return
   }
 
   // transform architecture to string
   yaml, err := architecture.Show()
   if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
+    writeError(w, r, http.StatusInternalServerError, "architecture can not be displayed", err)
     return
   }
 
   // write yaml
-  // w.Header().Set("Content-Type", "application/x-yaml")
-  io.WriteString(w, yaml)
+  writeResult(w, r, yaml)
 }
 
 //------------------------------------------------------------------------------
@@ -132,14 +166,14 @@ func ArchitectureDeleteHandler(w http.ResponseWriter, r *http.Request) {
   // determine domain
   domain, err := model.GetDomain(domainName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
     return
   }
 
   // delete architecture
   err = domain.DeleteArchitecture(architectureName, architectureVersion)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "architecture can not be deleted", err)
     return
   }
 }
@@ -153,55 +187,55 @@ func ArchitectureDeployHandler(w http.ResponseWriter, r *http.Request) {
   architectureName    := vars["architecture"]
   architectureVersion := vars["version"]
 
-	// determine domain
-	domain, err := model.GetDomain(domainName)
+  // determine domain
+  domain, err := model.GetDomain(domainName)
+  if err != nil {
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
+    return
+  }
 
-	if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
-    io.WriteString(w, "domain can not be identified")
+  // determine architecture
+  architecture, err := domain.GetArchitecture(architectureName, architectureVersion)
+  if err != nil {
+    writeError(w, r, http.StatusBadRequest, "architecture can not be identified", err)
     return
-	}
+  }
 
-	// determine architecture
-	architecture, err := domain.GetArchitecture(architectureName, architectureVersion)
+  // only determine the changes if requested
+  if r.URL.Query().Get("dryrun") == "true" {
+    writePlan(w, r, domain.Name, architecture)
+    return
+  }
 
-	if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
-    io.WriteString(w, "architecture can not be identified")
+  // update the solution and start a task
+  uuid, err := engine.Deploy(domain.Name, architecture)
+  if err != nil {
+    writeError(w, r, http.StatusInternalServerError, "architecture can not be deployed", err)
     return
-	}
+  }
 
-	// determine solution (create new solution if not found)
-	solution, err := domain.GetSolution(architecture.Architecture)
-	if err != nil {
-		solution, _ = model.NewSolution(architecture.Architecture, architecture.Version, "")
+  // return the uuid of the task
+  writeResult(w, r, uuid)
+}
 
-		domain.AddSolution(solution)
-	}
+//------------------------------------------------------------------------------
 
-	// update the target state of the solution
-	if err = solution.Update(domain.Name, architecture); err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
-    io.WriteString(w, "unable to create or update the solution:\n" + err.Error())
+// writePlan returns the changes a deployment of an architecture would apply
+// to its solution.
+func writePlan(w http.ResponseWriter, r *http.Request, domainName string, architecture *model.Architecture) {
+  plan, err := model.NewPlan(domainName, architecture)
+  if err != nil {
+    writeError(w, r, http.StatusInternalServerError, "plan can not be determined", err)
     return
-	}
-
-	// create task and start it by signalling an event
-	task, err := engine.NewSolutionTask(domain.Name, "", solution)
-	if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
-    io.WriteString(w, "task can not be created:\n" + err.Error())
-		return
-	}
-
-	// get event channel
-	channel := engine.GetEventChannel()
+  }
 
-	// create event
-	channel <- model.NewEvent(domain.Name, task.UUID, model.EventTypeTaskExecution, "", "initial")
+  result, err := plan.Show()
+  if err != nil {
+    writeError(w, r, http.StatusInternalServerError, "plan can not be displayed", err)
+    return
+  }
 
-  // return the uuid of the task
-  io.WriteString(w, task.UUID)
+  writeResult(w, r, result)
 }
 
 //------------------------------------------------------------------------------
@@ -0,0 +1,171 @@
+package api
+
+import (
+  "errors"
+  "strconv"
+  "strings"
+  "net/http"
+
+  "github.com/gorilla/mux"
+
+  "tsai.eu/solar/model"
+)
+
+//------------------------------------------------------------------------------
+
+// Anonymous is the actor recorded for requests without an authenticated user.
+const Anonymous string = "anonymous"
+
+//------------------------------------------------------------------------------
+
+// statusWriter captures the status code of a response.
+type statusWriter struct {
+  http.ResponseWriter
+  status int
+}
+
+// WriteHeader records the status code before it is written to the response.
+func (w *statusWriter) WriteHeader(status int) {
+  w.status = status
+  w.ResponseWriter.WriteHeader(status)
+}
+
+// Flush sends any buffered data to the client.
+func (w *statusWriter) Flush() {
+  if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+    flusher.Flush()
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// AuditMiddleware records all successful modifications in the audit log.
+func AuditMiddleware(next http.Handler) http.Handler {
+  return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    route    := mux.CurrentRoute(r)
+    template := ""
+    if route != nil {
+      template, _ = route.GetPathTemplate()
+    }
+
+    // only modifications are audited
+    if r.Method == http.MethodGet || route == nil || r.URL.Query().Get("dryrun") == "true" || strings.HasSuffix(template, "/validate") {
+      next.ServeHTTP(w, r)
+      return
+    }
+
+    // determine the actor
+    actor := Anonymous
+    if principal := GetPrincipal(r); principal != nil {
+      actor = principal.Subject
+    }
+
+    // execute the request
+    path   := auditPath(r.Method, template, mux.Vars(r))
+    before := model.AuditState(path)
+    writer := statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+    next.ServeHTTP(&writer, r)
+
+    if writer.status >= http.StatusMultipleChoices {
+      return
+    }
+
+    model.Audit(actor, model.AuditSourceREST, r.Method + " " + template, path, before, model.AuditState(path))
+  })
+}
+
+//------------------------------------------------------------------------------
+
+// auditPath determines the path of the entity modified by a request.
+func auditPath(method string, template string, vars map[string]string) string {
+  domain := vars["domain"]
+  kind   := strings.Split(strings.TrimPrefix(template, "/"), "/")[0]
+
+  switch kind {
+  case "component", "controller":
+    if name, ok := vars[kind]; ok {
+      return domain + "/" + kind + ":" + name + "/" + vars["version"]
+    }
+    return domain
+  case "architecture":
+    name, ok := vars["architecture"]
+    switch {
+    case !ok:
+      return domain
+    case method == http.MethodDelete:
+      return domain + "/architecture:" + name + "/" + vars["version"]
+    }
+    // deployments modify the solution of the same name
+    return domain + "/" + name
+  case "secret":
+    if name, ok := vars["secret"]; ok {
+      return domain + "/secret:" + name
+    }
+    return domain
+  case "task":
+    return domain + "/task:" + vars["task"]
+  case "operation":
+    return domain + "/operation:" + vars["operation"]
+  }
+
+  // entities of a solution
+  path := domain
+  for _, name := range []string{"solution", "element", "cluster", "instance"} {
+    value, ok := vars[name]
+    if !ok {
+      break
+    }
+    path += "/" + value
+  }
+
+  return path
+}
+
+//------------------------------------------------------------------------------
+
+// AuditGetHandler retrieves the audit log of a domain.
+func AuditGetHandler(w http.ResponseWriter, r *http.Request) {
+  vars       := mux.Vars(r)
+  domainName := vars["domain"]
+
+  // determine the page of the audit log
+  offset, err := queryNumber(r, "offset")
+  if err != nil {
+    writeError(w, r, http.StatusBadRequest, "offset is not a valid number", err)
+    return
+  }
+
+  limit, err := queryNumber(r, "limit")
+  if err != nil {
+    writeError(w, r, http.StatusBadRequest, "limit is not a valid number", err)
+    return
+  }
+
+  // determine the entries of the audit log
+  entries := model.GetAudit(domainName, offset, limit)
+
+  // return the result
+  writeEntity(w, r, entries)
+}
+
+//------------------------------------------------------------------------------
+
+// queryNumber determines the non-negative number defined by a query parameter
+// (0 if undefined).
+func queryNumber(r *http.Request, name string) (int, error) {
+  value := r.URL.Query().Get(name)
+  if value == "" {
+    return 0, nil
+  }
+
+  number, err := strconv.Atoi(value)
+  if err != nil || number < 0 {
+    return 0, errors.New("invalid " + name + ": " + value)
+  }
+
+  // success
+  return number, nil
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,37 @@
+package api
+
+import (
+  "testing"
+)
+
+//------------------------------------------------------------------------------
+
+// TestAudit01 verifies the paths of the entities modified by requests.
+func TestAudit01(t *testing.T) {
+  tests := []struct {
+    method   string
+    template string
+    vars     map[string]string
+    path     string
+  }{
+    {"PUT",    "/model",                                        map[string]string{},                                                        ""},
+    {"DELETE", "/domain/{domain}",                              map[string]string{"domain": "demo"},                                        "demo"},
+    {"POST",   "/component/{domain}",                           map[string]string{"domain": "demo"},                                        "demo"},
+    {"DELETE", "/component/{domain}/{component}/{version}",     map[string]string{"domain": "demo", "component": "web", "version": "V1"},   "demo/component:web/V1"},
+    {"DELETE", "/architecture/{domain}/{architecture}/{version}", map[string]string{"domain": "demo", "architecture": "app", "version": "V1"}, "demo/architecture:app/V1"},
+    {"POST",   "/architecture/{domain}/{architecture}/{version}", map[string]string{"domain": "demo", "architecture": "app", "version": "V1"}, "demo/app"},
+    {"POST",   "/solution/{domain}/{solution}/rollback",        map[string]string{"domain": "demo", "solution": "app"},                     "demo/app"},
+    {"PUT",    "/cluster/{domain}/{solution}/{element}/{cluster}", map[string]string{"domain": "demo", "solution": "app", "element": "web", "cluster": "V1"}, "demo/app/web/V1"},
+    {"POST",   "/secret/{domain}/{secret}",                     map[string]string{"domain": "demo", "secret": "token"},                     "demo/secret:token"},
+    {"DELETE", "/task/{domain}/{task}",                         map[string]string{"domain": "demo", "task": "1234"},                        "demo/task:1234"},
+    {"POST",   "/operation/{domain}/{operation}",               map[string]string{"domain": "demo", "operation": "42"},                     "demo/operation:42"},
+  }
+
+  for index, test := range tests {
+    if path := auditPath(test.method, test.template, test.vars); path != test.path {
+      t.Errorf("%d: %s %s should have been audited as '%s' instead of '%s'", index, test.method, test.template, test.path, path)
+    }
+  }
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,271 @@
+package api
+
+import (
+  "time"
+  "context"
+  "errors"
+  "strings"
+  "net/http"
+  "crypto/hmac"
+  "crypto/sha256"
+  "crypto/subtle"
+  "encoding/json"
+  "encoding/base64"
+
+  "github.com/gorilla/mux"
+
+  "tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// RoleViewer may read all information of a domain.
+const RoleViewer string = "viewer"
+
+// RoleOperator may in addition deploy and modify solutions of a domain.
+const RoleOperator string = "operator"
+
+// RoleAdmin may in addition manage the domain, its controllers and secrets.
+const RoleAdmin string = "admin"
+
+// AllDomains is the scope of roles granted for all domains.
+const AllDomains string = "*"
+
+//------------------------------------------------------------------------------
+
+// roleLevels orders the roles by their privileges.
+var roleLevels = map[string]int{
+  RoleViewer:   1,
+  RoleOperator: 2,
+  RoleAdmin:    3,
+}
+
+// adminRoutes lists the routes which may only be used by administrators.
+var adminRoutes = map[string]bool{
+  "POST /model":                                        true,
+  "PUT /model":                                         true,
+  "POST /domain":                                       true,
+  "POST /domain/{domain}":                              true,
+  "PUT /domain/{domain}":                               true,
+  "DELETE /domain/{domain}":                            true,
+  "POST /controller/{domain}":                          true,
+  "DELETE /controller/{domain}/{controller}/{version}": true,
+  "POST /secret/{domain}/{secret}":                     true,
+  "DELETE /secret/{domain}/{secret}":                   true,
+}
+
+// openRoutes lists the routes which do not require any authentication.
+var openRoutes = map[string]bool{
+  "/":             true,
+  "/solar":        true,
+  "/solar/":       true,
+  "/openapi.yaml": true,
+}
+
+// contextKey identifies values stored in the context of a request.
+type contextKey string
+
+// principalKey refers to the authenticated principal of a request.
+const principalKey contextKey = "principal"
+
+//------------------------------------------------------------------------------
+
+// Principal describes an authenticated user of the API.
+type Principal struct {
+  Subject string            // name of the user
+  Roles   map[string]string // role per domain
+}
+
+//------------------------------------------------------------------------------
+
+// Authenticator validates the bearer tokens of API requests and enforces the
+// roles of the users.
+type Authenticator struct {
+  Key    []byte                // key to validate JSON web tokens
+  Tokens map[string]*Principal // static API tokens
+}
+
+//------------------------------------------------------------------------------
+
+// NewAuthenticator creates an authenticator from the configuration.
+func NewAuthenticator(configuration util.AuthConfiguration) *Authenticator {
+  auth := Authenticator{
+    Key:    []byte(configuration.Key),
+    Tokens: map[string]*Principal{},
+  }
+
+  for _, token := range configuration.Tokens {
+    auth.Tokens[token.Token] = &Principal{Subject: token.Subject, Roles: token.Roles}
+  }
+
+  return &auth
+}
+
+//------------------------------------------------------------------------------
+
+// Enabled checks if the authentication has been configured.
+func (auth *Authenticator) Enabled() bool {
+  return len(auth.Key) > 0 || len(auth.Tokens) > 0
+}
+
+//------------------------------------------------------------------------------
+
+// Middleware rejects requests without a valid token or the required role.
+func (auth *Authenticator) Middleware(next http.Handler) http.Handler {
+  return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    route    := mux.CurrentRoute(r)
+    template := ""
+    if route != nil {
+      template, _ = route.GetPathTemplate()
+    }
+
+    // check if authentication is required
+    if !auth.Enabled() || openRoutes[template] {
+      next.ServeHTTP(w, r)
+      return
+    }
+
+    // authenticate the user
+    principal, err := auth.authenticate(r)
+    if err != nil {
+      writeError(w, r, http.StatusUnauthorized, "authentication required", err)
+      return
+    }
+
+    // authorize the request
+    domain, role := requiredRole(r.Method, template, mux.Vars(r))
+    if !principal.Authorized(domain, role) {
+      util.LogWarn(principal.Subject, "API", "access denied: " + r.Method + " " + r.URL.Path)
+      writeError(w, r, http.StatusForbidden, "role '" + role + "' required", nil)
+      return
+    }
+
+    next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey, principal)))
+  })
+}
+
+//------------------------------------------------------------------------------
+
+// GetPrincipal determines the authenticated principal of a request.
+func GetPrincipal(r *http.Request) *Principal {
+  principal, _ := r.Context().Value(principalKey).(*Principal)
+  return principal
+}
+
+//------------------------------------------------------------------------------
+
+// authenticate determines the user of a request from its bearer token.
+func (auth *Authenticator) authenticate(r *http.Request) (*Principal, error) {
+  header := r.Header.Get("Authorization")
+  if !strings.HasPrefix(header, "Bearer ") {
+    return nil, errors.New("bearer token required")
+  }
+  token := strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+
+  // static tokens
+  for value, principal := range auth.Tokens {
+    if subtle.ConstantTimeCompare([]byte(value), []byte(token)) == 1 {
+      return principal, nil
+    }
+  }
+
+  // JSON web tokens
+  if len(auth.Key) > 0 && strings.Count(token, ".") == 2 {
+    return auth.validateJWT(token)
+  }
+
+  return nil, errors.New("invalid token")
+}
+
+//------------------------------------------------------------------------------
+
+// validateJWT validates a JSON web token signed with HS256 and extracts the
+// subject and roles from its claims.
+func (auth *Authenticator) validateJWT(token string) (*Principal, error) {
+  parts := strings.Split(token, ".")
+
+  // check header
+  var header struct {
+    Alg string `json:"alg"`
+  }
+  if err := decodeSegment(parts[0], &header); err != nil || header.Alg != "HS256" {
+    return nil, errors.New("unsupported token")
+  }
+
+  // check signature
+  mac := hmac.New(sha256.New, auth.Key)
+  mac.Write([]byte(parts[0] + "." + parts[1]))
+
+  signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+  if err != nil || !hmac.Equal(signature, mac.Sum(nil)) {
+    return nil, errors.New("invalid token signature")
+  }
+
+  // check claims
+  var claims struct {
+    Sub   string            `json:"sub"`
+    Exp   int64             `json:"exp"`
+    Roles map[string]string `json:"roles"`
+  }
+  if err = decodeSegment(parts[1], &claims); err != nil {
+    return nil, errors.New("invalid token claims")
+  }
+
+  // tokens without expiration would be valid forever
+  if claims.Exp == 0 {
+    return nil, errors.New("token without expiration")
+  }
+
+  if time.Now().Unix() > claims.Exp {
+    return nil, errors.New("token has expired")
+  }
+
+  // success
+  return &Principal{Subject: claims.Sub, Roles: claims.Roles}, nil
+}
+
+//------------------------------------------------------------------------------
+
+// decodeSegment decodes a base64url encoded JSON segment of a token.
+func decodeSegment(segment string, entity interface{}) error {
+  data, err := base64.RawURLEncoding.DecodeString(segment)
+  if err != nil {
+    return err
+  }
+
+  return json.Unmarshal(data, entity)
+}
+
+//------------------------------------------------------------------------------
+
+// requiredRole determines the scope and the role required by a request.
+func requiredRole(method string, template string, vars map[string]string) (string, string) {
+  domain, ok := vars["domain"]
+  if !ok {
+    domain = AllDomains
+  }
+
+  switch {
+  case adminRoutes[method + " " + template]:
+    return domain, RoleAdmin
+  case method == http.MethodGet:
+    return domain, RoleViewer
+  }
+
+  return domain, RoleOperator
+}
+
+//------------------------------------------------------------------------------
+
+// Authorized checks if the principal has been granted a role for a domain.
+func (principal *Principal) Authorized(domain string, role string) bool {
+  level := roleLevels[principal.Roles[AllDomains]]
+
+  if domainLevel := roleLevels[principal.Roles[domain]]; domainLevel > level {
+    level = domainLevel
+  }
+
+  return level > 0 && level >= roleLevels[role]
+}
+
+//------------------------------------------------------------------------------
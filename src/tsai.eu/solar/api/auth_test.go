@@ -0,0 +1,90 @@
+package api
+
+import (
+  "testing"
+  "net/http"
+  "net/http/httptest"
+  "crypto/hmac"
+  "crypto/sha256"
+  "encoding/base64"
+  "github.com/gorilla/mux"
+
+  "tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// signJWT creates a JSON web token signed with HS256.
+func signJWT(key string, claims string) string {
+  header  := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+  payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+
+  mac := hmac.New(sha256.New, []byte(key))
+  mac.Write([]byte(header + "." + payload))
+
+  return header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+//------------------------------------------------------------------------------
+
+// TestAuth01 verifies the authentication and authorization of requests.
+func TestAuth01(t *testing.T) {
+  auth := NewAuthenticator(util.AuthConfiguration{
+    Key: "TestAuth01",
+    Tokens: []util.TokenConfiguration{
+      {Token: "viewer",   Subject: "viewer",   Roles: map[string]string{"demo": RoleViewer}},
+      {Token: "operator", Subject: "operator", Roles: map[string]string{"demo": RoleOperator}},
+      {Token: "admin",    Subject: "admin",    Roles: map[string]string{AllDomains: RoleAdmin}},
+    },
+  })
+
+  handler := func(w http.ResponseWriter, r *http.Request) {}
+
+  router := mux.NewRouter()
+  router.HandleFunc("/model",                                  handler).Methods("PUT")
+  router.HandleFunc("/domain/{domain}",                        handler).Methods("DELETE")
+  router.HandleFunc("/tasks/{domain}",                         handler).Methods("GET")
+  router.HandleFunc("/solution/{domain}/{solution}/{version}", handler).Methods("POST")
+  router.HandleFunc("/solar",                                  handler).Methods("GET")
+  router.Use(auth.Middleware)
+
+  tests := []struct {
+    method string
+    url    string
+    token  string
+    status int
+  }{
+    {"GET",    "/solar",                    "",         http.StatusOK},
+    {"GET",    "/tasks/demo",               "",         http.StatusUnauthorized},
+    {"GET",    "/tasks/demo",               "unknown",  http.StatusUnauthorized},
+    {"GET",    "/tasks/demo",               "viewer",   http.StatusOK},
+    {"GET",    "/tasks/other",              "viewer",   http.StatusForbidden},
+    {"POST",   "/solution/demo/app/V1.0.0", "viewer",   http.StatusForbidden},
+    {"POST",   "/solution/demo/app/V1.0.0", "operator", http.StatusOK},
+    {"DELETE", "/domain/demo",              "operator", http.StatusForbidden},
+    {"PUT",    "/model",                    "operator", http.StatusForbidden},
+    {"PUT",    "/model",                    "admin",    http.StatusOK},
+    {"DELETE", "/domain/demo",              "admin",    http.StatusOK},
+    {"POST",   "/solution/demo/app/V1.0.0", signJWT("TestAuth01", `{"sub":"jwt","exp":4102444800,"roles":{"demo":"operator"}}`), http.StatusOK},
+    {"POST",   "/solution/demo/app/V1.0.0", signJWT("TestAuth01", `{"sub":"jwt","exp":4102444800,"roles":{"demo":"viewer"}}`),   http.StatusForbidden},
+    {"POST",   "/solution/demo/app/V1.0.0", signJWT("unknown",    `{"sub":"jwt","exp":4102444800,"roles":{"demo":"operator"}}`), http.StatusUnauthorized},
+    {"POST",   "/solution/demo/app/V1.0.0", signJWT("TestAuth01", `{"sub":"jwt","exp":1,"roles":{"demo":"operator"}}`),          http.StatusUnauthorized},
+    {"POST",   "/solution/demo/app/V1.0.0", signJWT("TestAuth01", `{"sub":"jwt","roles":{"demo":"operator"}}`),                 http.StatusUnauthorized},
+  }
+
+  for index, test := range tests {
+    req := httptest.NewRequest(test.method, test.url, nil)
+    if test.token != "" {
+      req.Header.Set("Authorization", "Bearer " + test.token)
+    }
+
+    rec := httptest.NewRecorder()
+    router.ServeHTTP(rec, req)
+
+    if rec.Code != test.status {
+      t.Errorf("%d: %s %s should have returned %d instead of %d", index, test.method, test.url, test.status, rec.Code)
+    }
+  }
+}
+
+//------------------------------------------------------------------------------
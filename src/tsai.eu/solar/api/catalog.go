@@ -1,13 +1,11 @@
 package api
 
 import (
-  "io"
   "net/http"
 
   "github.com/gorilla/mux"
 
   "tsai.eu/solar/model"
-  "tsai.eu/solar/util"
 )
 
 //------------------------------------------------------------------------------
@@ -20,26 +18,19 @@ func CatalogGetHandler(w http.ResponseWriter, r *http.Request) {
   // determine domain
   domain, err := model.GetDomain(domainName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
     return
   }
 
   // determine components
   components, err := domain.GetComponents()
   if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
-    return
-  }
-
-  // convert to yaml
-  result, err := util.ConvertToYAML(components)
-  if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
+    writeError(w, r, http.StatusInternalServerError, "components can not be determined", err)
     return
   }
 
   // return the result
-  io.WriteString(w, result)
+  writeEntity(w, r, components)
 }
 
 //------------------------------------------------------------------------------
@@ -1,14 +1,11 @@
 package api
 
 import (
-  "io"
-  "io/ioutil"
   "net/http"
 
   "github.com/gorilla/mux"
 
   "tsai.eu/solar/model"
-  "tsai.eu/solar/util"
 )
 
 //------------------------------------------------------------------------------
@@ -22,26 +19,19 @@ func ComponentListHandler(w http.ResponseWriter, r *http.Request) {
   // determine domain
   domain, err := model.GetDomain(domainName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
     return
   }
 
   // determine components
   components, err := domain.ListComponents()
   if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
-    return
-  }
-
-  // convert to yaml
-  result, err := util.ConvertToYAML(components)
-  if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
+    writeError(w, r, http.StatusInternalServerError, "components can not be listed", err)
     return
   }
 
   // return the result
-  io.WriteString(w, result)
+  writeEntity(w, r, components)
 }
 
 //------------------------------------------------------------------------------
@@ -53,32 +43,32 @@ func ComponentSetHandler(w http.ResponseWriter, r *http.Request) {
   domainName := vars["domain"]
 
   // get yaml
-  body, err := ioutil.ReadAll(r.Body)
+  body, err := readBody(r)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "unable to read component", err)
     return
   }
 
   // determine domain
   domain, err := model.GetDomain(domainName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
     return
   }
 
   // create new dummy component
   component, _ := model.NewComponent("", "", "", "")
 
-  err = component.Load2(string(body))
+  err = component.Load2(body)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "unable to parse component", err)
     return
   }
 
   // add component to domain
   err = domain.AddComponent(component)
   if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
+    writeError(w, r, http.StatusInternalServerError, "unable to add component", err)
     return
   }
 }
@@ -95,20 +85,19 @@ func ComponentGetHandler(w http.ResponseWriter, r *http.Request) {
   // determine component
   component, err := model.GetComponent(domainName, componentName, version)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "component can not be identified", err)
     return
   }
 
   // transform domain to string
   yaml, err := component.Show()
   if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
+    writeError(w, r, http.StatusInternalServerError, "component can not be displayed", err)
     return
   }
 
   // write yaml
-  // w.Header().Set("Content-Type", "application/x-yaml")
-  io.WriteString(w, yaml)
+  writeResult(w, r, yaml)
 }
 
 //------------------------------------------------------------------------------
@@ -123,14 +112,14 @@ func ComponentDeleteHandler(w http.ResponseWriter, r *http.Request) {
   // determine domain
   domain, err := model.GetDomain(domainName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
     return
   }
 
   // delete component
   err = domain.DeleteComponent(componentName, version)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "component can not be deleted", err)
     return
   }
 }
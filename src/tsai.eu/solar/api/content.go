@@ -0,0 +1,165 @@
+package api
+
+import (
+  "io"
+  "errors"
+  "strconv"
+  "strings"
+  "io/ioutil"
+  "net/http"
+
+  "tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// MimeYAML is the media type of YAML documents (default).
+const MimeYAML string = "application/x-yaml"
+
+// MimeJSON is the media type of JSON documents.
+const MimeJSON string = "application/json"
+
+//------------------------------------------------------------------------------
+
+// Error describes the reason why a request has failed.
+type Error struct {
+  Status  int    `yaml:"Status"`            // http status code
+  Error   string `yaml:"Error"`             // http status text
+  Message string `yaml:"Message"`           // description of the failure
+  Details string `yaml:"Details,omitempty"` // underlying cause of the failure
+}
+
+//------------------------------------------------------------------------------
+
+// isJSON checks if a media type refers to JSON.
+func isJSON(mediaType string) bool {
+  mediaType = strings.ToLower(strings.TrimSpace(strings.Split(mediaType, ";")[0]))
+
+  return mediaType == MimeJSON || mediaType == "text/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+//------------------------------------------------------------------------------
+
+// isYAML checks if a media type explicitly refers to YAML.
+func isYAML(mediaType string) bool {
+  mediaType = strings.ToLower(strings.TrimSpace(strings.Split(mediaType, ";")[0]))
+
+  switch mediaType {
+  case MimeYAML, "application/yaml", "text/yaml", "text/x-yaml":
+    return true
+  }
+  return strings.HasSuffix(mediaType, "+yaml")
+}
+
+//------------------------------------------------------------------------------
+
+// acceptsJSON checks if the client prefers JSON over YAML. The preference is
+// determined by the quality values of the media ranges in the Accept header,
+// wildcards fall back to YAML.
+func acceptsJSON(r *http.Request) bool {
+  jsonQuality := 0.0
+  yamlQuality := 0.0
+
+  for _, mediaRange := range strings.Split(r.Header.Get("Accept"), ",") {
+    quality := 1.0
+    for _, parameter := range strings.Split(mediaRange, ";")[1:] {
+      parameter = strings.TrimSpace(parameter)
+      if strings.HasPrefix(parameter, "q=") {
+        if value, err := strconv.ParseFloat(parameter[2:], 64); err == nil {
+          quality = value
+        }
+      }
+    }
+
+    switch {
+    case isJSON(mediaRange) && quality > jsonQuality:
+      jsonQuality = quality
+    case isYAML(mediaRange) && quality > yamlQuality:
+      yamlQuality = quality
+    }
+  }
+
+  return jsonQuality > yamlQuality
+}
+
+//------------------------------------------------------------------------------
+
+// readBody reads the body of a request. JSON bodies are converted to YAML.
+func readBody(r *http.Request) (string, error) {
+  body, err := ioutil.ReadAll(r.Body)
+  if err != nil {
+    return "", err
+  }
+
+  if isJSON(r.Header.Get("Content-Type")) {
+    body, err = util.ConvertJSONToYAML(body)
+    if err != nil {
+      return "", errors.New("invalid JSON document: " + err.Error())
+    }
+  }
+
+  // success
+  return string(body), nil
+}
+
+//------------------------------------------------------------------------------
+
+// writeResult writes a YAML document to the response. The document is
+// converted to JSON if the client prefers JSON.
+func writeResult(w http.ResponseWriter, r *http.Request, yaml string) {
+  if !acceptsJSON(r) {
+    w.Header().Set("Content-Type", MimeYAML)
+    io.WriteString(w, yaml)
+    return
+  }
+
+  result, err := util.ConvertYAMLToJSON([]byte(yaml))
+  if err != nil {
+    writeError(w, r, http.StatusInternalServerError, "result can not be converted to JSON", err)
+    return
+  }
+
+  w.Header().Set("Content-Type", MimeJSON)
+  w.Write(result)
+}
+
+//------------------------------------------------------------------------------
+
+// writeEntity converts an entity to YAML or JSON and writes it to the response.
+func writeEntity(w http.ResponseWriter, r *http.Request, entity interface{}) {
+  yaml, err := util.ConvertToYAML(entity)
+  if err != nil {
+    writeError(w, r, http.StatusInternalServerError, "result can not be converted", err)
+    return
+  }
+
+  writeResult(w, r, yaml)
+}
+
+//------------------------------------------------------------------------------
+
+// writeError writes a structured description of a failure to the response.
+func writeError(w http.ResponseWriter, r *http.Request, status int, message string, err error) {
+  info := Error{
+    Status:  status,
+    Error:   http.StatusText(status),
+    Message: message,
+  }
+  if err != nil {
+    info.Details = err.Error()
+  }
+
+  yaml, _ := util.ConvertToYAML(info)
+  result  := []byte(yaml)
+
+  w.Header().Set("Content-Type", MimeYAML)
+  if acceptsJSON(r) {
+    result, _ = util.ConvertYAMLToJSON(result)
+    w.Header().Set("Content-Type", MimeJSON)
+  }
+
+  w.WriteHeader(status)
+  w.Write(result)
+}
+
+//------------------------------------------------------------------------------
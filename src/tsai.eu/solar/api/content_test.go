@@ -0,0 +1,93 @@
+package api
+
+import (
+  "strings"
+  "testing"
+  "net/http"
+  "encoding/json"
+  "net/http/httptest"
+  "github.com/gorilla/mux"
+)
+
+//------------------------------------------------------------------------------
+
+// TestContent01 verifies the negotiation of the media type of responses.
+func TestContent01(t *testing.T) {
+  tests := []struct {
+    accept string
+    json   bool
+  }{
+    {"",                                          false},
+    {"*/*",                                       false},
+    {"application/json",                          true},
+    {"application/json, text/plain, */*",         true},
+    {"application/x-yaml",                        false},
+    {"application/json;q=0.5, application/yaml",  false},
+    {"application/yaml;q=0.5, application/json",  true},
+    {"application/problem+json",                  true},
+  }
+
+  for index, test := range tests {
+    req := httptest.NewRequest("GET", "/", nil)
+    req.Header.Set("Accept", test.accept)
+
+    if acceptsJSON(req) != test.json {
+      t.Errorf("%d: '%s' should have resulted in JSON: %v", index, test.accept, test.json)
+    }
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// TestContent02 verifies JSON requests, responses and error bodies.
+func TestContent02(t *testing.T) {
+  var result  map[string]interface{}
+  var failure Error
+
+  router := mux.NewRouter()
+  router.HandleFunc("/content", func(w http.ResponseWriter, r *http.Request) {
+    body, err := readBody(r)
+    if err != nil {
+      writeError(w, r, http.StatusBadRequest, "unable to read body", err)
+      return
+    }
+    writeResult(w, r, body)
+  }).Methods("POST")
+
+  // JSON request and response
+  req := httptest.NewRequest("POST", "/content", strings.NewReader(`{"Name": "app", "Size": 3}`))
+  req.Header.Set("Content-Type", "application/json")
+  req.Header.Set("Accept",       "application/json")
+
+  rec := httptest.NewRecorder()
+  router.ServeHTTP(rec, req)
+
+  if rec.Header().Get("Content-Type") != MimeJSON || json.Unmarshal(rec.Body.Bytes(), &result) != nil || result["Size"] != 3.0 {
+    t.Errorf("the request should have been answered with JSON:\n%s", rec.Body.String())
+  }
+
+  // JSON request and YAML response
+  req = httptest.NewRequest("POST", "/content", strings.NewReader(`{"Name": "app"}`))
+  req.Header.Set("Content-Type", "application/json")
+
+  rec = httptest.NewRecorder()
+  router.ServeHTTP(rec, req)
+
+  if rec.Header().Get("Content-Type") != MimeYAML || rec.Body.String() != "Name: app\n" {
+    t.Errorf("the request should have been answered with YAML:\n%s", rec.Body.String())
+  }
+
+  // structured error
+  req = httptest.NewRequest("POST", "/content", strings.NewReader(`{"Name":`))
+  req.Header.Set("Content-Type", "application/json")
+  req.Header.Set("Accept",       "application/json")
+
+  rec = httptest.NewRecorder()
+  router.ServeHTTP(rec, req)
+
+  if rec.Code != http.StatusBadRequest || json.Unmarshal(rec.Body.Bytes(), &failure) != nil || failure.Status != http.StatusBadRequest || failure.Message != "unable to read body" {
+    t.Errorf("the request should have been rejected with a structured error:\n%s", rec.Body.String())
+  }
+}
+
+//------------------------------------------------------------------------------
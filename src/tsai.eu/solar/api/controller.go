@@ -1,14 +1,11 @@
 package api
 
 import (
-  "io"
-  "io/ioutil"
   "net/http"
 
   "github.com/gorilla/mux"
 
   "tsai.eu/solar/model"
-  "tsai.eu/solar/util"
 )
 
 //------------------------------------------------------------------------------
@@ -21,7 +18,7 @@ func ControllerListHandler(w http.ResponseWriter, r *http.Request) {
   // determine domain
   domain, err := model.GetDomain(domainName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
     return
   }
 
@@ -30,7 +27,7 @@ func ControllerListHandler(w http.ResponseWriter, r *http.Request) {
 
   cNameVersions, err := domain.ListControllers()
   if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
+    writeError(w, r, http.StatusInternalServerError, "controllers can not be listed", err)
     return
   }
 
@@ -40,15 +37,8 @@ func ControllerListHandler(w http.ResponseWriter, r *http.Request) {
     controllers = append(controllers, controller)
   }
 
-  // convert to yaml
-  yaml, err := util.ConvertToYAML(controllers)
-  if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
-    return
-  }
-
   // return the result
-  io.WriteString(w, yaml)
+  writeEntity(w, r, controllers)
 }
 
 //------------------------------------------------------------------------------
@@ -59,32 +49,32 @@ func ControllerSetHandler(w http.ResponseWriter, r *http.Request) {
   domainName := vars["domain"]
 
   // get yaml
-  body, err := ioutil.ReadAll(r.Body)
+  body, err := readBody(r)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "unable to read controller", err)
     return
   }
 
   // determine domain
   domain, err := model.GetDomain(domainName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
     return
   }
 
   // create new controller
   controller, _ := model.NewController("","")
 
-  err = controller.Load2(string(body))
+  err = controller.Load2(body)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "unable to parse controller", err)
     return
   }
 
   // add controller to domain
   err = domain.AddController(controller)
   if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
+    writeError(w, r, http.StatusInternalServerError, "unable to add controller", err)
     return
   }
 }
@@ -101,20 +91,19 @@ func ControllerGetHandler(w http.ResponseWriter, r *http.Request) {
   // determine controller
   controller, err := model.GetController(domainName, controllerName, controllerVersion)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "controller can not be identified", err)
     return
   }
 
   // transform controller to string
   yaml, err := controller.Show()
   if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
+    writeError(w, r, http.StatusInternalServerError, "controller can not be displayed", err)
     return
   }
 
   // write yaml
-  // w.Header().Set("Content-Type", "application/x-yaml")
-  io.WriteString(w, yaml)
+  writeResult(w, r, yaml)
 }
 
 //------------------------------------------------------------------------------
@@ -129,14 +118,14 @@ func ControllerDeleteHandler(w http.ResponseWriter, r *http.Request) {
   // determine domain
   domain, err := model.GetDomain(domainName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
     return
   }
 
   // delete controller
   err = domain.DeleteController(controllerName, controllerVersion)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "controller can not be deleted", err)
     return
   }
 }
@@ -1,14 +1,11 @@
 package api
 
 import (
-  "io"
-  "io/ioutil"
   "net/http"
 
   "github.com/gorilla/mux"
 
   "tsai.eu/solar/model"
-  "tsai.eu/solar/util"
 )
 
 //------------------------------------------------------------------------------
@@ -19,21 +16,12 @@ func DomainListHandler(w http.ResponseWriter, r *http.Request) {
 
   // check validity of input
   if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
-    return
-  }
-
-  // load yaml model
-  result, err := util.ConvertToYAML(domains)
-
-  // check validity of result
-  if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
+    writeError(w, r, http.StatusInternalServerError, "domains can not be listed", err)
     return
   }
 
   // return the result
-  io.WriteString(w, result)
+  writeEntity(w, r, domains)
 }
 
 //------------------------------------------------------------------------------
@@ -50,7 +38,7 @@ func DomainCreateHandler(w http.ResponseWriter, r *http.Request) {
 
   // check validity of result
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "unable to create domain", err)
     return
   }
 }
@@ -67,7 +55,7 @@ func DomainDeleteHandler(w http.ResponseWriter, r *http.Request) {
 
   // check validity of result
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "domain can not be deleted", err)
     return
   }
 }
@@ -76,22 +64,22 @@ func DomainDeleteHandler(w http.ResponseWriter, r *http.Request) {
 
 // DomainSetHandler handles the uploading of a new domain.
 func DomainSetHandler(w http.ResponseWriter, r *http.Request) {
-  body, err := ioutil.ReadAll(r.Body)
+  body, err := readBody(r)
 
   // check validity of input
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "unable to read domain", err)
     return
   }
 
   // load yaml domain
   domain, _ := model.NewDomain("dummy")
 
-  err = domain.Load2(string(body))
+  err = domain.Load2(body)
 
   // check validity of input
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "unable to parse domain", err)
     return
   }
 
@@ -100,7 +88,7 @@ func DomainSetHandler(w http.ResponseWriter, r *http.Request) {
 
   // check success of import
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "unable to add domain", err)
     return
   }
 }
@@ -117,7 +105,7 @@ func DomainGetHandler(w http.ResponseWriter, r *http.Request) {
 
   // check validity of result
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
     return
   }
 
@@ -126,13 +114,11 @@ func DomainGetHandler(w http.ResponseWriter, r *http.Request) {
 
   // check validity of result
   if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
+    writeError(w, r, http.StatusInternalServerError, "domain can not be displayed", err)
     return
   }
 
-  // w.Header().Set("Content-Type", "application/x-yaml")
-
-  io.WriteString(w, result)
+  writeResult(w, r, result)
 }
 
 //------------------------------------------------------------------------------
@@ -147,25 +133,19 @@ func DomainResetHandler(w http.ResponseWriter, r *http.Request) {
 
   // check validity of result
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "domain can not be deleted", err)
     return
   }
 
   // create new domain
   domain, _ := model.NewDomain(domainName)
 
-  // check if domain has been created
-  if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
-    return
-  }
-
   // add domain to model
   err = model.GetModel().AddDomain(domain)
 
   // check success of import
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "unable to reset domain", err)
     return
   }
 }
@@ -1,8 +1,6 @@
 package api
 
 import (
-  "io"
-  "io/ioutil"
   "net/http"
 
   "github.com/gorilla/mux"
@@ -32,41 +30,40 @@ func InstanceUpdateHandler(w http.ResponseWriter, r *http.Request) {
   config        := InstanceUpdateInformation{}
 
   // determine desired configuration
-  body, err := ioutil.ReadAll(r.Body)
+  yaml, err := readBody(r)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
-    io.WriteString(w, "unable to read target configuration:\n" + err.Error())
+    writeError(w, r, http.StatusBadRequest, "unable to read target configuration", err)
     return
   }
 
-  yaml := string(body)
-
-  util.ConvertFromYAML(yaml, &config)
+  err = util.ConvertFromYAML(yaml, &config)
+  if err != nil {
+    writeError(w, r, http.StatusBadRequest, "unable to parse target configuration", err)
+    return
+  }
 
   // determine solution
   solution, err := model.GetSolution(domainName, solutionName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
-    io.WriteString(w, "unable to determine solution")
+    writeError(w, r, http.StatusBadRequest, "unable to determine solution", err)
     return
   }
 
   // create task and start it by signalling an event
 	task, err := engine.NewInstanceTask(domainName, "", solutionName, solution.Version, elementName, clusterName, instanceName, config.State)
 	if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
-    io.WriteString(w, "task can not be created:\n" + err.Error())
+    writeError(w, r, http.StatusInternalServerError, "task can not be created", err)
 		return
 	}
 
-	// get event channel
-	channel := engine.GetEventChannel()
+	// get event queue
+	queue := engine.GetEventQueue()
 
 	// create event
-	channel <- model.NewEvent(domainName, task.UUID, model.EventTypeTaskExecution, "", "initial")
+	queue.Push(model.NewEvent(domainName, task.UUID, model.EventTypeTaskExecution, "", "initial"))
 
   // return the uuid of the task
-  io.WriteString(w, task.UUID)
+  writeResult(w, r, task.UUID)
 }
 
 //------------------------------------------------------------------------------
@@ -1,8 +1,6 @@
 package api
 
 import (
-  "io"
-  "io/ioutil"
   "net/http"
 
   "tsai.eu/solar/model"
@@ -12,20 +10,20 @@ import (
 
 // ModelSetHandler handles the uploading of a new model.
 func ModelSetHandler(w http.ResponseWriter, r *http.Request) {
-  body, err := ioutil.ReadAll(r.Body)
+  body, err := readBody(r)
 
   // check validity of input
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "unable to read model", err)
     return
   }
 
   // load yaml model
-  err = model.GetModel().Load2(string(body))
+  err = model.GetModel().Load2(body)
 
   // check success of import
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "unable to load model", err)
     return
   }
 }
@@ -34,11 +32,15 @@ func ModelSetHandler(w http.ResponseWriter, r *http.Request) {
 
 // ModelGetHandler retrieves the current model.
 func ModelGetHandler(w http.ResponseWriter, r *http.Request) {
-  result, _ := model.GetModel().Show()
+  result, err := model.GetModel().Show()
 
-  // w.Header().Set("Content-Type", "application/x-yaml")
+  // check validity of result
+  if err != nil {
+    writeError(w, r, http.StatusInternalServerError, "model can not be displayed", err)
+    return
+  }
 
-  io.WriteString(w, result)
+  writeResult(w, r, result)
 }
 
 //------------------------------------------------------------------------------
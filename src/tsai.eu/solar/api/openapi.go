@@ -0,0 +1,306 @@
+package api
+
+import (
+  "regexp"
+  "reflect"
+  "strings"
+  "net/http"
+
+  "tsai.eu/solar/model"
+  "tsai.eu/solar/engine"
+  ctrl "tsai.eu/solar/controller"
+)
+
+//------------------------------------------------------------------------------
+
+// Operation documents a route of the API.
+type Operation struct {
+  Method   string      // http method
+  Path     string      // path template
+  Tag      string      // group of the operation
+  Summary  string      // short description
+  Query    []string    // supported query parameters
+  Request  interface{} // example of the request body (nil if no body is expected)
+  Response interface{} // example of the response body (nil if no body is returned)
+}
+
+//------------------------------------------------------------------------------
+
+// plainText describes bodies which are exchanged as plain text.
+type plainText string
+
+// eventStream describes responses which are streamed as server-sent events.
+type eventStream struct {
+  model.Notification
+}
+
+// taskUUID is the response of operations which start a task.
+var taskUUID = "uuid of the task"
+
+// Operations lists all routes registered in NewRouter.
+var Operations = []Operation{
+  {"GET",    "/openapi.yaml", "api", "OpenAPI document of the API", nil, nil, map[string]interface{}{}},
+
+  {"POST",   "/model", "model", "Load a model",       nil, &model.Model{}, nil},
+  {"GET",    "/model", "model", "Retrieve the model", nil, nil, &model.Model{}},
+  {"PUT",    "/model", "model", "Reset the model",    nil, nil, nil},
+
+  {"GET",    "/queue", "queue", "Retrieve the metrics of the event queue", nil, nil, &engine.QueueMetrics{}},
+
+  {"GET",    "/domain",          "domain", "List the domains",           nil, nil, []string{}},
+  {"POST",   "/domain",          "domain", "Add a domain",               nil, &model.Domain{}, nil},
+  {"POST",   "/domain/{domain}", "domain", "Create an empty domain",     nil, nil, nil},
+  {"GET",    "/domain/{domain}", "domain", "Retrieve a domain",          nil, nil, &model.Domain{}},
+  {"PUT",    "/domain/{domain}", "domain", "Reset a domain",             nil, nil, nil},
+  {"DELETE", "/domain/{domain}", "domain", "Delete a domain",            nil, nil, nil},
+
+  {"GET",    "/catalog/{domain}", "component", "Retrieve the catalog of components of a domain", nil, nil, []*model.Component{}},
+
+  {"GET",    "/component/{domain}",                       "component", "List the components of a domain", nil, nil, [][2]string{}},
+  {"POST",   "/component/{domain}",                       "component", "Add a component",                 nil, &model.Component{}, nil},
+  {"GET",    "/component/{domain}/{component}/{version}", "component", "Retrieve a component",            nil, nil, &model.Component{}},
+  {"DELETE", "/component/{domain}/{component}/{version}", "component", "Delete a component",              nil, nil, nil},
+
+  {"GET",    "/architecture/{domain}",                          "architecture", "List the architectures of a domain",                          nil, nil, []string{}},
+  {"POST",   "/architecture/{domain}",                          "architecture", "Validate and add an architecture",                            nil, &model.Architecture{}, nil},
+  {"POST",   "/architecture/{domain}/validate",                 "architecture", "Validate an architecture without adding it",                  nil, &model.Architecture{}, &model.Validation{}},
+  {"GET",    "/architecture/{domain}/{architecture}/{version}", "architecture", "Retrieve an architecture",                                    nil, nil, &model.Architecture{}},
+  {"DELETE", "/architecture/{domain}/{architecture}/{version}", "architecture", "Delete an architecture",                                      nil, nil, nil},
+  {"POST",   "/architecture/{domain}/{architecture}/{version}", "architecture", "Deploy an architecture (returns the plan if dryrun is true)", []string{"dryrun"}, nil, &taskUUID},
+
+  {"GET",    "/solution/{domain}",                      "solution", "List the solutions of a domain",                             nil, nil, []string{}},
+  {"POST",   "/solution/{domain}",                      "solution", "Add a solution",                                             nil, &model.Solution{}, nil},
+  {"GET",    "/solution/{domain}/{solution}",           "solution", "Retrieve a solution",                                        nil, nil, &model.Solution{}},
+  {"DELETE", "/solution/{domain}/{solution}",           "solution", "Delete a solution",                                          nil, nil, nil},
+  {"POST",   "/solution/{domain}/{solution}/rollback",  "solution", "Re-apply the last successful deployment of a solution",      nil, nil, &taskUUID},
+  {"GET",    "/solution/{domain}/{solution}/drift",     "solution", "List the instances of a solution with configuration drift", nil, nil, []*model.Drift{}},
+  {"POST",   "/solution/{domain}/{solution}/{version}", "solution", "Deploy a version of a solution (returns the plan if dryrun is true)", []string{"dryrun"}, nil, &taskUUID},
+
+  {"PUT",    "/cluster/{domain}/{solution}/{element}/{cluster}",             "solution", "Update the target state and size of a cluster", nil, &ClusterUpdateInformation{},  &taskUUID},
+  {"PUT",    "/instance/{domain}/{solution}/{element}/{cluster}/{instance}", "solution", "Update the target state of an instance",        nil, &InstanceUpdateInformation{}, &taskUUID},
+
+  {"GET",    "/controller/{domain}",                        "controller", "List the controllers of a domain", nil, nil, []*model.Controller{}},
+  {"POST",   "/controller/{domain}",                        "controller", "Add a controller",                 nil, &model.Controller{}, nil},
+  {"GET",    "/controller/{domain}/{controller}/{version}", "controller", "Retrieve a controller",            nil, nil, &model.Controller{}},
+  {"DELETE", "/controller/{domain}/{controller}/{version}", "controller", "Delete a controller",              nil, nil, nil},
+
+  {"POST",   "/operation/{domain}/{operation}", "controller", "Report the result of an asynchronous controller operation", nil, &ctrl.Response{}, nil},
+
+  {"GET",    "/secret/{domain}",          "secret", "List the names of the secrets of a domain",  nil, nil, []string{}},
+  {"POST",   "/secret/{domain}/{secret}", "secret", "Set the value of a secret provided as body", nil, new(plainText), nil},
+  {"DELETE", "/secret/{domain}/{secret}", "secret", "Delete a secret",                            nil, nil, nil},
+
+  {"GET",    "/audit/{domain}", "audit", "Retrieve the audit log of a domain", []string{"offset", "limit"}, nil, []*model.AuditEntry{}},
+
+  {"GET",    "/stream/{domain}", "stream", "Stream the events and state changes of a domain as server-sent events", []string{"solution", "element"}, nil, &eventStream{}},
+
+  {"GET",    "/tasks/{domain}",                                           "task", "List the tasks of a domain",    nil, nil, []*TaskSummary{}},
+  {"GET",    "/tasks/{domain}/{solution}",                                "task", "List the tasks of a solution",  nil, nil, []*TaskSummary{}},
+  {"GET",    "/tasks/{domain}/{solution}/{element}",                      "task", "List the tasks of an element",  nil, nil, []*TaskSummary{}},
+  {"GET",    "/tasks/{domain}/{solution}/{element}/{cluster}",            "task", "List the tasks of a cluster",   nil, nil, []*TaskSummary{}},
+  {"GET",    "/tasks/{domain}/{solution}/{element}/{cluster}/{instance}", "task", "List the tasks of an instance", nil, nil, []*TaskSummary{}},
+
+  {"GET",    "/task/{domain}/{task}",         "task", "Retrieve the trace of a task",                     nil, nil, &model.Trace{}},
+  {"GET",    "/task/{domain}/{task}/{level}", "task", "Retrieve a task and its subtasks up to a level",   nil, nil, &model.TaskInfo{}},
+  {"DELETE", "/task/{domain}/{task}",         "task", "Terminate a task",                                 nil, nil, nil},
+}
+
+//------------------------------------------------------------------------------
+
+// pathParameter matches the parameters of a path template.
+var pathParameter = regexp.MustCompile(`{([^}]+)}`)
+
+//------------------------------------------------------------------------------
+
+// OpenAPIHandler returns the OpenAPI document describing the API.
+func OpenAPIHandler(w http.ResponseWriter, r *http.Request) {
+  writeEntity(w, r, NewOpenAPI())
+}
+
+//------------------------------------------------------------------------------
+
+// NewOpenAPI creates an OpenAPI 3 document from the list of operations. The
+// schemas of the request and response bodies are derived from their types.
+func NewOpenAPI() map[string]interface{} {
+  schemas := map[string]interface{}{}
+  paths   := map[string]interface{}{}
+
+  for _, operation := range Operations {
+    path, ok := paths[operation.Path].(map[string]interface{})
+    if !ok {
+      path = map[string]interface{}{}
+      paths[operation.Path] = path
+    }
+
+    // parameters
+    parameters := []interface{}{}
+    for _, match := range pathParameter.FindAllStringSubmatch(operation.Path, -1) {
+      parameters = append(parameters, map[string]interface{}{
+        "name":     match[1],
+        "in":       "path",
+        "required": true,
+        "schema":   map[string]interface{}{"type": "string"},
+      })
+    }
+    for _, query := range operation.Query {
+      parameters = append(parameters, map[string]interface{}{
+        "name":   query,
+        "in":     "query",
+        "schema": map[string]interface{}{"type": "string"},
+      })
+    }
+
+    // responses
+    success := map[string]interface{}{"description": "success"}
+    if operation.Response != nil {
+      success["content"] = newContent(operation.Response, schemas)
+    }
+
+    failure := map[string]interface{}{
+      "description": "failure",
+      "content":     newContent(&Error{}, schemas),
+    }
+
+    entry := map[string]interface{}{
+      "operationId": operationID(operation),
+      "summary":     operation.Summary,
+      "tags":        []string{operation.Tag},
+      "responses":   map[string]interface{}{"200": success, "default": failure},
+    }
+    if len(parameters) > 0 {
+      entry["parameters"] = parameters
+    }
+    if operation.Request != nil {
+      entry["requestBody"] = map[string]interface{}{
+        "required": true,
+        "content":  newContent(operation.Request, schemas),
+      }
+    }
+
+    path[strings.ToLower(operation.Method)] = entry
+  }
+
+  // success
+  return map[string]interface{}{
+    "openapi": "3.0.3",
+    "info":    map[string]interface{}{
+      "title":   "SOLAR API",
+      "version": "1.0.0",
+    },
+    "paths":      paths,
+    "components": map[string]interface{}{
+      "schemas":         schemas,
+      "securitySchemes": map[string]interface{}{
+        "bearerAuth": map[string]interface{}{"type": "http", "scheme": "bearer"},
+      },
+    },
+    "security": []interface{}{map[string]interface{}{"bearerAuth": []string{}}},
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// operationID derives a unique identifier of an operation from its method and
+// the static segments of its path.
+func operationID(operation Operation) string {
+  id := strings.ToLower(operation.Method)
+
+  for _, segment := range strings.Split(operation.Path, "/") {
+    segment = strings.Replace(segment, ".", "", -1)
+    if segment == "" {
+      continue
+    }
+    if strings.HasPrefix(segment, "{") {
+      segment = strings.Trim(segment, "{}")
+      id     += "By"
+    }
+    id += strings.ToUpper(segment[:1]) + segment[1:]
+  }
+
+  return id
+}
+
+//------------------------------------------------------------------------------
+
+// newContent describes a body in all supported media types.
+func newContent(example interface{}, schemas map[string]interface{}) map[string]interface{} {
+  if _, ok := example.(*eventStream); ok {
+    schema := newSchema(reflect.TypeOf(model.Notification{}), schemas, false)
+    return map[string]interface{}{MimeEventStream: map[string]interface{}{"schema": schema}}
+  }
+
+  schema := newSchema(reflect.TypeOf(example), schemas, false)
+
+  if _, ok := example.(*plainText); ok {
+    return map[string]interface{}{"text/plain": map[string]interface{}{"schema": schema}}
+  }
+
+  return map[string]interface{}{
+    MimeYAML: map[string]interface{}{"schema": schema},
+    MimeJSON: map[string]interface{}{"schema": schema},
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// newSchema derives the schema of a type from its yaml tags. Named structures
+// are registered as components and referenced.
+func newSchema(t reflect.Type, schemas map[string]interface{}, inline bool) map[string]interface{} {
+  for t.Kind() == reflect.Ptr {
+    t = t.Elem()
+  }
+
+  switch t.Kind() {
+  case reflect.String:
+    return map[string]interface{}{"type": "string"}
+  case reflect.Bool:
+    return map[string]interface{}{"type": "boolean"}
+  case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+       reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+    return map[string]interface{}{"type": "integer"}
+  case reflect.Float32, reflect.Float64:
+    return map[string]interface{}{"type": "number"}
+  case reflect.Array:
+    return map[string]interface{}{"type": "array", "items": newSchema(t.Elem(), schemas, false), "minItems": t.Len(), "maxItems": t.Len()}
+  case reflect.Slice:
+    return map[string]interface{}{"type": "array", "items": newSchema(t.Elem(), schemas, false)}
+  case reflect.Map:
+    return map[string]interface{}{"type": "object", "additionalProperties": newSchema(t.Elem(), schemas, false)}
+  case reflect.Struct:
+    // refer to named structures
+    if !inline && t.Name() != "" {
+      if _, ok := schemas[t.Name()]; !ok {
+        schemas[t.Name()] = nil
+        schemas[t.Name()] = newSchema(t, schemas, true)
+      }
+      return map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+    }
+
+    properties := map[string]interface{}{}
+    for index := 0; index < t.NumField(); index++ {
+      field := t.Field(index)
+
+      // ignore unexported fields and mutexes
+      if field.PkgPath != "" || field.Type.PkgPath() == "sync" {
+        continue
+      }
+
+      name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+      if name == "-" {
+        continue
+      }
+      if name == "" {
+        name = field.Name
+      }
+
+      properties[name] = newSchema(field.Type, schemas, false)
+    }
+
+    return map[string]interface{}{"type": "object", "properties": properties}
+  }
+
+  // arbitrary values
+  return map[string]interface{}{}
+}
+
+//------------------------------------------------------------------------------
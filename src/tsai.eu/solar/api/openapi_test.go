@@ -0,0 +1,65 @@
+package api
+
+import (
+  "strings"
+  "testing"
+
+  "github.com/gorilla/mux"
+)
+
+//------------------------------------------------------------------------------
+
+// TestOpenAPI01 verifies that the OpenAPI document describes all routes.
+func TestOpenAPI01(t *testing.T) {
+  document := NewOpenAPI()
+  paths    := document["paths"].(map[string]interface{})
+  routes   := map[string]bool{}
+
+  // all registered routes have to be documented
+  NewRouter().Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+    template, err := route.GetPathTemplate()
+    methods,  _   := route.GetMethods()
+    if err != nil || strings.HasPrefix(template, "/solar") || template == "/" {
+      return nil
+    }
+
+    for _, method := range methods {
+      routes[method + " " + template] = true
+
+      path, _ := paths[template].(map[string]interface{})
+      if _, ok := path[strings.ToLower(method)]; !ok {
+        t.Errorf("%s %s should have been documented", method, template)
+      }
+    }
+    return nil
+  })
+
+  // all documented operations have to be registered
+  operationIDs := map[string]bool{}
+  for _, operation := range Operations {
+    if !routes[operation.Method + " " + operation.Path] {
+      t.Errorf("%s %s should have been registered", operation.Method, operation.Path)
+    }
+
+    id := operationID(operation)
+    if operationIDs[id] {
+      t.Errorf("%s %s should have a unique operation id: %s", operation.Method, operation.Path, id)
+    }
+    operationIDs[id] = true
+  }
+
+  // schemas are derived from the types
+  schemas := document["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+  for _, name := range []string{"TaskSummary", "ClusterUpdateInformation", "Architecture", "ElementConfiguration", "Error", "Notification"} {
+    if schemas[name] == nil {
+      t.Errorf("schema %s should have been derived", name)
+    }
+  }
+
+  properties := schemas["Domain"].(map[string]interface{})["properties"].(map[string]interface{})
+  if _, ok := properties["ComponentsX"]; ok {
+    t.Errorf("schema Domain should not contain mutexes")
+  }
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,46 @@
+package api
+
+import (
+  "net/http"
+
+  "github.com/gorilla/mux"
+
+  "tsai.eu/solar/engine"
+  "tsai.eu/solar/util"
+  ctrl "tsai.eu/solar/controller"
+)
+
+//------------------------------------------------------------------------------
+
+// OperationCompleteHandler receives the final response of an asynchronous
+// operation from a controller and resumes the task waiting for the operation.
+func OperationCompleteHandler(w http.ResponseWriter, r *http.Request) {
+  vars          := mux.Vars(r)
+  domainName    := vars["domain"]
+  operationName := vars["operation"]
+
+  // get yaml
+  body, err := readBody(r)
+  if err != nil {
+    writeError(w, r, http.StatusBadRequest, "unable to read response", err)
+    return
+  }
+
+  // parse the response of the controller
+  response := ctrl.Response{}
+
+  err = util.ConvertFromYAML(body, &response)
+  if err != nil {
+    writeError(w, r, http.StatusBadRequest, "unable to parse response", err)
+    return
+  }
+
+  // resume the task
+  err = engine.CompleteOperation(domainName, operationName, &response)
+  if err != nil {
+    writeError(w, r, http.StatusNotFound, "operation can not be identified", err)
+    return
+  }
+}
+
+//------------------------------------------------------------------------------
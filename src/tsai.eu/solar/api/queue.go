@@ -0,0 +1,16 @@
+package api
+
+import (
+  "net/http"
+
+  "tsai.eu/solar/engine"
+)
+
+//------------------------------------------------------------------------------
+
+// QueueGetHandler retrieves the metrics of the event queue.
+func QueueGetHandler(w http.ResponseWriter, r *http.Request) {
+  writeEntity(w, r, engine.GetEventQueue().Metrics())
+}
+
+//------------------------------------------------------------------------------
@@ -53,11 +53,17 @@ func Start(ctx context.Context) (*API) {
 func NewRouter() *mux.Router {
   router := mux.NewRouter()
 
+  // api description
+  router.HandleFunc("/openapi.yaml", OpenAPIHandler).Methods("GET")
+
   // model
   router.HandleFunc("/model", ModelSetHandler).Methods("POST")
   router.HandleFunc("/model", ModelGetHandler).Methods("GET")
   router.HandleFunc("/model", ModelResetHandler).Methods("PUT")
 
+  // event queue
+  router.HandleFunc("/queue", QueueGetHandler).Methods("GET")
+
   // domain
   router.HandleFunc("/domain",          DomainListHandler).Methods("GET")
   router.HandleFunc("/domain/{domain}", DomainCreateHandler).Methods("POST")
@@ -78,6 +84,7 @@ func NewRouter() *mux.Router {
   // architecture
   router.HandleFunc("/architecture/{domain}",                          ArchitectureListHandler).Methods("GET")
   router.HandleFunc("/architecture/{domain}",                          ArchitectureSetHandler).Methods("POST")
+  router.HandleFunc("/architecture/{domain}/validate",                 ArchitectureValidateHandler).Methods("POST")
   router.HandleFunc("/architecture/{domain}/{architecture}/{version}", ArchitectureGetHandler).Methods("GET")
   router.HandleFunc("/architecture/{domain}/{architecture}/{version}", ArchitectureDeleteHandler).Methods("DELETE")
   router.HandleFunc("/architecture/{domain}/{architecture}/{version}", ArchitectureDeployHandler).Methods("POST")
@@ -87,6 +94,8 @@ func NewRouter() *mux.Router {
   router.HandleFunc("/solution/{domain}",                       SolutionSetHandler).Methods("POST")
   router.HandleFunc("/solution/{domain}/{solution}",            SolutionGetHandler).Methods("GET")
   router.HandleFunc("/solution/{domain}/{solution}",            SolutionDeleteHandler).Methods("DELETE")
+  router.HandleFunc("/solution/{domain}/{solution}/rollback",   SolutionRollbackHandler).Methods("POST")
+  router.HandleFunc("/solution/{domain}/{solution}/drift",      SolutionDriftHandler).Methods("GET")
   router.HandleFunc("/solution/{domain}/{solution}/{version}",  SolutionDeployHandler).Methods("POST")
 
   // cluster
@@ -101,6 +110,20 @@ func NewRouter() *mux.Router {
   router.HandleFunc("/controller/{domain}/{controller}/{version}", ControllerGetHandler).Methods("GET")
   router.HandleFunc("/controller/{domain}/{controller}/{version}", ControllerDeleteHandler).Methods("DELETE")
 
+  // asynchronous controller operations
+  router.HandleFunc("/operation/{domain}/{operation}", OperationCompleteHandler).Methods("POST")
+
+  // secret
+  router.HandleFunc("/secret/{domain}",          SecretListHandler).Methods("GET")
+  router.HandleFunc("/secret/{domain}/{secret}", SecretSetHandler).Methods("POST")
+  router.HandleFunc("/secret/{domain}/{secret}", SecretDeleteHandler).Methods("DELETE")
+
+  // audit
+  router.HandleFunc("/audit/{domain}", AuditGetHandler).Methods("GET")
+
+  // stream
+  router.HandleFunc("/stream/{domain}", StreamHandler).Methods("GET")
+
   // task
   router.HandleFunc("/tasks/{domain}/{solution}/{element}/{cluster}/{instance}", TaskListHandler).Methods("GET")
   router.HandleFunc("/tasks/{domain}/{solution}/{element}/{cluster}",            TaskListHandler).Methods("GET")
@@ -118,6 +141,21 @@ func NewRouter() *mux.Router {
   // static files
   router.PathPrefix("/solar/").Handler(http.StripPrefix("/solar/", http.FileServer(http.Dir("./static/"))))
 
+  // authenticate and authorize all requests
+  configuration, _ := util.GetConfiguration()
+
+  auth := NewAuthenticator(configuration.AUTH)
+  if !auth.Enabled() {
+    util.LogWarn("main", "API", "authentication is disabled")
+  }
+  router.Use(auth.Middleware)
+
+  // record all modifications in the audit log
+  router.Use(AuditMiddleware)
+
+  // remove the values of secrets from all responses
+  router.Use(RedactionMiddleware)
+
   return router
 }
 
@@ -0,0 +1,119 @@
+package api
+
+import (
+  "io/ioutil"
+  "net/http"
+
+  "github.com/gorilla/mux"
+
+  "tsai.eu/solar/model"
+  "tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// redactingWriter removes the values of secrets from a response.
+type redactingWriter struct {
+  http.ResponseWriter
+}
+
+// Write redacts the data before it is written to the response.
+func (w redactingWriter) Write(data []byte) (int, error) {
+  if _, err := w.ResponseWriter.Write([]byte(util.Redact(string(data)))); err != nil {
+    return 0, err
+  }
+  return len(data), nil
+}
+
+// Flush sends any buffered data to the client.
+func (w redactingWriter) Flush() {
+  if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+    flusher.Flush()
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// RedactionMiddleware removes the values of secrets from all API responses.
+func RedactionMiddleware(next http.Handler) http.Handler {
+  return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    next.ServeHTTP(redactingWriter{w}, r)
+  })
+}
+
+//------------------------------------------------------------------------------
+
+// SecretListHandler lists the names of the secrets of a domain.
+func SecretListHandler(w http.ResponseWriter, r *http.Request) {
+  vars       := mux.Vars(r)
+  domainName := vars["domain"]
+
+  // determine domain
+  domain, err := model.GetDomain(domainName)
+  if err != nil {
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
+    return
+  }
+
+  // determine list of secrets
+  secrets, _ := domain.ListSecrets()
+
+  // return the result
+  writeEntity(w, r, secrets)
+}
+
+//------------------------------------------------------------------------------
+
+// SecretSetHandler stores the value of a secret which is provided as body. The
+// value is stored as is regardless of the content type.
+func SecretSetHandler(w http.ResponseWriter, r *http.Request) {
+  vars       := mux.Vars(r)
+  domainName := vars["domain"]
+  secretName := vars["secret"]
+
+  // get value
+  body, err := ioutil.ReadAll(r.Body)
+  if err != nil {
+    writeError(w, r, http.StatusBadRequest, "unable to read secret", err)
+    return
+  }
+
+  // determine domain
+  domain, err := model.GetDomain(domainName)
+  if err != nil {
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
+    return
+  }
+
+  // store secret
+  err = domain.SetSecret(secretName, string(body))
+  if err != nil {
+    writeError(w, r, http.StatusBadRequest, "unable to set secret", err)
+    return
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// SecretDeleteHandler deletes a secret.
+func SecretDeleteHandler(w http.ResponseWriter, r *http.Request) {
+  vars       := mux.Vars(r)
+  domainName := vars["domain"]
+  secretName := vars["secret"]
+
+  // determine domain
+  domain, err := model.GetDomain(domainName)
+  if err != nil {
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
+    return
+  }
+
+  // delete secret
+  err = domain.DeleteSecret(secretName)
+  if err != nil {
+    writeError(w, r, http.StatusBadRequest, "secret can not be deleted", err)
+    return
+  }
+}
+
+//------------------------------------------------------------------------------
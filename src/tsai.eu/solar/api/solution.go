@@ -1,14 +1,11 @@
 package api
 
 import (
-  "io"
-  "io/ioutil"
   "net/http"
 
   "github.com/gorilla/mux"
 
   "tsai.eu/solar/model"
-  "tsai.eu/solar/util"
   "tsai.eu/solar/engine"
 )
 
@@ -22,26 +19,19 @@ func SolutionListHandler(w http.ResponseWriter, r *http.Request) {
   // determine domain
   domain, err := model.GetDomain(domainName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
     return
   }
 
   // determine solutions
   solutions, err := domain.ListSolutions()
   if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
-    return
-  }
-
-  // convert to yaml
-  yaml, err := util.ConvertToYAML(solutions)
-  if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
+    writeError(w, r, http.StatusInternalServerError, "solutions can not be listed", err)
     return
   }
 
   // return the result
-  io.WriteString(w, yaml)
+  writeEntity(w, r, solutions)
 }
 
 //------------------------------------------------------------------------------
@@ -52,32 +42,32 @@ func SolutionSetHandler(w http.ResponseWriter, r *http.Request) {
   domainName := vars["domain"]
 
   // get yaml
-  body, err := ioutil.ReadAll(r.Body)
+  body, err := readBody(r)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "unable to read solution", err)
     return
   }
 
   // determine domain
   domain, err := model.GetDomain(domainName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
     return
   }
 
   // create new solution
   solution, _ := model.NewSolution("","","")
 
-  err = solution.Load2(string(body))
+  err = solution.Load2(body)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "unable to parse solution", err)
     return
   }
 
   // add solution to domain
   err = domain.AddSolution(solution)
   if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
+    writeError(w, r, http.StatusInternalServerError, "unable to add solution", err)
     return
   }
 }
@@ -93,20 +83,19 @@ func SolutionGetHandler(w http.ResponseWriter, r *http.Request) {
   // determine solution
   solution, err := model.GetSolution(domainName, solutionName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "solution can not be identified", err)
     return
   }
 
   // transform solution to string
   yaml, err := solution.Show()
   if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
+    writeError(w, r, http.StatusInternalServerError, "solution can not be displayed", err)
     return
   }
 
   // write yaml
-  // w.Header().Set("Content-Type", "application/x-yaml")
-  io.WriteString(w, yaml)
+  writeResult(w, r, yaml)
 }
 
 //------------------------------------------------------------------------------
@@ -120,14 +109,14 @@ func SolutionDeleteHandler(w http.ResponseWriter, r *http.Request) {
   // determine domain
   domain, err := model.GetDomain(domainName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
     return
   }
 
   // delete solution
   err = domain.DeleteSolution(solutionName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "solution can not be deleted", err)
     return
   }
 }
@@ -144,43 +133,71 @@ func SolutionDeployHandler(w http.ResponseWriter, r *http.Request) {
   // determine domain
   domain, err := model.GetDomain(domainName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
     return
   }
 
   // determine architecture
   architecture, err := model.GetArchitecture(domainName, solutionName, versionNumber)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "architecture can not be identified", err)
     return
   }
 
-  // determine solution (create new solution if not found)
-  solution, err := domain.GetSolution(architecture.Architecture)
-  if err != nil {
-    solution, _ = model.NewSolution(architecture.Architecture, architecture.Version, "")
-
-    domain.AddSolution(solution)
+  // only determine the changes if requested
+  if r.URL.Query().Get("dryrun") == "true" {
+    writePlan(w, r, domain.Name, architecture)
+    return
   }
 
-  // update the target state of the solution
-  if err = solution.Update(domain.Name, architecture); err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
+  // update the solution and start a task
+  uuid, err := engine.Deploy(domain.Name, architecture)
+  if err != nil {
+    writeError(w, r, http.StatusInternalServerError, "solution can not be deployed", err)
     return
   }
 
-  // create task and start it by signalling an event
-  task, err := engine.NewSolutionTask(domain.Name, "", solution)
+  // return the uuid of the task
+  writeResult(w, r, uuid)
+}
+
+//------------------------------------------------------------------------------
+
+// SolutionRollbackHandler re-applies the last successful deployment of a solution.
+func SolutionRollbackHandler(w http.ResponseWriter, r *http.Request) {
+  vars         := mux.Vars(r)
+  domainName   := vars["domain"]
+  solutionName := vars["solution"]
+
+  // start a task which rolls back the solution
+  uuid, err := engine.Rollback(domainName, solutionName)
   if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
+    writeError(w, r, http.StatusBadRequest, "solution can not be rolled back", err)
     return
   }
 
-  // get event channel
-  channel := engine.GetEventChannel()
+  // return the uuid of the task
+  writeResult(w, r, uuid)
+}
+
+//------------------------------------------------------------------------------
+
+// SolutionDriftHandler lists the instances of a solution whose configuration
+// reported by their controller has drifted from the desired configuration.
+func SolutionDriftHandler(w http.ResponseWriter, r *http.Request) {
+  vars         := mux.Vars(r)
+  domainName   := vars["domain"]
+  solutionName := vars["solution"]
+
+  // determine the drifted instances
+  drifts, err := model.GetDrift(domainName, solutionName)
+  if err != nil {
+    writeError(w, r, http.StatusBadRequest, "solution can not be identified", err)
+    return
+  }
 
-  // create event
-  channel <- model.NewEvent(domain.Name, task.UUID, model.EventTypeTaskExecution, "", "initial")
+  // return the result
+  writeEntity(w, r, drifts)
 }
 
 //------------------------------------------------------------------------------
@@ -0,0 +1,83 @@
+package api
+
+import (
+  "time"
+  "net/http"
+  "encoding/json"
+
+  "github.com/gorilla/mux"
+
+  "tsai.eu/solar/model"
+)
+
+//------------------------------------------------------------------------------
+
+// MimeEventStream is the media type of server-sent events.
+const MimeEventStream string = "text/event-stream"
+
+// keepAliveInterval determines how often an idle stream is kept alive.
+var keepAliveInterval = 15 * time.Second
+
+//------------------------------------------------------------------------------
+
+// StreamHandler streams the events processed for a domain and the state
+// changes of its elements, clusters and instances as server-sent events. The
+// notifications can be restricted to a solution and an element.
+func StreamHandler(w http.ResponseWriter, r *http.Request) {
+  vars       := mux.Vars(r)
+  domainName := vars["domain"]
+  query      := r.URL.Query()
+
+  // check domain
+  if _, err := model.GetDomain(domainName); err != nil {
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
+    return
+  }
+
+  flusher, ok := w.(http.Flusher)
+  if !ok {
+    writeError(w, r, http.StatusInternalServerError, "streaming is not supported", nil)
+    return
+  }
+
+  // subscribe to the notifications
+  subscription := model.Subscribe(domainName, query.Get("solution"), query.Get("element"))
+  defer model.Unsubscribe(subscription)
+
+  w.Header().Set("Content-Type",  MimeEventStream)
+  w.Header().Set("Cache-Control", "no-cache")
+  w.Header().Set("Connection",    "keep-alive")
+  w.WriteHeader(http.StatusOK)
+  flusher.Flush()
+
+  keepAlive := time.NewTicker(keepAliveInterval)
+  defer keepAlive.Stop()
+
+  // forward the notifications until the client disconnects
+  for {
+    select {
+    case <-r.Context().Done():
+      return
+    case <-keepAlive.C:
+      if _, err := w.Write([]byte(": keep-alive\n\n")); err != nil {
+        return
+      }
+    case notification, ok := <-subscription.Channel:
+      if !ok {
+        return
+      }
+
+      data, err := json.Marshal(notification)
+      if err != nil {
+        continue
+      }
+
+      if _, err := w.Write([]byte("event: " + notification.Type + "\ndata: " + string(data) + "\n\n")); err != nil {
+        return
+      }
+    }
+    flusher.Flush()
+  }
+}
+
+//------------------------------------------------------------------------------
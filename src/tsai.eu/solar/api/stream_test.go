@@ -0,0 +1,55 @@
+package api
+
+import (
+  "bufio"
+  "strings"
+  "testing"
+  "net/http"
+  "net/http/httptest"
+
+  "tsai.eu/solar/model"
+)
+
+//------------------------------------------------------------------------------
+
+// TestStream01 verifies that notifications are streamed as server-sent events.
+func TestStream01(t *testing.T) {
+  model.GetModel().Load("testdata/model_001.yaml")
+
+  server := httptest.NewServer(NewRouter())
+  defer server.Close()
+
+  // unknown domain
+  resp, err := http.Get(server.URL + "/stream/unknown")
+  if err != nil {
+    t.Fatalf("request should have been executed:\n%s", err)
+  }
+  resp.Body.Close()
+  if resp.StatusCode != http.StatusBadRequest {
+    t.Errorf("stream of an unknown domain should have been rejected: %d", resp.StatusCode)
+  }
+
+  // stream of an element
+  resp, err = http.Get(server.URL + "/stream/demo?solution=app&element=web")
+  if err != nil {
+    t.Fatalf("request should have been executed:\n%s", err)
+  }
+  defer resp.Body.Close()
+
+  if resp.Header.Get("Content-Type") != MimeEventStream {
+    t.Errorf("response should have been an event stream: %s", resp.Header.Get("Content-Type"))
+  }
+
+  model.PublishState(model.NotificationCluster, "demo/app/db/V1", model.ActiveState)
+  model.PublishState(model.NotificationCluster, "demo/app/web/V1", model.ActiveState)
+
+  reader := bufio.NewReader(resp.Body)
+  event, _ := reader.ReadString('\n')
+  data,  _ := reader.ReadString('\n')
+
+  if event != "event: Cluster\n" || !strings.Contains(data, `"Element":"web"`) || !strings.Contains(data, `"State":"active"`) {
+    t.Errorf("state change of the element should have been streamed:\n%s%s", event, data)
+  }
+}
+
+//------------------------------------------------------------------------------
@@ -1,7 +1,7 @@
 package api
 
 import (
-  "io"
+  "errors"
   "net/http"
   "strconv"
   "time"
@@ -10,7 +10,6 @@ import (
   "github.com/gorilla/mux"
 
   "tsai.eu/solar/model"
-  "tsai.eu/solar/util"
   "tsai.eu/solar/engine"
 )
 
@@ -49,7 +48,7 @@ func TaskListHandler(w http.ResponseWriter, r *http.Request) {
   // determine domain
   domain, err := model.GetDomain(domainName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "domain can not be identified", err)
     return
   }
 
@@ -111,15 +110,8 @@ func TaskListHandler(w http.ResponseWriter, r *http.Request) {
     return tasks[i].Started > tasks[j].Started
   })
 
-  // convert list to yaml
-  yaml, err := util.ConvertToYAML(tasks)
-  if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
-    return
-  }
-
   // return the result
-  io.WriteString(w, yaml)
+  writeEntity(w, r, tasks)
 }
 
 //------------------------------------------------------------------------------
@@ -133,21 +125,14 @@ func TaskTraceHandler(w http.ResponseWriter, r *http.Request) {
   // determine task
   task, err := model.GetTask(domainName, taskName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "task can not be identified", err)
     return
   }
 
   // retrieve the task information
   trace := model.NewTrace(task)
-  yaml, err := util.ConvertToYAML(trace)
-  if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
-    return
-  }
 
-  // write yaml
-  // w.Header().Set("Content-Type", "application/x-yaml")
-  io.WriteString(w, yaml)
+  writeEntity(w, r, trace)
 }
 
 //------------------------------------------------------------------------------
@@ -162,7 +147,7 @@ func TaskGetHandler(w http.ResponseWriter, r *http.Request) {
   // determine task
   task, err := model.GetTask(domainName, taskName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "task can not be identified", err)
     return
   }
 
@@ -171,12 +156,12 @@ func TaskGetHandler(w http.ResponseWriter, r *http.Request) {
   if levelNumber != "" {
     value, err := strconv.Atoi(levelNumber)
     if err != nil {
-      w.WriteHeader(http.StatusBadRequest)
+      writeError(w, r, http.StatusBadRequest, "level is not a number", err)
       return
     }
 
     if value < 0 {
-      w.WriteHeader(http.StatusBadRequest)
+      writeError(w, r, http.StatusBadRequest, "level is negative", errors.New("invalid level: " + levelNumber))
       return
     }
     level = value
@@ -184,15 +169,8 @@ func TaskGetHandler(w http.ResponseWriter, r *http.Request) {
 
   // retrieve the task information
   taskinfo := model.NewTaskInfo(task, level)
-  yaml, err := util.ConvertToYAML(taskinfo)
-  if err != nil {
-    w.WriteHeader(http.StatusInternalServerError)
-    return
-  }
 
-  // write yaml
-  // w.Header().Set("Content-Type", "application/x-yaml")
-  io.WriteString(w, yaml)
+  writeEntity(w, r, taskinfo)
 }
 
 //------------------------------------------------------------------------------
@@ -206,16 +184,16 @@ func TaskTerminateHandler(w http.ResponseWriter, r *http.Request) {
   // determine task
   task, err := model.GetTask(domainName, taskName)
   if err != nil {
-    w.WriteHeader(http.StatusBadRequest)
+    writeError(w, r, http.StatusBadRequest, "task can not be identified", err)
     return
   }
 
   // execute the command
-  // get event channel
-  channel := engine.GetEventChannel()
+  // get event queue
+  queue := engine.GetEventQueue()
 
   // create event
-  channel <- model.NewEvent(domainName, task.UUID, model.EventTypeTaskTermination, "", "initial")
+  queue.Push(model.NewEvent(domainName, task.UUID, model.EventTypeTaskTermination, "", "initial"))
 }
 
 //------------------------------------------------------------------------------
@@ -1,8 +1,11 @@
 package cli
 
 import (
+	"strings"
+
 	ishell "gopkg.in/abiosoft/ishell.v2"
 	"tsai.eu/solar/engine"
+	"tsai.eu/solar/client"
 	"tsai.eu/solar/model"
 	"tsai.eu/solar/util"
 )
@@ -92,9 +95,49 @@ func ArchitectureCommand(context *ishell.Context, m *model.Model) {
 			return
 		}
 
+		// validate architecture
+		validation := model.ValidateArchitecture(domain, architecture)
+		if !validation.OK() {
+			result, _ := validation.Show()
+			handleResult(context, validation.Error(), "architecture is invalid:\n" + result, "")
+			return
+		}
+
 		// add architecture to domain
 		err = domain.AddArchitecture(architecture)
 		handleResult(context, err, "architecture could not be loaded", "")
+	case _validate:
+		// check availability of arguments
+		if len(context.Args) != 3 && len(context.Args) != 4 {
+			ArchitectureUsage(true, context)
+			return
+		}
+
+		// get domain
+		domain, err := m.GetDomain(context.Args[1])
+
+		if err != nil {
+			handleResult(context, err, "domain can not be identified", "")
+			return
+		}
+
+		// load architecture from a file or determine an existing architecture
+		architecture, _ := model.NewArchitecture("", "", "")
+
+		if len(context.Args) == 3 {
+			err = architecture.Load(context.Args[2])
+		} else {
+			architecture, err = domain.GetArchitecture(context.Args[2], context.Args[3])
+		}
+
+		if err != nil {
+			handleResult(context, err, "architecture could not be loaded", "")
+			return
+		}
+
+		// execute the command
+		result, err := model.ValidateArchitecture(domain, architecture).Show()
+		handleResult(context, err, "validation can not be displayed", result)
 	case _get:
 		// check availability of arguments
 		if len(context.Args) != 4 {
@@ -162,34 +205,198 @@ func ArchitectureCommand(context *ishell.Context, m *model.Model) {
 			return
 		}
 
-		// determine solution (create new solution if not found)
-		solution, err := domain.GetSolution(architecture.Architecture)
+		// update the solution and start a task
+		uuid, err := engine.Deploy(domain.Name, architecture)
+		handleResult(context, err, "architecture can not be executed", uuid)
+	case _plan:
+		// check availability of arguments
+		if len(context.Args) != 4 {
+			ArchitectureUsage(true, context)
+			return
+		}
+
+		// determine architecture
+		architecture, err := model.GetArchitecture(context.Args[1], context.Args[2], context.Args[3])
+
 		if err != nil {
-			solution, _ = model.NewSolution(architecture.Architecture, architecture.Version, "")
+			handleResult(context, err, "architecture can not be identified", "")
+			return
+		}
 
-			domain.AddSolution(solution)
+		// determine the changes without modifying the solution
+		plan, err := model.NewPlan(context.Args[1], architecture)
+		if err != nil {
+			handleResult(context, err, "plan can not be determined", "")
+			return
 		}
 
-		// update the target state of the solution
-		if err = solution.Update(domain.Name, architecture); err != nil {
-			handleResult(context, err, "unable to create or update the solution", "")
+		result, err := plan.Show()
+		handleResult(context, err, "plan can not be displayed", result)
+	default:
+		ArchitectureUsage(true, context)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// RemoteArchitectureCommand executes the architecture related subcommands on a remote server
+func RemoteArchitectureCommand(context *ishell.Context, c *client.Client) {
+	// check if the action has been defined
+	if len(context.Args) < 1 {
+		ArchitectureUsage(true, context)
+		return
+	}
+
+	// determine the required action
+	action := context.Args[0]
+
+	// handle required action
+	switch action {
+	case "?":
+		ArchitectureUsage(true, context)
+	case _list:
+		// check availability of arguments
+		if len(context.Args) < 2 || 4 < len(context.Args) {
+			ArchitectureUsage(true, context)
 			return
 		}
 
-		// create task and start it by signalling an event
-		task, err := engine.NewSolutionTask(domain.Name, "", solution)
+		// set architecture name and version filter
+		architectureName := ""
+		if len(context.Args) >= 3 {
+			architectureName = context.Args[2]
+		}
+
+		versionName := ""
+		if len(context.Args) == 4 {
+			versionName = context.Args[3]
+		}
+
+		// determine list of architecture names
+		aNameVersions, err := c.ListArchitectures(context.Args[1])
 		if err != nil {
-			handleResult(context, err, "task can not be created", "")
+			handleResult(context, err, "domain can not be identified", "")
 			return
 		}
 
-		// get event channel
-		channel := engine.GetEventChannel()
+		architectures := []string{}
+		for _, aNameVersion := range aNameVersions {
+			names := strings.SplitN(aNameVersion, " - ", 2)
+			if len(names) != 2 {
+				continue
+			}
 
-		// create event
-		channel <- model.NewEvent(domain.Name, task.UUID, model.EventTypeTaskExecution, "", "initial")
+			if (architectureName == "" || architectureName == names[0]) &&
+			   (versionName      == "" || versionName      == names[1]) {
+				architectures = append(architectures, aNameVersion)
+			}
+		}
+
+		result, err := util.ConvertToYAML(architectures)
+		handleResult(context, err, "architectures could not be listed", result)
+	case _set:
+		// check availability of arguments
+		if len(context.Args) != 3 {
+			ArchitectureUsage(true, context)
+			return
+		}
+
+		// load architecture
+		architecture, _ := model.NewArchitecture("", "", "")
+
+		err := architecture.Load(context.Args[2])
+		if err != nil {
+			handleResult(context, err, "architecture could not be loaded", "")
+			return
+		}
+
+		// validate and add architecture to domain
+		err = c.SetArchitecture(context.Args[1], architecture)
+		handleResult(context, err, "architecture could not be loaded", "")
+	case _validate:
+		// check availability of arguments
+		if len(context.Args) != 3 && len(context.Args) != 4 {
+			ArchitectureUsage(true, context)
+			return
+		}
+
+		// load architecture from a file or determine an existing architecture
+		architecture, _ := model.NewArchitecture("", "", "")
+
+		var err error
+		if len(context.Args) == 3 {
+			err = architecture.Load(context.Args[2])
+		} else {
+			architecture, err = c.GetArchitecture(context.Args[1], context.Args[2], context.Args[3])
+		}
+
+		if err != nil {
+			handleResult(context, err, "architecture could not be loaded", "")
+			return
+		}
+
+		// execute the command
+		validation, err := c.ValidateArchitecture(context.Args[1], architecture)
+		if err != nil {
+			handleResult(context, err, "architecture can not be validated", "")
+			return
+		}
+
+		result, err := validation.Show()
+		handleResult(context, err, "validation can not be displayed", result)
+	case _get:
+		// check availability of arguments
+		if len(context.Args) != 4 {
+			ArchitectureUsage(true, context)
+			return
+		}
+
+		// determine architecture
+		architecture, err := c.GetArchitecture(context.Args[1], context.Args[2], context.Args[3])
+		if err != nil {
+			handleResult(context, err, "architecture can not be identified", "")
+			return
+		}
+
+		// execute the command
+		result, err := architecture.Show()
+		handleResult(context, err, "architecture can not be displayed", result)
+	case _delete:
+		// check availability of arguments
+		if len(context.Args) != 4 {
+			ArchitectureUsage(true, context)
+			return
+		}
+
+		// execute command
+		err := c.DeleteArchitecture(context.Args[1], context.Args[2], context.Args[3])
+		handleResult(context, err, "architecture can not be deleted", "architecture has been deleted")
+	case _deploy:
+		// check availability of arguments
+		if len(context.Args) != 4 {
+			ArchitectureUsage(true, context)
+			return
+		}
+
+		// update the solution and start a task
+		uuid, err := c.DeployArchitecture(context.Args[1], context.Args[2], context.Args[3])
+		handleResult(context, err, "architecture can not be executed", uuid)
+	case _plan:
+		// check availability of arguments
+		if len(context.Args) != 4 {
+			ArchitectureUsage(true, context)
+			return
+		}
+
+		// determine the changes without modifying the solution
+		plan, err := c.PlanArchitecture(context.Args[1], context.Args[2], context.Args[3])
+		if err != nil {
+			handleResult(context, err, "plan can not be determined", "")
+			return
+		}
 
-		handleResult(context, nil, "architecture can not be executed", task.UUID)
+		result, err := plan.Show()
+		handleResult(context, err, "plan can not be displayed", result)
 	default:
 		ArchitectureUsage(true, context)
 	}
@@ -205,9 +412,12 @@ func ArchitectureUsage(header bool, context *ishell.Context) {
 	}
 	info += "  architecture list <domain> <architecture> <version>\n"
 	info += "               set <domain> <filename>\n"
+	info += "               validate <domain> <filename>\n"
+	info += "               validate <domain> <architecture> <version>\n"
 	info += "               get <domain> <architecture> <version>\n"
 	info += "               delete <domain> <architecture> <version>\n"
 	info += "               deploy <domain> <architecture> <version>\n"
+	info += "               plan <domain> <architecture> <version>\n"
 
   writeInfo(context, info)
 }
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"os"
+	"errors"
+	"os/user"
+	"strconv"
+
+	ishell "gopkg.in/abiosoft/ishell.v2"
+	"tsai.eu/solar/client"
+	"tsai.eu/solar/model"
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// AuditCommand displays the audit log of a domain
+func AuditCommand(context *ishell.Context, m *model.Model) {
+	// check availability of arguments
+	if len(context.Args) < 1 || 3 < len(context.Args) || context.Args[0] == "?" {
+		AuditUsage(true, context)
+		return
+	}
+
+	// determine the page of the audit log
+	offset, limit, err := auditPage(context.Args[1:])
+	if err != nil {
+		handleResult(context, err, "invalid page of the audit log (not a non-negative integer)", "")
+		return
+	}
+
+	// execute command
+	entries := model.GetAudit(context.Args[0], offset, limit)
+	result, err := util.ConvertToYAML(entries)
+	handleResult(context, err, "audit log could not be displayed", result)
+}
+
+//------------------------------------------------------------------------------
+
+// RemoteAuditCommand displays the audit log of a domain of a remote server
+func RemoteAuditCommand(context *ishell.Context, c *client.Client) {
+	// check availability of arguments
+	if len(context.Args) < 1 || 3 < len(context.Args) || context.Args[0] == "?" {
+		AuditUsage(true, context)
+		return
+	}
+
+	// determine the page of the audit log
+	offset, limit, err := auditPage(context.Args[1:])
+	if err != nil {
+		handleResult(context, err, "invalid page of the audit log (not a non-negative integer)", "")
+		return
+	}
+
+	// execute command
+	entries, err := c.GetAudit(context.Args[0], offset, limit)
+	if err != nil {
+		handleResult(context, err, "audit log could not be displayed", "")
+		return
+	}
+
+	result, err := util.ConvertToYAML(entries)
+	handleResult(context, err, "audit log could not be displayed", result)
+}
+
+//------------------------------------------------------------------------------
+
+// AuditUsage describes how to make use of the audit subcommand
+func AuditUsage(header bool, context *ishell.Context) {
+	info := ""
+	if header {
+		info = _usage
+	}
+	info += "  audit <domain> [<offset> [<limit>]]\n"
+
+	writeInfo(context, info)
+}
+
+//------------------------------------------------------------------------------
+
+// auditPage determines the offset and the limit of the entries of the audit
+// log to be displayed (all entries by default).
+func auditPage(args []string) (offset int, limit int, err error) {
+	page := []int{0, 0}
+
+	for index, arg := range args {
+		value, err := strconv.Atoi(arg)
+		if err != nil {
+			return 0, 0, err
+		}
+		if value < 0 {
+			return 0, 0, errors.New("negative value: " + arg)
+		}
+		page[index] = value
+	}
+
+	return page[0], page[1], nil
+}
+
+//------------------------------------------------------------------------------
+
+// audited executes a command and records the modification of the model in
+// the audit log if the command has been successful.
+func audited(context *ishell.Context, command string, execute func()) {
+	path, ok := auditPath(command, context.Args)
+	if !ok {
+		execute()
+		return
+	}
+
+	before := model.AuditState(path)
+
+	lastError = nil
+	execute()
+	if lastError != nil {
+		return
+	}
+
+	model.Audit(actor(), model.AuditSourceCLI, command + " " + context.Args[0], path, before, model.AuditState(path))
+}
+
+//------------------------------------------------------------------------------
+
+// auditPath determines the path of the entity modified by a command. Commands
+// which do not modify the model are not audited.
+func auditPath(command string, args []string) (string, bool) {
+	if len(args) == 0 {
+		return "", false
+	}
+
+	switch command + " " + args[0] {
+	case "model reset":
+		if len(args) == 1 {
+			return "", true
+		}
+	case "model set", "domain set":
+		if len(args) == 2 {
+			return "", true
+		}
+	case "domain create", "domain delete", "domain reset":
+		if len(args) == 2 {
+			return args[1], true
+		}
+	case "component set", "architecture set", "solution set", "controller set":
+		if len(args) == 3 {
+			return args[1], true
+		}
+	case "component delete", "architecture delete", "controller delete":
+		if len(args) == 4 {
+			return args[1] + "/" + command + ":" + args[2] + "/" + args[3], true
+		}
+	case "architecture deploy":
+		if len(args) == 4 {
+			return args[1] + "/" + args[2], true
+		}
+	case "solution delete", "solution rollback":
+		if len(args) == 3 {
+			return args[1] + "/" + args[2], true
+		}
+	case "secret set", "secret delete":
+		if len(args) >= 3 {
+			return args[1] + "/secret:" + args[2], true
+		}
+	case "task terminate":
+		if len(args) == 3 {
+			return args[1] + "/task:" + args[2], true
+		}
+	}
+
+	return "", false
+}
+
+//------------------------------------------------------------------------------
+
+// actor determines the user of the command line interface.
+func actor() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+
+	return "unknown"
+}
+
+//------------------------------------------------------------------------------
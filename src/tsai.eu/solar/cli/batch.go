@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	ishell "gopkg.in/abiosoft/ishell.v2"
+	"tsai.eu/solar/client"
+	"tsai.eu/solar/model"
+)
+
+//------------------------------------------------------------------------------
+
+// ExitOK indicates that the command and the followed task have been successful.
+const ExitOK int = 0
+
+// ExitFailure indicates that the command has failed.
+const ExitFailure int = 1
+
+// ExitUsage indicates that the command has been invoked incorrectly.
+const ExitUsage int = 2
+
+// ExitTaskFailure indicates that the followed task has failed, timed out or has been terminated.
+const ExitTaskFailure int = 3
+
+// ExitTimeout indicates that the followed task has not finished in time.
+const ExitTimeout int = 4
+
+//------------------------------------------------------------------------------
+
+// TaskStatus determines the status of a task of a domain.
+type TaskStatus func(domainName string, taskName string) (string, error)
+
+// pollInterval determines how often the status of a followed task is checked.
+var pollInterval = 500 * time.Millisecond
+
+//------------------------------------------------------------------------------
+
+// LocalTaskStatus determines the status of a task of the local model.
+func LocalTaskStatus(domainName string, taskName string) (string, error) {
+	task, err := model.GetTask(domainName, taskName)
+	if err != nil {
+		return "", err
+	}
+
+	return task.GetStatus(), nil
+}
+
+//------------------------------------------------------------------------------
+
+// RemoteTaskStatus determines the status of the tasks of a remote server.
+func RemoteTaskStatus(c *client.Client) TaskStatus {
+	return func(domainName string, taskName string) (string, error) {
+		info, err := c.GetTask(domainName, taskName, 0)
+		if err != nil {
+			return "", err
+		}
+
+		return info.Status, nil
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// Execute runs a single command non-interactively and determines the exit
+// code. If wait is requested the task started by the command is followed until
+// it has completed or failed or until the timeout (0 = unlimited) has expired.
+func Execute(shell *ishell.Shell, args []string, status TaskStatus, wait bool, timeout time.Duration) int {
+	batch      = true
+	lastError  = nil
+	lastResult = ""
+	usageShown = false
+
+	// execute the command
+	err := shell.Process(args...)
+
+	switch {
+	case lastError != nil:
+		return ExitFailure
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "unknown command: " + args[0])
+		return ExitUsage
+	case usageShown && args[0] != "usage" && args[len(args)-1] != "?":
+		return ExitUsage
+	}
+
+	// determine the started task
+	domainName, started := startedTask(args)
+	if !wait || !started {
+		return ExitOK
+	}
+
+	return waitForTask(status, domainName, lastResult, timeout)
+}
+
+//------------------------------------------------------------------------------
+
+// startedTask determines the domain of the task started by a command.
+func startedTask(args []string) (string, bool) {
+	if len(args) < 3 {
+		return "", false
+	}
+
+	switch args[0] + " " + args[1] {
+	case "architecture deploy", "solution rollback":
+		return args[2], true
+	}
+
+	return "", false
+}
+
+//------------------------------------------------------------------------------
+
+// waitForTask follows a task until it has completed or failed.
+func waitForTask(status TaskStatus, domainName string, taskName string, timeout time.Duration) int {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	for {
+		taskStatus, err := status(domainName, taskName)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "task " + taskName + " can not be identified\n" + err.Error())
+			return ExitFailure
+		}
+
+		switch taskStatus {
+		case model.TaskStatusCompleted:
+			fmt.Fprintln(os.Stderr, "task " + taskName + " has completed")
+			return ExitOK
+		case model.TaskStatusFailed, model.TaskStatusTimeout, model.TaskStatusTerminated:
+			fmt.Fprintln(os.Stderr, "task " + taskName + " has finished with status: " + taskStatus)
+			return ExitTaskFailure
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			fmt.Fprintln(os.Stderr, "task " + taskName + " has not finished within " + timeout.String() + " (status: " + taskStatus + ")")
+			return ExitTimeout
+		}
+
+		time.Sleep(pollInterval)
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"tsai.eu/solar/model"
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// TestBatch01 verifies the exit codes of non-interactive invocations.
+func TestBatch01(t *testing.T) {
+	util.LogLevel("error")
+
+	startEngine()
+
+	model.GetModel().Reset()
+	model.GetModel().Load("testdata/model_002.yaml")
+
+	shell  := Shell()
+	status := func(domainName string, taskName string) (string, error) { return model.TaskStatusFailed, nil }
+
+	tests := []struct {
+		args []string
+		wait bool
+		code int
+	}{
+		{[]string{"domain", "list"},                                    false, ExitOK},
+		{[]string{"domain", "?"},                                       false, ExitOK},
+		{[]string{"domain", "get"},                                     false, ExitUsage},
+		{[]string{"unknown"},                                           false, ExitUsage},
+		{[]string{"domain", "get", "unknown"},                          false, ExitFailure},
+		{[]string{"architecture", "plan", "demo", "app", "V0.0.0"},     true,  ExitOK},
+		{[]string{"architecture", "deploy", "demo", "app", "V0.0.0"},   false, ExitOK},
+		{[]string{"architecture", "deploy", "demo", "app", "V0.0.0"},   true,  ExitTaskFailure},
+	}
+
+	for index, test := range tests {
+		if code := Execute(shell, test.args, status, test.wait, 0); code != test.code {
+			t.Errorf("%d: %s should have exited with %d instead of %d", index, strings.Join(test.args, " "), test.code, code)
+		}
+	}
+
+	// timeout and unknown tasks
+	running := func(domainName string, taskName string) (string, error) { return model.TaskStatusExecuting, nil }
+	if code := waitForTask(running, "demo", "1234", time.Millisecond); code != ExitTimeout {
+		t.Errorf("waitForTask should have timed out: %d", code)
+	}
+
+	unknown := func(domainName string, taskName string) (string, error) { return "", errors.New("task not found") }
+	if code := waitForTask(unknown, "demo", "1234", 0); code != ExitFailure {
+		t.Errorf("waitForTask should have failed for an unknown task: %d", code)
+	}
+
+	batch = false
+}
+
+//------------------------------------------------------------------------------
+
+// TestFormat01 verifies the conversion of results into the output formats.
+func TestFormat01(t *testing.T) {
+	defer SetFormat(FormatYAML)
+
+	if SetFormat("xml") == nil {
+		t.Errorf("SetFormat should have rejected an unknown format")
+	}
+
+	result := "- Name: web\n  Size: 2\n- Name: db\n  Size: 1\n"
+
+	SetFormat(FormatJSON)
+	if output := formatResult(result); output != `[{"Name":"web","Size":2},{"Name":"db","Size":1}]` {
+		t.Errorf("result should have been converted to JSON:\n%s", output)
+	}
+
+	SetFormat(FormatTable)
+	if output := formatResult(result); output != "NAME  SIZE\nweb   2\ndb    1" {
+		t.Errorf("result should have been converted to a table:\n%s", output)
+	}
+
+	if output := formatResult("Name: web\nTags:\n- a\n"); output != "ATTRIBUTE  VALUE\nName       web\nTags       [\"a\"]" {
+		t.Errorf("object should have been converted to a table:\n%s", output)
+	}
+
+	if output := formatResult("component has been deleted"); output != "component has been deleted" {
+		t.Errorf("message should have been displayed as it is:\n%s", output)
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -8,7 +8,12 @@ import (
 	"context"
 	"io"
 	"os"
+	"sync"
+	"net/http/httptest"
 
+	ishell "gopkg.in/abiosoft/ishell.v2"
+	"tsai.eu/solar/api"
+	"tsai.eu/solar/client"
 	"tsai.eu/solar/engine"
 	"tsai.eu/solar/util"
 )
@@ -62,34 +67,17 @@ func LoadCommands() ([][]string, error) {
 
 //------------------------------------------------------------------------------
 
-// TestCLI verifies the command line interface.
-func TestCLI(t *testing.T) {
-	filename := "output.txt"
-	// cleanup routine
-  defer func() {os.Remove(filename)}()
-
-	// prepare configuration file
-  srcConfig  := "testdata/solar-conf.yaml"
-  destConfig := "solar-conf.yaml"
-
-  copyFile(srcConfig, destConfig)
-
-  // cleanup routine
-  defer func() {os.Remove(destConfig)}()
-
-	// initialise command line options
-	util.LogLevel("error")
-	util.ParseCommandLineOptions()
-
-	// display progam information
-	fmt.Println("SOLAR Version 1.0.0")
+// startEngine starts the main event loop once for all tests.
+func startEngine() {
+	engineOnce.Do(func() { engine.Start(context.Background()) })
+}
 
-	// start the main event loop
-	engine.Start(context.Background())
+var engineOnce sync.Once
 
-	// get the command line interface
-	shell := Shell()
+//------------------------------------------------------------------------------
 
+// runCommands executes the commands of the test script with a shell.
+func runCommands(t *testing.T, shell *ishell.Shell) {
 	// load commands from a file
 	cmds, err := LoadCommands()
 	if err != nil {
@@ -131,3 +119,63 @@ func TestCLI(t *testing.T) {
 }
 
 //------------------------------------------------------------------------------
+
+// TestCLI verifies the command line interface.
+func TestCLI(t *testing.T) {
+	filename := "output.txt"
+	// cleanup routine
+  defer func() {os.Remove(filename)}()
+
+	// prepare configuration file
+  srcConfig  := "testdata/solar-conf.yaml"
+  destConfig := "solar-conf.yaml"
+
+  copyFile(srcConfig, destConfig)
+
+  // cleanup routine
+  defer func() {os.Remove(destConfig)}()
+
+	// initialise command line options
+	util.LogLevel("error")
+	util.ParseCommandLineOptions()
+
+	// display progam information
+	fmt.Println("SOLAR Version 1.0.0")
+
+	// start the main event loop
+	startEngine()
+
+	// execute the commands with the command line interface
+	runCommands(t, Shell())
+}
+
+//------------------------------------------------------------------------------
+
+// TestRemote01 verifies the command line interface driving a remote server.
+func TestRemote01(t *testing.T) {
+	filename := "output.txt"
+	// cleanup routine
+  defer func() {os.Remove(filename)}()
+
+	// prepare configuration file
+  srcConfig  := "testdata/solar-conf.yaml"
+  destConfig := "solar-conf.yaml"
+
+  copyFile(srcConfig, destConfig)
+
+  // cleanup routine
+  defer func() {os.Remove(destConfig)}()
+
+	util.LogLevel("error")
+
+	// start the main event loop and the remote server
+	startEngine()
+
+	server := httptest.NewServer(api.NewRouter())
+	defer server.Close()
+
+	// execute the commands with the remote command line interface
+	runCommands(t, RemoteShell(client.NewClient(server.URL, "")))
+}
+
+//------------------------------------------------------------------------------
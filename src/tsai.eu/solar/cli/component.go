@@ -2,6 +2,7 @@ package cli
 
 import (
 	ishell "gopkg.in/abiosoft/ishell.v2"
+	"tsai.eu/solar/client"
 	"tsai.eu/solar/model"
 	"tsai.eu/solar/util"
 )
@@ -145,6 +146,109 @@ func ComponentCommand(context *ishell.Context, m *model.Model) {
 
 //------------------------------------------------------------------------------
 
+// RemoteComponentCommand executes the component related subcommands on a remote server
+func RemoteComponentCommand(context *ishell.Context, c *client.Client) {
+	// check if the action has been defined
+	if len(context.Args) < 1 {
+		ComponentUsage(true, context)
+		return
+	}
+
+	// determine the required action
+	action := context.Args[0]
+
+	// handle required action
+	switch action {
+	case "?":
+		ComponentUsage(true, context)
+	case _list:
+		// check availability of arguments
+		if len(context.Args) < 2 || 4 < len(context.Args) {
+			ComponentUsage(true, context)
+			return
+		}
+
+		// set component name and version filter
+		componentName := ""
+		if len(context.Args) >= 3 {
+			componentName = context.Args[2]
+		}
+
+		versionName := ""
+		if len(context.Args) == 4 {
+			versionName = context.Args[3]
+		}
+
+		// determine list of component names
+		cNameVersions, err := c.ListComponents(context.Args[1])
+		if err != nil {
+			handleResult(context, err, "domain can not be identified", "")
+			return
+		}
+
+		components := []string{}
+		for _, cNameVersion := range cNameVersions {
+			if (componentName == "" || componentName == cNameVersion[0]) &&
+			   (versionName   == "" || versionName   == cNameVersion[1]) {
+				components = append(components, cNameVersion[0] + " - " + cNameVersion[1])
+			}
+		}
+
+		result, err := util.ConvertToYAML(components)
+		handleResult(context, err, "components could not be listed", result)
+	case _set:
+		// check availability of arguments
+		if len(context.Args) != 3 {
+			ComponentUsage(true, context)
+			return
+		}
+
+		// load component
+		component, _ := model.NewComponent("A", "B", "C", "D")
+
+		err := component.Load(context.Args[2])
+		if err != nil {
+			handleResult(context, err, "component could not be loaded", "")
+			return
+		}
+
+		// add component to domain
+		err = c.SetComponent(context.Args[1], component)
+		handleResult(context, err, "unable to load component", "")
+	case _get:
+		// check availability of arguments
+		if len(context.Args) != 4 {
+			ComponentUsage(true, context)
+			return
+		}
+
+		// determine component
+		component, err := c.GetComponent(context.Args[1], context.Args[2], context.Args[3])
+		if err != nil {
+			handleResult(context, err, "component can not be identified", "")
+			return
+		}
+
+		// execute the command
+		result, err := component.Show()
+		handleResult(context, err, "component can not be displayed", result)
+	case _delete:
+		// check availability of arguments
+		if len(context.Args) != 4 {
+			ComponentUsage(true, context)
+			return
+		}
+
+		// execute command
+		err := c.DeleteComponent(context.Args[1], context.Args[2], context.Args[3])
+		handleResult(context, err, "component can not be deleted", "component has been deleted")
+	default:
+		ComponentUsage(true, context)
+	}
+}
+
+//------------------------------------------------------------------------------
+
 // ComponentUsage describes how to make use of the subcommand
 func ComponentUsage(header bool, context *ishell.Context) {
 	info := ""
@@ -9,5 +9,9 @@ const _get       = "get"
 const _show      = "show"
 const _reset     = "reset"
 const _deploy    = "deploy"
+const _plan      = "plan"
+const _rollback  = "rollback"
+const _drift     = "drift"
+const _validate  = "validate"
 const _terminate = "terminate"
 const _trace     = "trace"
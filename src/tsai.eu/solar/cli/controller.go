@@ -2,6 +2,7 @@ package cli
 
 import (
 	ishell "gopkg.in/abiosoft/ishell.v2"
+	"tsai.eu/solar/client"
 	"tsai.eu/solar/model"
 	"tsai.eu/solar/util"
 )
@@ -131,6 +132,90 @@ func ControllerCommand(context *ishell.Context, m *model.Model) {
 
 //------------------------------------------------------------------------------
 
+// RemoteControllerCommand executes the controller related subcommands on a remote server
+func RemoteControllerCommand(context *ishell.Context, c *client.Client) {
+	// check if the action has been defined
+	if len(context.Args) < 1 {
+		ControllerUsage(true, context)
+		return
+	}
+
+	// determine the required action
+	action := context.Args[0]
+
+	// handle required action
+	switch action {
+	case "?":
+		ControllerUsage(true, context)
+	case _list:
+		// check availability of arguments
+		if len(context.Args) != 2 {
+			ControllerUsage(true, context)
+			return
+		}
+
+		// determine list of controllers
+		controllers, err := c.ListControllers(context.Args[1])
+		if err != nil {
+			handleResult(context, err, "domain can not be identified", "")
+			return
+		}
+
+		result, err := util.ConvertToYAML(controllers)
+		handleResult(context, err, "controllers could not be listed", result)
+	case _delete:
+		// check availability of arguments
+		if len(context.Args) != 4 {
+			ControllerUsage(true, context)
+			return
+		}
+
+		// execute command
+		err := c.DeleteController(context.Args[1], context.Args[2], context.Args[3])
+		handleResult(context, err, "controller can not be deleted", "controller has been deleted")
+	case _set:
+		// check availability of arguments
+		if len(context.Args) != 3 {
+			ControllerUsage(true, context)
+			return
+		}
+
+		// load controller
+		controller, _ := model.NewController("", "")
+
+		err := controller.Load(context.Args[2])
+		if err != nil {
+			handleResult(context, err, "controller could not be loaded", "")
+			return
+		}
+
+		// add controller to domain
+		err = c.SetController(context.Args[1], controller)
+		handleResult(context, err, "unable to load controller", "controller has been loaded")
+	case _get:
+		// check availability of arguments
+		if len(context.Args) != 4 {
+			ControllerUsage(true, context)
+			return
+		}
+
+		// determine controller
+		controller, err := c.GetController(context.Args[1], context.Args[2], context.Args[3])
+		if err != nil {
+			handleResult(context, err, "controller can not be identified", "")
+			return
+		}
+
+		// execute the command
+		result, err := controller.Show()
+		handleResult(context, err, "controller can not be displayed", result)
+	default:
+		ControllerUsage(true, context)
+	}
+}
+
+//------------------------------------------------------------------------------
+
 // ControllerUsage describes how to make use of the domain subcommand
 func ControllerUsage(header bool, context *ishell.Context) {
 	info := ""
@@ -2,6 +2,7 @@ package cli
 
 import (
 	ishell "gopkg.in/abiosoft/ishell.v2"
+	"tsai.eu/solar/client"
 	"tsai.eu/solar/model"
 	"tsai.eu/solar/util"
 )
@@ -108,6 +109,76 @@ func DomainCommand(context *ishell.Context, m *model.Model) {
 
 //------------------------------------------------------------------------------
 
+// RemoteDomainCommand executes the domain related subcommands on a remote server
+func RemoteDomainCommand(context *ishell.Context, c *client.Client) {
+	// check if the action has been defined
+	if len(context.Args) < 1 {
+		DomainUsage(true, context)
+		return
+	}
+
+	// determine the required action
+	action := context.Args[0]
+
+	// check availability of arguments
+	if action != "?" && action != _list && len(context.Args) < 2 {
+		DomainUsage(true, context)
+		return
+	}
+
+	// handle required action
+	switch action {
+	case "?":
+		DomainUsage(true, context)
+	case _list:
+		domains, err := c.ListDomains()
+		if err != nil {
+			handleResult(context, err, "domains could not be listed", "")
+			return
+		}
+
+		result, err := util.ConvertToYAML(domains)
+		handleResult(context, err, "domains could not be listed", result)
+	case _create:
+		err := c.CreateDomain(context.Args[1])
+		handleResult(context, err, "unable to create domain", "")
+	case _delete:
+		err := c.DeleteDomain(context.Args[1])
+		handleResult(context, err, "domain can not be deleted", "")
+	case _set:
+		// create new domain
+		d, _ := model.NewDomain("dummy")
+
+		// load domain information
+		err := d.Load(context.Args[1])
+		if err != nil {
+			handleResult(context, err, "domain could not be loaded", "")
+			return
+		}
+
+		// add domain to model
+		err = c.SetDomain(d)
+		handleResult(context, err, "domain could not be loaded", "")
+	case _get:
+		d, err := c.GetDomain(context.Args[1])
+		if err != nil {
+			handleResult(context, err, "domain is not known", "")
+			return
+		}
+
+		// display domain
+		result, err := d.Show()
+		handleResult(context, err, "domain can not be displayed", result)
+	case _reset:
+		err := c.ResetDomain(context.Args[1])
+		handleResult(context, err, "unable to reset domain", "")
+	default:
+		DomainUsage(true, context)
+	}
+}
+
+//------------------------------------------------------------------------------
+
 // DomainUsage describes how to make use of the domain subcommand
 func DomainUsage(header bool, context *ishell.Context) {
 	info := ""
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"bytes"
+	"errors"
+	"strings"
+	"encoding/json"
+	"text/tabwriter"
+
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// FormatYAML displays the results of commands as YAML documents (default).
+const FormatYAML string = "yaml"
+
+// FormatJSON displays the results of commands as JSON documents.
+const FormatJSON string = "json"
+
+// FormatTable displays the results of commands as aligned columns.
+const FormatTable string = "table"
+
+//------------------------------------------------------------------------------
+
+var format = FormatYAML // format of the results
+
+//------------------------------------------------------------------------------
+
+// SetFormat defines the format of the results of commands.
+func SetFormat(name string) error {
+	switch name {
+	case FormatYAML, FormatJSON, FormatTable:
+		format = name
+		return nil
+	}
+
+	return errors.New("unknown output format: " + name)
+}
+
+//------------------------------------------------------------------------------
+
+// formatResult converts the YAML result of a command into the selected format.
+// Results which are no valid YAML documents are displayed as they are.
+func formatResult(result string) string {
+	if format == FormatYAML || result == "" {
+		return result
+	}
+
+	data, err := util.ConvertYAMLToJSON([]byte(result))
+	if err != nil {
+		return result
+	}
+
+	if format == FormatJSON {
+		return string(data)
+	}
+
+	var value interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(&value); err != nil {
+		return result
+	}
+
+	return formatTable(value)
+}
+
+//------------------------------------------------------------------------------
+
+// formatTable displays lists of objects as rows with a column per attribute,
+// objects as rows of attributes and values and lists of values as rows.
+func formatTable(value interface{}) string {
+	var buffer bytes.Buffer
+
+	writer := tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+
+	switch entity := value.(type) {
+	case []interface{}:
+		columns := tableColumns(entity)
+
+		if len(columns) > 0 {
+			fmt.Fprintln(writer, strings.ToUpper(strings.Join(columns, "\t")))
+		}
+
+		for _, row := range entity {
+			object, ok := row.(map[string]interface{})
+			if !ok {
+				fmt.Fprintln(writer, tableCell(row))
+				continue
+			}
+
+			cells := []string{}
+			for _, column := range columns {
+				cells = append(cells, tableCell(object[column]))
+			}
+			fmt.Fprintln(writer, strings.Join(cells, "\t"))
+		}
+	case map[string]interface{}:
+		fmt.Fprintln(writer, "ATTRIBUTE\tVALUE")
+
+		for _, name := range sortedKeys(entity) {
+			fmt.Fprintln(writer, name + "\t" + tableCell(entity[name]))
+		}
+	default:
+		fmt.Fprintln(writer, tableCell(entity))
+	}
+
+	writer.Flush()
+
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+//------------------------------------------------------------------------------
+
+// tableColumns determines the attributes of the objects of a list.
+func tableColumns(rows []interface{}) []string {
+	names := map[string]interface{}{}
+
+	for _, row := range rows {
+		if object, ok := row.(map[string]interface{}); ok {
+			for name := range object {
+				names[name] = true
+			}
+		}
+	}
+
+	return sortedKeys(names)
+}
+
+//------------------------------------------------------------------------------
+
+// sortedKeys determines the sorted names of the attributes of an object.
+func sortedKeys(object map[string]interface{}) []string {
+	keys := []string{}
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+//------------------------------------------------------------------------------
+
+// tableCell displays a value in a single cell. Structured values are displayed
+// as compact JSON.
+func tableCell(value interface{}) string {
+	switch cell := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.Replace(cell, "\n", " ", -1)
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(cell)
+		return string(data)
+	}
+
+	return fmt.Sprint(value)
+}
+
+//------------------------------------------------------------------------------
@@ -2,7 +2,9 @@ package cli
 
 import (
 	ishell "gopkg.in/abiosoft/ishell.v2"
+	"tsai.eu/solar/client"
 	"tsai.eu/solar/model"
+	"tsai.eu/solar/util"
 )
 
 //------------------------------------------------------------------------------
@@ -38,6 +40,59 @@ func ModelCommand(context *ishell.Context, m *model.Model) {
 
 //------------------------------------------------------------------------------
 
+// RemoteModelCommand executes the model related subcommands on a remote server
+func RemoteModelCommand(context *ishell.Context, c *client.Client) {
+	// check if the action has been defined
+	if len(context.Args) < 1 {
+		ModelUsage(true, context)
+		return
+	}
+
+	// determine the required action
+	action := context.Args[0]
+
+	// handle required action
+	switch action {
+	case "?":
+		ModelUsage(true, context)
+	case _set:
+		// check availability of arguments
+		if len(context.Args) != 2 {
+			ModelUsage(true, context)
+			return
+		}
+
+		// load model information
+		m := model.Model{}
+
+		err := util.LoadYAML(context.Args[1], &m)
+		if err != nil {
+			handleResult(context, err, "model could not be loaded", "")
+			return
+		}
+
+		// execute command
+		err = c.SetModel(&m)
+		handleResult(context, err, "model could not be loaded", "")
+	case _get:
+		m, err := c.GetModel()
+		if err != nil {
+			handleResult(context, err, "model can not be displayed", "")
+			return
+		}
+
+		result, err := m.Show()
+		handleResult(context, err, "model can not be displayed", result)
+	case _reset:
+		err := c.ResetModel()
+		handleResult(context, err, "model could not be reset", "")
+	default:
+		ModelUsage(true, context)
+	}
+}
+
+//------------------------------------------------------------------------------
+
 // ModelUsage describes how to make use of the model subcommand
 func ModelUsage(header bool, context *ishell.Context) {
 	info := ""
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	ishell "gopkg.in/abiosoft/ishell.v2"
+	"tsai.eu/solar/client"
+	"tsai.eu/solar/model"
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// SecretCommand executes the secret related subcommands
+func SecretCommand(context *ishell.Context, m *model.Model) {
+	// check if the action has been defined
+	if len(context.Args) < 1 {
+		SecretUsage(true, context)
+		return
+	}
+
+	// determine the required action
+	action := context.Args[0]
+
+	// handle required action
+	switch action {
+	case "?":
+		SecretUsage(true, context)
+	case _list:
+		// check availability of arguments
+		if len(context.Args) != 2 {
+			SecretUsage(true, context)
+			return
+		}
+
+		// determine domain
+		domain, err := m.GetDomain(context.Args[1])
+		if err != nil {
+			handleResult(context, err, "domain can not be identified", "")
+			return
+		}
+
+		// execute command
+		secrets, _ := domain.ListSecrets()
+		result, err := util.ConvertToYAML(secrets)
+		handleResult(context, err, "secrets could not be listed", result)
+	case _set:
+		// check availability of arguments
+		if len(context.Args) != 4 {
+			SecretUsage(true, context)
+			return
+		}
+
+		// determine domain
+		domain, err := m.GetDomain(context.Args[1])
+		if err != nil {
+			handleResult(context, err, "domain can not be identified", "")
+			return
+		}
+
+		// execute command
+		err = domain.SetSecret(context.Args[2], context.Args[3])
+		handleResult(context, err, "unable to set secret", "")
+	case _delete:
+		// check availability of arguments
+		if len(context.Args) != 3 {
+			SecretUsage(true, context)
+			return
+		}
+
+		// determine domain
+		domain, err := m.GetDomain(context.Args[1])
+		if err != nil {
+			handleResult(context, err, "domain can not be identified", "")
+			return
+		}
+
+		// execute command
+		err = domain.DeleteSecret(context.Args[2])
+		handleResult(context, err, "secret can not be deleted", "")
+	default:
+		SecretUsage(true, context)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// RemoteSecretCommand executes the secret related subcommands on a remote server
+func RemoteSecretCommand(context *ishell.Context, c *client.Client) {
+	// check if the action has been defined
+	if len(context.Args) < 1 {
+		SecretUsage(true, context)
+		return
+	}
+
+	// determine the required action
+	action := context.Args[0]
+
+	// handle required action
+	switch action {
+	case "?":
+		SecretUsage(true, context)
+	case _list:
+		// check availability of arguments
+		if len(context.Args) != 2 {
+			SecretUsage(true, context)
+			return
+		}
+
+		// execute command
+		secrets, err := c.ListSecrets(context.Args[1])
+		if err != nil {
+			handleResult(context, err, "domain can not be identified", "")
+			return
+		}
+
+		result, err := util.ConvertToYAML(secrets)
+		handleResult(context, err, "secrets could not be listed", result)
+	case _set:
+		// check availability of arguments
+		if len(context.Args) != 4 {
+			SecretUsage(true, context)
+			return
+		}
+
+		// execute command
+		err := c.SetSecret(context.Args[1], context.Args[2], context.Args[3])
+		handleResult(context, err, "unable to set secret", "")
+	case _delete:
+		// check availability of arguments
+		if len(context.Args) != 3 {
+			SecretUsage(true, context)
+			return
+		}
+
+		// execute command
+		err := c.DeleteSecret(context.Args[1], context.Args[2])
+		handleResult(context, err, "secret can not be deleted", "")
+	default:
+		SecretUsage(true, context)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// SecretUsage describes how to make use of the secret subcommand
+func SecretUsage(header bool, context *ishell.Context) {
+	info := ""
+	if header {
+		info = _usage
+	}
+	info += "  secret list <domain>\n"
+	info += "         set <domain> <secret> <value>\n"
+	info += "         delete <domain> <secret>\n"
+
+	writeInfo(context, info)
+}
+
+//------------------------------------------------------------------------------
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	ishell "gopkg.in/abiosoft/ishell.v2"
+	"tsai.eu/solar/client"
 	"tsai.eu/solar/model"
 	"tsai.eu/solar/util"
 )
@@ -14,6 +15,10 @@ import (
 
 var output        string
 var outputEnabled bool
+var lastError     error  // error reported by the last command
+var lastResult    string // result reported by the last successful command
+var usageShown    bool   // usage information has been displayed
+var batch         bool   // commands are executed non-interactively
 
 //------------------------------------------------------------------------------
 
@@ -22,87 +27,192 @@ func Shell() *ishell.Shell{
 	// get model
 	m := model.GetModel()
 
-	// create new shell which by default includes 'exit', 'help' and
-	// 'clear' commands
-	shell := ishell.New()
+	// create new shell which by default includes 'exit', 'help', 'clear',
+	// 'usage', 'output' and comment commands
+	shell := newShell()
 
 	// register a function for the "model" command.
 	shell.AddCmd(&ishell.Cmd{
-		Name: "usage",
-		Help: "usage command",
-		Func: func(c *ishell.Context) {
-			OutputUsage(true, c)
-			ModelUsage(false, c)
-			DomainUsage(false, c)
-			ComponentUsage(false, c)
-			ArchitectureUsage(false, c)
-			SolutionUsage(false, c)
-			ControllerUsage(false, c)
-			TaskUsage(false, c)
-			info := ""
-			info += "  # <comment>\n\n"
-			info += "  clear\n\n"
-			info += "  help\n\n"
-			info += "  exit\n"
+		Name: "model",
+		Help: "model commands",
+		Func: func(c *ishell.Context) { audited(c, "model", func() { ModelCommand(c, m) }) },
+	})
 
-		  writeInfo(c, info)
-		},
+	// register a function for the "domain" command.
+	shell.AddCmd(&ishell.Cmd{
+		Name: "domain",
+		Help: "domain commands",
+		Func: func(c *ishell.Context) { audited(c, "domain", func() { DomainCommand(c, m) }) },
 	})
 
-	// register a function for the "output" command.
+	// register a function for the "component" command.
 	shell.AddCmd(&ishell.Cmd{
-		Name: "output",
-		Help: "output commands",
-		Func: func(c *ishell.Context) { OutputCommand(c, m) },
+		Name: "component",
+		Help: "component commands",
+		Func: func(c *ishell.Context) { audited(c, "component", func() { ComponentCommand(c, m) }) },
+	})
+
+	// register a function for the "architecture" command.
+	shell.AddCmd(&ishell.Cmd{
+		Name: "architecture",
+		Help: "architecture commands",
+		Func: func(c *ishell.Context) { audited(c, "architecture", func() { ArchitectureCommand(c, m) }) },
+	})
+
+	// register a function for the "solution" command.
+	shell.AddCmd(&ishell.Cmd{
+		Name: "solution",
+		Help: "solution commands",
+		Func: func(c *ishell.Context) { audited(c, "solution", func() { SolutionCommand(c, m) }) },
+	})
+
+	// register a function for the "task" command.
+	shell.AddCmd(&ishell.Cmd{
+		Name: "task",
+		Help: "task commands",
+		Func: func(c *ishell.Context) { audited(c, "task", func() { TaskCommand(c, m) }) },
 	})
 
+	// register a function for the "controller" command.
+	shell.AddCmd(&ishell.Cmd{
+		Name: "controller",
+		Help: "controller commands",
+		Func: func(c *ishell.Context) { audited(c, "controller", func() { ControllerCommand(c, m) }) },
+	})
+
+	// register a function for the "secret" command.
+	shell.AddCmd(&ishell.Cmd{
+		Name: "secret",
+		Help: "secret commands",
+		Func: func(c *ishell.Context) { audited(c, "secret", func() { SecretCommand(c, m) }) },
+	})
+
+	// register a function for the "audit" command.
+	shell.AddCmd(&ishell.Cmd{
+		Name: "audit",
+		Help: "audit commands",
+		Func: func(c *ishell.Context) { AuditCommand(c, m) },
+	})
+
+	// return shell
+	return shell
+}
+
+//------------------------------------------------------------------------------
+
+// RemoteShell creates a command line interface which executes all commands
+// on a remote server via its REST API. The modifications are recorded in the
+// audit log of the server.
+func RemoteShell(r *client.Client) *ishell.Shell{
+	// create new shell which by default includes 'exit', 'help', 'clear',
+	// 'usage', 'output' and comment commands
+	shell := newShell()
+
 	// register a function for the "model" command.
 	shell.AddCmd(&ishell.Cmd{
 		Name: "model",
 		Help: "model commands",
-		Func: func(c *ishell.Context) { ModelCommand(c, m) },
+		Func: func(c *ishell.Context) { RemoteModelCommand(c, r) },
 	})
 
 	// register a function for the "domain" command.
 	shell.AddCmd(&ishell.Cmd{
 		Name: "domain",
 		Help: "domain commands",
-		Func: func(c *ishell.Context) { DomainCommand(c, m) },
+		Func: func(c *ishell.Context) { RemoteDomainCommand(c, r) },
 	})
 
 	// register a function for the "component" command.
 	shell.AddCmd(&ishell.Cmd{
 		Name: "component",
 		Help: "component commands",
-		Func: func(c *ishell.Context) { ComponentCommand(c, m) },
+		Func: func(c *ishell.Context) { RemoteComponentCommand(c, r) },
 	})
 
 	// register a function for the "architecture" command.
 	shell.AddCmd(&ishell.Cmd{
 		Name: "architecture",
 		Help: "architecture commands",
-		Func: func(c *ishell.Context) { ArchitectureCommand(c, m) },
+		Func: func(c *ishell.Context) { RemoteArchitectureCommand(c, r) },
 	})
 
 	// register a function for the "solution" command.
 	shell.AddCmd(&ishell.Cmd{
 		Name: "solution",
 		Help: "solution commands",
-		Func: func(c *ishell.Context) { SolutionCommand(c, m) },
+		Func: func(c *ishell.Context) { RemoteSolutionCommand(c, r) },
 	})
 
 	// register a function for the "task" command.
 	shell.AddCmd(&ishell.Cmd{
 		Name: "task",
 		Help: "task commands",
-		Func: func(c *ishell.Context) { TaskCommand(c, m) },
+		Func: func(c *ishell.Context) { RemoteTaskCommand(c, r) },
 	})
 
 	// register a function for the "controller" command.
 	shell.AddCmd(&ishell.Cmd{
 		Name: "controller",
 		Help: "controller commands",
-		Func: func(c *ishell.Context) { ControllerCommand(c, m) },
+		Func: func(c *ishell.Context) { RemoteControllerCommand(c, r) },
+	})
+
+	// register a function for the "secret" command.
+	shell.AddCmd(&ishell.Cmd{
+		Name: "secret",
+		Help: "secret commands",
+		Func: func(c *ishell.Context) { RemoteSecretCommand(c, r) },
+	})
+
+	// register a function for the "audit" command.
+	shell.AddCmd(&ishell.Cmd{
+		Name: "audit",
+		Help: "audit commands",
+		Func: func(c *ishell.Context) { RemoteAuditCommand(c, r) },
+	})
+
+	// return shell
+	return shell
+}
+
+//------------------------------------------------------------------------------
+
+// newShell creates a shell with the commands which do not depend on the model.
+func newShell() *ishell.Shell{
+	// create new shell which by default includes 'exit', 'help' and
+	// 'clear' commands
+	shell := ishell.New()
+
+	// register a function for the "model" command.
+	shell.AddCmd(&ishell.Cmd{
+		Name: "usage",
+		Help: "usage command",
+		Func: func(c *ishell.Context) {
+			OutputUsage(true, c)
+			ModelUsage(false, c)
+			DomainUsage(false, c)
+			ComponentUsage(false, c)
+			ArchitectureUsage(false, c)
+			SolutionUsage(false, c)
+			ControllerUsage(false, c)
+			SecretUsage(false, c)
+			TaskUsage(false, c)
+			AuditUsage(false, c)
+			info := ""
+			info += "  # <comment>\n\n"
+			info += "  clear\n\n"
+			info += "  help\n\n"
+			info += "  exit\n"
+
+		  writeInfo(c, info)
+		},
+	})
+
+	// register a function for the "output" command.
+	shell.AddCmd(&ishell.Cmd{
+		Name: "output",
+		Help: "output commands",
+		Func: func(c *ishell.Context) { OutputCommand(c, nil) },
 	})
 
 	// register a function for "#" command.
@@ -129,6 +239,9 @@ func Shell() *ishell.Shell{
 
 // handleResult reports error information if present or display success message
 func handleResult(context *ishell.Context, err error, fail string, success string) {
+	lastError  = err
+	lastResult = ""
+
 	if err != nil {
 		// inform shell about error
 		context.Err(err)
@@ -142,7 +255,8 @@ func handleResult(context *ishell.Context, err error, fail string, success strin
 			writeError(context, info)
 		}
 	} else {
-		writeOutput(context, success)
+		lastResult = success
+		writeOutput(context, formatResult(success))
 	}
 }
 
@@ -164,13 +278,19 @@ func setOutputEnabled(enabled bool) {
 
 // writeInfo prints information to the console.
 func writeInfo(context *ishell.Context, info string) {
+	usageShown = true
 	context.Println(info)
 }
 
 //------------------------------------------------------------------------------
 
-// writeError prints error information to the console.
+// writeError prints error information to the console (or to the standard
+// error of non-interactive invocations).
 func writeError(context *ishell.Context, info string) {
+	if batch {
+		fmt.Fprintln(os.Stderr, info)
+		return
+	}
 	context.Println(info)
 }
 
@@ -182,6 +302,9 @@ func writeOutput(context *ishell.Context, info string) error {
 		return nil
 	}
 
+	// remove the values of secrets
+	info = util.Redact(info)
+
 	// check which channel to use
 	if output == "" {
 		if info != "" {
@@ -2,6 +2,8 @@ package cli
 
 import (
 	ishell "gopkg.in/abiosoft/ishell.v2"
+	"tsai.eu/solar/engine"
+	"tsai.eu/solar/client"
 	"tsai.eu/solar/model"
 	"tsai.eu/solar/util"
 )
@@ -132,6 +134,155 @@ func SolutionCommand(context *ishell.Context, m *model.Model) {
 		// execute command
 		err = d.DeleteSolution(context.Args[2])
 		handleResult(context, err, "solution can not be deleted", "solution has been deleted")
+	case _rollback:
+		// check availability of arguments
+		if len(context.Args) != 3 {
+			SolutionUsage(true, context)
+			return
+		}
+
+		// re-apply the last successful deployment
+		uuid, err := engine.Rollback(context.Args[1], context.Args[2])
+		handleResult(context, err, "solution can not be rolled back", uuid)
+	case _drift:
+		// check availability of arguments
+		if len(context.Args) != 3 {
+			SolutionUsage(true, context)
+			return
+		}
+
+		// determine the drifted instances
+		drifts, err := model.GetDrift(context.Args[1], context.Args[2])
+		if err != nil {
+			handleResult(context, err, "solution can not be identified", "")
+			return
+		}
+
+		result, err := util.ConvertToYAML(drifts)
+		handleResult(context, err, "drift can not be displayed", result)
+	default:
+		SolutionUsage(true, context)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// RemoteSolutionCommand executes the solution related subcommands on a remote server
+func RemoteSolutionCommand(context *ishell.Context, c *client.Client) {
+	// check if the action has been defined
+	if len(context.Args) < 1 {
+		SolutionUsage(true, context)
+		return
+	}
+
+	// determine the required action
+	action := context.Args[0]
+
+	// handle required action
+	switch action {
+	case "?":
+		SolutionUsage(true, context)
+	case _list:
+		// check availability of arguments
+		if len(context.Args) < 2 || 3 < len(context.Args) {
+			SolutionUsage(true, context)
+			return
+		}
+
+		// set solution name filter
+		solutionName := ""
+		if len(context.Args) >= 3 {
+			solutionName = context.Args[2]
+		}
+
+		// determine list of solution names
+		sNames, err := c.ListSolutions(context.Args[1])
+		if err != nil {
+			handleResult(context, err, "domain can not be identified", "")
+			return
+		}
+
+		solutions := []string{}
+		for _, sName := range sNames {
+			if solutionName == "" || solutionName == sName {
+				solutions = append(solutions, sName)
+			}
+		}
+
+		result, err := util.ConvertToYAML(solutions)
+		handleResult(context, err, "solutions could not be listed", result)
+	case _set:
+		// check availability of arguments
+		if len(context.Args) != 3 {
+			SolutionUsage(true, context)
+			return
+		}
+
+		// load solution
+		solution, _ := model.NewSolution("", "", "")
+
+		err := solution.Load(context.Args[2])
+		if err != nil {
+			handleResult(context, err, "solution could not be loaded", "")
+			return
+		}
+
+		// add solution to domain
+		err = c.SetSolution(context.Args[1], solution)
+		handleResult(context, err, "unable to load solution", "solution has been loaded")
+	case _get:
+		// check availability of arguments
+		if len(context.Args) != 3 {
+			SolutionUsage(true, context)
+			return
+		}
+
+		// determine solution
+		solution, err := c.GetSolution(context.Args[1], context.Args[2])
+		if err != nil {
+			handleResult(context, err, "solution can not be identified", "")
+			return
+		}
+
+		// execute the command
+		result, err := solution.Show()
+		handleResult(context, err, "solution can not be displayed", result)
+	case _delete:
+		// check availability of arguments
+		if len(context.Args) != 3 {
+			SolutionUsage(true, context)
+			return
+		}
+
+		// execute command
+		err := c.DeleteSolution(context.Args[1], context.Args[2])
+		handleResult(context, err, "solution can not be deleted", "solution has been deleted")
+	case _rollback:
+		// check availability of arguments
+		if len(context.Args) != 3 {
+			SolutionUsage(true, context)
+			return
+		}
+
+		// re-apply the last successful deployment
+		uuid, err := c.RollbackSolution(context.Args[1], context.Args[2])
+		handleResult(context, err, "solution can not be rolled back", uuid)
+	case _drift:
+		// check availability of arguments
+		if len(context.Args) != 3 {
+			SolutionUsage(true, context)
+			return
+		}
+
+		// determine the drifted instances
+		drifts, err := c.GetDrift(context.Args[1], context.Args[2])
+		if err != nil {
+			handleResult(context, err, "solution can not be identified", "")
+			return
+		}
+
+		result, err := util.ConvertToYAML(drifts)
+		handleResult(context, err, "drift can not be displayed", result)
 	default:
 		SolutionUsage(true, context)
 	}
@@ -149,6 +300,8 @@ func SolutionUsage(header bool, context *ishell.Context) {
 	info += "           set <domain> <filename>\n"
 	info += "           get <domain> <solution>\n"
 	info += "           delete <domain> <solution>\n"
+	info += "           rollback <domain> <solution>\n"
+	info += "           drift <domain> <solution>\n"
 
   writeInfo(context, info)
 }
@@ -4,6 +4,7 @@ import (
 	"strconv"
 
 	ishell "gopkg.in/abiosoft/ishell.v2"
+	"tsai.eu/solar/client"
 	"tsai.eu/solar/model"
 	"tsai.eu/solar/util"
 	"tsai.eu/solar/engine"
@@ -135,11 +136,11 @@ func TaskCommand(context *ishell.Context, m *model.Model) {
 		}
 
 		// execute the command
-		// get event channel
-		channel := engine.GetEventChannel()
+		// get event queue
+		queue := engine.GetEventQueue()
 
 		// create event
-		channel <- model.NewEvent(context.Args[1], task.UUID, model.EventTypeTaskTermination, "", "initial")
+		queue.Push(model.NewEvent(context.Args[1], task.UUID, model.EventTypeTaskTermination, "", "initial"))
 
 		handleResult(context, nil, "task can not be terminated", "")
 	case _trace:
@@ -170,6 +171,108 @@ func TaskCommand(context *ishell.Context, m *model.Model) {
 
 //------------------------------------------------------------------------------
 
+// RemoteTaskCommand executes the task related subcommands on a remote server
+func RemoteTaskCommand(context *ishell.Context, c *client.Client) {
+	// check if the action has been defined
+	if len(context.Args) < 1 {
+		TaskUsage(true, context)
+		return
+	}
+
+	// determine the required action
+	action := context.Args[0]
+
+	// handle required action
+	switch action {
+	case "?":
+		TaskUsage(true, context)
+	case _list:
+		// check availability of arguments
+		if len(context.Args) < 2 || 6 < len(context.Args) {
+			TaskUsage(true, context)
+			return
+		}
+
+		// determine the tasks of the entity
+		summaries, err := c.ListTasks(context.Args[1], context.Args[2:]...)
+		if err != nil {
+			handleResult(context, err, "domain can not be identified", "")
+			return
+		}
+
+		tasks := []string{}
+		for _, summary := range summaries {
+			tasks = append(tasks, summary.UUID)
+		}
+
+		result, err := util.ConvertToYAML(tasks)
+		handleResult(context, err, "tasks could not be listed", result)
+	case _get:
+		// check availability of arguments
+		if len(context.Args) < 3 || 4 < len(context.Args){
+			TaskUsage(true, context)
+			return
+		}
+
+		// determine level
+		level := 0
+		if len(context.Args) == 4 {
+			value, err := strconv.Atoi(context.Args[3])
+			if err != nil {
+				handleResult(context, err, "invalid level (not an integer)", "")
+				return
+			}
+
+			if value < 0 {
+				handleResult(context, nil, "invalid level (must not be negative)", "")
+				return
+			}
+			level = value
+		}
+
+		// determine task
+		taskinfo, err := c.GetTask(context.Args[1], context.Args[2], level)
+		if err != nil {
+			handleResult(context, err, "task can not be identified", "")
+			return
+		}
+
+		// execute the command
+		result, err := util.ConvertToYAML(taskinfo)
+		handleResult(context, err, "task can not be displayed", result)
+	case _terminate:
+		// check availability of arguments
+		if len(context.Args) != 3 {
+			TaskUsage(true, context)
+			return
+		}
+
+		// execute the command
+		err := c.TerminateTask(context.Args[1], context.Args[2])
+		handleResult(context, err, "task can not be terminated", "")
+	case _trace:
+		// check availability of arguments
+		if len(context.Args) != 3 {
+			TaskUsage(true, context)
+			return
+		}
+
+		// determine the trace of the task
+		trace, err := c.TraceTask(context.Args[1], context.Args[2])
+		if err != nil {
+			handleResult(context, err, "task can not be identified", "")
+			return
+		}
+
+		result, err := util.ConvertToYAML(trace)
+		handleResult(context, err, "trace could not be created", result)
+	default:
+		TaskUsage(true, context)
+	}
+}
+
+//------------------------------------------------------------------------------
+
 // TaskUsage describes how to make use of the subcommand
 func TaskUsage(header bool, context *ishell.Context) {
 	info := ""
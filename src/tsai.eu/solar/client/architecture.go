@@ -0,0 +1,68 @@
+package client
+
+import (
+	"tsai.eu/solar/model"
+)
+
+//------------------------------------------------------------------------------
+
+// ListArchitectures lists the architectures of a domain as "<name> - <version>".
+func (client *Client) ListArchitectures(domainName string) ([]string, error) {
+	result := []string{}
+	err    := client.do("GET", path("architecture", domainName), nil, &result)
+	return result, err
+}
+
+//------------------------------------------------------------------------------
+
+// SetArchitecture validates an architecture and adds it to a domain.
+func (client *Client) SetArchitecture(domainName string, architecture *model.Architecture) error {
+	return client.do("POST", path("architecture", domainName), architecture, nil)
+}
+
+//------------------------------------------------------------------------------
+
+// ValidateArchitecture validates an architecture without adding it.
+func (client *Client) ValidateArchitecture(domainName string, architecture *model.Architecture) (*model.Validation, error) {
+	result := model.Validation{}
+	err    := client.do("POST", path("architecture", domainName, "validate"), architecture, &result)
+	return &result, err
+}
+
+//------------------------------------------------------------------------------
+
+// GetArchitecture retrieves an architecture.
+func (client *Client) GetArchitecture(domainName string, architectureName string, version string) (*model.Architecture, error) {
+	result := model.Architecture{}
+	err    := client.do("GET", path("architecture", domainName, architectureName, version), nil, &result)
+	return &result, err
+}
+
+//------------------------------------------------------------------------------
+
+// DeleteArchitecture deletes an architecture.
+func (client *Client) DeleteArchitecture(domainName string, architectureName string, version string) error {
+	return client.do("DELETE", path("architecture", domainName, architectureName, version), nil, nil)
+}
+
+//------------------------------------------------------------------------------
+
+// DeployArchitecture deploys an architecture and returns the uuid of the
+// solution task.
+func (client *Client) DeployArchitecture(domainName string, architectureName string, version string) (string, error) {
+	result := ""
+	err    := client.do("POST", path("architecture", domainName, architectureName, version), nil, &result)
+	return result, err
+}
+
+//------------------------------------------------------------------------------
+
+// PlanArchitecture determines the changes a deployment of an architecture
+// would apply to its solution.
+func (client *Client) PlanArchitecture(domainName string, architectureName string, version string) (*model.Plan, error) {
+	result := model.Plan{}
+	err    := client.do("POST", path("architecture", domainName, architectureName, version) + "?dryrun=true", nil, &result)
+	return &result, err
+}
+
+//------------------------------------------------------------------------------
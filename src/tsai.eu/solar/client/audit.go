@@ -0,0 +1,24 @@
+package client
+
+import (
+	"net/url"
+	"strconv"
+
+	"tsai.eu/solar/model"
+)
+
+//------------------------------------------------------------------------------
+
+// GetAudit retrieves a page of the audit log of a domain (all entries if
+// limit is 0).
+func (client *Client) GetAudit(domainName string, offset int, limit int) ([]*model.AuditEntry, error) {
+	query := url.Values{}
+	query.Set("offset", strconv.Itoa(offset))
+	query.Set("limit",  strconv.Itoa(limit))
+
+	result := []*model.AuditEntry{}
+	err    := client.do("GET", path("audit", domainName) + "?" + query.Encode(), nil, &result)
+	return result, err
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"io"
+	"errors"
+	"strings"
+	"net/url"
+	"net/http"
+	"io/ioutil"
+
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+// Client
+// ======
+//
+// The client drives a remote SOLAR server via its REST API. The operations
+// correspond to the routes documented in the OpenAPI document of the server
+// (GET /openapi.yaml).
+//------------------------------------------------------------------------------
+
+// Client holds the connection information of a remote server.
+type Client struct {
+	URL   string       // base url of the server, e.g. http://localhost:80
+	Token string       // bearer token (optional)
+	HTTP  *http.Client // http client executing the requests
+}
+
+//------------------------------------------------------------------------------
+
+// Error describes a request which has been rejected by the server.
+type Error struct {
+	Status  int    `yaml:"Status"`            // http status code
+	Message string `yaml:"Message"`           // description of the failure
+	Details string `yaml:"Details,omitempty"` // underlying cause of the failure
+}
+
+//------------------------------------------------------------------------------
+
+// Error describes the failure.
+func (err *Error) Error() string {
+	if err.Details == "" {
+		return err.Message
+	}
+	return err.Message + ":\n" + err.Details
+}
+
+//------------------------------------------------------------------------------
+
+// NewClient creates a client for a server.
+func NewClient(serverURL string, token string) *Client {
+	return &Client{
+		URL:   strings.TrimSuffix(serverURL, "/"),
+		Token: token,
+		HTTP:  http.DefaultClient,
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// path constructs the path of a resource from its escaped segments.
+func path(segments ...string) string {
+	result := ""
+	for _, segment := range segments {
+		result += "/" + url.PathEscape(segment)
+	}
+	return result
+}
+
+//------------------------------------------------------------------------------
+
+// do executes a request. The body is sent as YAML unless it is plain text. The
+// response is decoded into result which may be nil, a *string for plain text
+// or a pointer to an entity.
+func (client *Client) do(method string, resource string, body interface{}, result interface{}) error {
+	var reader io.Reader
+
+	contentType := "application/x-yaml"
+	switch value := body.(type) {
+	case nil:
+	case string:
+		contentType = "text/plain"
+		reader      = strings.NewReader(value)
+	default:
+		yaml, err := util.ConvertToYAML(body)
+		if err != nil {
+			return errors.New("unable to convert request:\n" + err.Error())
+		}
+		reader = strings.NewReader(yaml)
+	}
+
+	// prepare request
+	req, err := http.NewRequest(method, client.URL + resource, reader)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/x-yaml")
+	if reader != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if client.Token != "" {
+		req.Header.Set("Authorization", "Bearer " + client.Token)
+	}
+
+	// execute request
+	httpClient := client.HTTP
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// handle failures
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		failure := Error{}
+		if util.ConvertFromYAML(string(data), &failure) != nil || failure.Message == "" {
+			failure = Error{Message: strings.TrimSpace(string(data))}
+		}
+		failure.Status = resp.StatusCode
+		if failure.Message == "" {
+			failure.Message = http.StatusText(resp.StatusCode)
+		}
+		return &failure
+	}
+
+	// decode result
+	switch value := result.(type) {
+	case nil:
+	case *string:
+		*value = strings.TrimSpace(string(data))
+	default:
+		if err = util.ConvertFromYAML(string(data), result); err != nil {
+			return errors.New("unable to decode response:\n" + err.Error())
+		}
+	}
+
+	// success
+	return nil
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"net/http"
+	"net/http/httptest"
+
+	"tsai.eu/solar/api"
+	"tsai.eu/solar/model"
+)
+
+//------------------------------------------------------------------------------
+
+// TestClient01 drives the API of a local server.
+func TestClient01(t *testing.T) {
+	server := httptest.NewServer(api.NewRouter())
+	defer server.Close()
+
+	client := NewClient(server.URL + "/", "")
+
+	if err := client.ResetModel(); err != nil {
+		t.Fatalf("ResetModel should have reset the model:\n%s", err)
+	}
+
+	// domains
+	if err := client.CreateDomain("client"); err != nil {
+		t.Fatalf("CreateDomain should have created the domain:\n%s", err)
+	}
+
+	if domains, err := client.ListDomains(); err != nil || len(domains) != 1 || domains[0] != "client" {
+		t.Errorf("ListDomains should have listed the domain: %v", domains)
+	}
+
+	if domain, err := client.GetDomain("client"); err != nil || domain.Name != "client" {
+		t.Errorf("GetDomain should have retrieved the domain:\n%v", err)
+	}
+
+	// components
+	component, _ := model.NewComponent("server", "V1.0.0", "", "Internal")
+	if err := client.SetComponent("client", component); err != nil {
+		t.Errorf("SetComponent should have added the component:\n%s", err)
+	}
+
+	if result, err := client.GetComponent("client", "server", "V1.0.0"); err != nil || result.Component != "server" || result.Controller != "Internal" {
+		t.Errorf("GetComponent should have retrieved the component:\n%v", err)
+	}
+
+	// secrets
+	if err := client.SetSecret("client", "token", "TestClient01-value"); err != nil {
+		t.Errorf("SetSecret should have stored the secret:\n%s", err)
+	}
+
+	if secrets, err := client.ListSecrets("client"); err != nil || len(secrets) != 1 || secrets[0] != "token" {
+		t.Errorf("ListSecrets should have listed the secret: %v", secrets)
+	}
+
+	// failures
+	_, err := client.GetDomain("unknown")
+	failure, ok := err.(*Error)
+	if !ok || failure.Status != http.StatusBadRequest || failure.Message != "domain can not be identified" {
+		t.Errorf("GetDomain should have reported a structured error: %v", err)
+	}
+
+	if _, err = client.Stream(context.Background(), "unknown", "", ""); err == nil {
+		t.Errorf("Stream should have rejected an unknown domain")
+	}
+
+	// notifications
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	notifications, err := client.Stream(ctx, "client", "app", "")
+	if err != nil {
+		t.Fatalf("Stream should have followed the domain:\n%s", err)
+	}
+
+	model.PublishState(model.NotificationInstance, "client/app/web/V1/0", model.ActiveState)
+	if notification := <-notifications; notification == nil || notification.Instance != "0" || notification.State != model.ActiveState {
+		t.Errorf("Stream should have delivered the state change: %v", notification)
+	}
+
+	cancel()
+
+	if err = client.DeleteDomain("client"); err != nil {
+		t.Errorf("DeleteDomain should have deleted the domain:\n%s", err)
+	}
+}
+
+//------------------------------------------------------------------------------
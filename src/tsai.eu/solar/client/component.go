@@ -0,0 +1,48 @@
+package client
+
+import (
+	"tsai.eu/solar/model"
+)
+
+//------------------------------------------------------------------------------
+
+// GetCatalog retrieves the components of a domain including their parameters.
+func (client *Client) GetCatalog(domainName string) ([]*model.Component, error) {
+	result := []*model.Component{}
+	err    := client.do("GET", path("catalog", domainName), nil, &result)
+	return result, err
+}
+
+//------------------------------------------------------------------------------
+
+// ListComponents lists the names and versions of the components of a domain.
+func (client *Client) ListComponents(domainName string) ([][2]string, error) {
+	result := [][2]string{}
+	err    := client.do("GET", path("component", domainName), nil, &result)
+	return result, err
+}
+
+//------------------------------------------------------------------------------
+
+// SetComponent adds a component to a domain.
+func (client *Client) SetComponent(domainName string, component *model.Component) error {
+	return client.do("POST", path("component", domainName), component, nil)
+}
+
+//------------------------------------------------------------------------------
+
+// GetComponent retrieves a component.
+func (client *Client) GetComponent(domainName string, componentName string, version string) (*model.Component, error) {
+	result := model.Component{}
+	err    := client.do("GET", path("component", domainName, componentName, version), nil, &result)
+	return &result, err
+}
+
+//------------------------------------------------------------------------------
+
+// DeleteComponent deletes a component.
+func (client *Client) DeleteComponent(domainName string, componentName string, version string) error {
+	return client.do("DELETE", path("component", domainName, componentName, version), nil, nil)
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"tsai.eu/solar/model"
+)
+
+//------------------------------------------------------------------------------
+
+// ListControllers retrieves the controllers of a domain.
+func (client *Client) ListControllers(domainName string) ([]*model.Controller, error) {
+	result := []*model.Controller{}
+	err    := client.do("GET", path("controller", domainName), nil, &result)
+	return result, err
+}
+
+//------------------------------------------------------------------------------
+
+// SetController adds a controller to a domain.
+func (client *Client) SetController(domainName string, controller *model.Controller) error {
+	return client.do("POST", path("controller", domainName), controller, nil)
+}
+
+//------------------------------------------------------------------------------
+
+// GetController retrieves a controller.
+func (client *Client) GetController(domainName string, controllerName string, version string) (*model.Controller, error) {
+	result := model.Controller{}
+	err    := client.do("GET", path("controller", domainName, controllerName, version), nil, &result)
+	return &result, err
+}
+
+//------------------------------------------------------------------------------
+
+// DeleteController deletes a controller.
+func (client *Client) DeleteController(domainName string, controllerName string, version string) error {
+	return client.do("DELETE", path("controller", domainName, controllerName, version), nil, nil)
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"tsai.eu/solar/model"
+)
+
+//------------------------------------------------------------------------------
+
+// ListDomains lists the names of the domains.
+func (client *Client) ListDomains() ([]string, error) {
+	result := []string{}
+	err    := client.do("GET", path("domain"), nil, &result)
+	return result, err
+}
+
+//------------------------------------------------------------------------------
+
+// SetDomain adds a domain.
+func (client *Client) SetDomain(domain *model.Domain) error {
+	return client.do("POST", path("domain"), domain, nil)
+}
+
+//------------------------------------------------------------------------------
+
+// CreateDomain creates an empty domain.
+func (client *Client) CreateDomain(domainName string) error {
+	return client.do("POST", path("domain", domainName), nil, nil)
+}
+
+//------------------------------------------------------------------------------
+
+// GetDomain retrieves a domain.
+func (client *Client) GetDomain(domainName string) (*model.Domain, error) {
+	result := model.Domain{}
+	err    := client.do("GET", path("domain", domainName), nil, &result)
+	return &result, err
+}
+
+//------------------------------------------------------------------------------
+
+// ResetDomain removes all artefacts of a domain.
+func (client *Client) ResetDomain(domainName string) error {
+	return client.do("PUT", path("domain", domainName), nil, nil)
+}
+
+//------------------------------------------------------------------------------
+
+// DeleteDomain deletes a domain.
+func (client *Client) DeleteDomain(domainName string) error {
+	return client.do("DELETE", path("domain", domainName), nil, nil)
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"tsai.eu/solar/model"
+	"tsai.eu/solar/engine"
+)
+
+//------------------------------------------------------------------------------
+
+// GetModel retrieves the model.
+func (client *Client) GetModel() (*model.Model, error) {
+	result := model.Model{}
+	err    := client.do("GET", path("model"), nil, &result)
+	return &result, err
+}
+
+//------------------------------------------------------------------------------
+
+// SetModel replaces the model.
+func (client *Client) SetModel(m *model.Model) error {
+	return client.do("POST", path("model"), m, nil)
+}
+
+//------------------------------------------------------------------------------
+
+// ResetModel resets the model.
+func (client *Client) ResetModel() error {
+	return client.do("PUT", path("model"), nil, nil)
+}
+
+//------------------------------------------------------------------------------
+
+// GetQueue retrieves the metrics of the event queue.
+func (client *Client) GetQueue() (*engine.QueueMetrics, error) {
+	result := engine.QueueMetrics{}
+	err    := client.do("GET", path("queue"), nil, &result)
+	return &result, err
+}
+
+//------------------------------------------------------------------------------
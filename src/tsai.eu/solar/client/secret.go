@@ -0,0 +1,26 @@
+package client
+
+//------------------------------------------------------------------------------
+
+// ListSecrets lists the names of the secrets of a domain.
+func (client *Client) ListSecrets(domainName string) ([]string, error) {
+	result := []string{}
+	err    := client.do("GET", path("secret", domainName), nil, &result)
+	return result, err
+}
+
+//------------------------------------------------------------------------------
+
+// SetSecret sets the value of a secret.
+func (client *Client) SetSecret(domainName string, secretName string, value string) error {
+	return client.do("POST", path("secret", domainName, secretName), value, nil)
+}
+
+//------------------------------------------------------------------------------
+
+// DeleteSecret deletes a secret.
+func (client *Client) DeleteSecret(domainName string, secretName string) error {
+	return client.do("DELETE", path("secret", domainName, secretName), nil, nil)
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"tsai.eu/solar/api"
+	"tsai.eu/solar/model"
+)
+
+//------------------------------------------------------------------------------
+
+// ListSolutions lists the names of the solutions of a domain.
+func (client *Client) ListSolutions(domainName string) ([]string, error) {
+	result := []string{}
+	err    := client.do("GET", path("solution", domainName), nil, &result)
+	return result, err
+}
+
+//------------------------------------------------------------------------------
+
+// SetSolution adds a solution to a domain.
+func (client *Client) SetSolution(domainName string, solution *model.Solution) error {
+	return client.do("POST", path("solution", domainName), solution, nil)
+}
+
+//------------------------------------------------------------------------------
+
+// GetSolution retrieves a solution.
+func (client *Client) GetSolution(domainName string, solutionName string) (*model.Solution, error) {
+	result := model.Solution{}
+	err    := client.do("GET", path("solution", domainName, solutionName), nil, &result)
+	return &result, err
+}
+
+//------------------------------------------------------------------------------
+
+// DeleteSolution deletes a solution.
+func (client *Client) DeleteSolution(domainName string, solutionName string) error {
+	return client.do("DELETE", path("solution", domainName, solutionName), nil, nil)
+}
+
+//------------------------------------------------------------------------------
+
+// DeploySolution deploys a version of the architecture of a solution and
+// returns the uuid of the solution task.
+func (client *Client) DeploySolution(domainName string, solutionName string, version string) (string, error) {
+	result := ""
+	err    := client.do("POST", path("solution", domainName, solutionName, version), nil, &result)
+	return result, err
+}
+
+//------------------------------------------------------------------------------
+
+// RollbackSolution re-applies the last successful deployment of a solution
+// and returns the uuid of the solution task.
+func (client *Client) RollbackSolution(domainName string, solutionName string) (string, error) {
+	result := ""
+	err    := client.do("POST", path("solution", domainName, solutionName, "rollback"), nil, &result)
+	return result, err
+}
+
+//------------------------------------------------------------------------------
+
+// GetDrift lists the instances of a solution with configuration drift.
+func (client *Client) GetDrift(domainName string, solutionName string) ([]*model.Drift, error) {
+	result := []*model.Drift{}
+	err    := client.do("GET", path("solution", domainName, solutionName, "drift"), nil, &result)
+	return result, err
+}
+
+//------------------------------------------------------------------------------
+
+// UpdateCluster updates the target state and size of a cluster and returns
+// the uuid of the cluster task.
+func (client *Client) UpdateCluster(domainName string, solutionName string, elementName string, clusterName string, update *api.ClusterUpdateInformation) (string, error) {
+	result := ""
+	err    := client.do("PUT", path("cluster", domainName, solutionName, elementName, clusterName), update, &result)
+	return result, err
+}
+
+//------------------------------------------------------------------------------
+
+// UpdateInstance updates the target state of an instance and returns the
+// uuid of the instance task.
+func (client *Client) UpdateInstance(domainName string, solutionName string, elementName string, clusterName string, instanceName string, update *api.InstanceUpdateInformation) (string, error) {
+	result := ""
+	err    := client.do("PUT", path("instance", domainName, solutionName, elementName, clusterName, instanceName), update, &result)
+	return result, err
+}
+
+//------------------------------------------------------------------------------
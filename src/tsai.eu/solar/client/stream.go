@@ -0,0 +1,103 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"strings"
+	"net/url"
+	"net/http"
+	"io/ioutil"
+	"encoding/json"
+
+	"tsai.eu/solar/model"
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// Stream follows the events and state changes of a domain, optionally
+// restricted to a solution and an element. The channel is closed when the
+// context is cancelled or the server closes the stream.
+func (client *Client) Stream(ctx context.Context, domainName string, solutionName string, elementName string) (<-chan *model.Notification, error) {
+	query := url.Values{}
+	if solutionName != "" {
+		query.Set("solution", solutionName)
+	}
+	if elementName != "" {
+		query.Set("element", elementName)
+	}
+
+	resource := path("stream", domainName)
+	if len(query) > 0 {
+		resource += "?" + query.Encode()
+	}
+
+	// prepare request
+	req, err := http.NewRequest("GET", client.URL + resource, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	req.Header.Set("Accept", "text/event-stream")
+	if client.Token != "" {
+		req.Header.Set("Authorization", "Bearer " + client.Token)
+	}
+
+	// execute request
+	httpClient := client.HTTP
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// handle failures
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+
+		data, _ := ioutil.ReadAll(resp.Body)
+		failure := Error{}
+		if util.ConvertFromYAML(string(data), &failure) != nil || failure.Message == "" {
+			failure = Error{Message: strings.TrimSpace(string(data))}
+		}
+		failure.Status = resp.StatusCode
+		return nil, &failure
+	}
+
+	// forward the notifications
+	notifications := make(chan *model.Notification)
+
+	go func() {
+		defer close(notifications)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+
+			notification := model.Notification{}
+			if json.Unmarshal([]byte(line[len("data: "):]), &notification) != nil {
+				continue
+			}
+
+			select {
+			case notifications <- &notification:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// success
+	return notifications, nil
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"strconv"
+
+	"tsai.eu/solar/api"
+	"tsai.eu/solar/model"
+)
+
+//------------------------------------------------------------------------------
+
+// ListTasks lists the tasks of a domain or of the entity identified by the
+// optional solution, element, cluster and instance names.
+func (client *Client) ListTasks(domainName string, names ...string) ([]*api.TaskSummary, error) {
+	result := []*api.TaskSummary{}
+	err    := client.do("GET", path(append([]string{"tasks", domainName}, names...)...), nil, &result)
+	return result, err
+}
+
+//------------------------------------------------------------------------------
+
+// GetTask retrieves a task and its subtasks up to a level.
+func (client *Client) GetTask(domainName string, taskName string, level int) (*model.TaskInfo, error) {
+	result := model.TaskInfo{}
+	err    := client.do("GET", path("task", domainName, taskName, strconv.Itoa(level)), nil, &result)
+	return &result, err
+}
+
+//------------------------------------------------------------------------------
+
+// TraceTask retrieves the trace of a task.
+func (client *Client) TraceTask(domainName string, taskName string) (*model.Trace, error) {
+	result := model.Trace{}
+	err    := client.do("GET", path("task", domainName, taskName), nil, &result)
+	return &result, err
+}
+
+//------------------------------------------------------------------------------
+
+// TerminateTask terminates a task.
+func (client *Client) TerminateTask(domainName string, taskName string) error {
+	return client.do("DELETE", path("task", domainName, taskName), nil, nil)
+}
+
+//------------------------------------------------------------------------------
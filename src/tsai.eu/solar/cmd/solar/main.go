@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"tsai.eu/solar/engine"
 	"tsai.eu/solar/api"
@@ -10,6 +11,8 @@ import (
 	"tsai.eu/solar/controller"
 	"tsai.eu/solar/msg"
 	"tsai.eu/solar/cli"
+	"tsai.eu/solar/client"
+	"tsai.eu/solar/store"
 	"tsai.eu/solar/util"
 )
 
@@ -18,6 +21,7 @@ import (
 // Control holds a handle to all running process
 type Control struct {
 	Cancel     context.CancelFunc      // process context
+	Store      *store.Store            // model store
 	Dispatcher *engine.Dispatcher      // the orchestration engine
 	MSG        *msg.MSG                // messaging interface
 	Monitor    *monitor.Monitor	       // monitoring process
@@ -43,8 +47,16 @@ func main() {
   mainCtx, cancelFunction := context.WithCancel(ctx)
 	control.Cancel = cancelFunction
 
+	// exit code of non-interactive invocations
+	exitCode := cli.ExitOK
+
 	// defer canceling so that all the resources are freed up for this and the derived contexts
-  defer func() { terminate(&control) }()
+  defer func() {
+		terminate(&control)
+		if exitCode != cli.ExitOK {
+			os.Exit(exitCode)
+		}
+	}()
 
 	// parse configuration file 'solar-conf.yaml' in local directory and initiate logging
 	util.StartLogging()
@@ -52,8 +64,38 @@ func main() {
 	// initialise command line options
 	util.ParseCommandLineOptions()
 
+	if err := cli.SetFormat(util.Format()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		exitCode = cli.ExitUsage
+		return
+	}
+
+	// a command provided as arguments is executed non-interactively
+	args := util.Args()
+
 	// display progam information
-	fmt.Println("SOLAR Version 1.0.0")
+	if len(args) == 0 {
+		fmt.Println("SOLAR Version 1.0.0")
+	}
+
+	// manage a remote server via its REST API
+	if util.Remote() != "" {
+		remote := client.NewClient(util.Remote(), util.Token())
+		shell  := cli.RemoteShell(remote)
+
+		if len(args) > 0 {
+			exitCode = cli.Execute(shell, args, cli.RemoteTaskStatus(remote), util.Wait(), util.Timeout())
+			return
+		}
+
+		fmt.Println("Remote server: " + util.Remote())
+
+		shell.Run()
+		return
+	}
+
+	// restore the model from the store
+	control.Store, _ = store.Start(mainCtx)
 
 	// start the controller manager
 	control.Controller = controller.Start(mainCtx)
@@ -61,9 +103,18 @@ func main() {
 	// start the main event loop
 	control.Dispatcher = engine.Start(mainCtx)
 
+	// recover the tasks of the restored model
+	engine.Restore()
+
 	// start the messaging interface listener
 	control.MSG, _ = msg.Start(mainCtx)
 
+	// execute a single command without monitoring loop and API
+	if len(args) > 0 {
+		exitCode = cli.Execute(cli.Shell(), args, cli.LocalTaskStatus, util.Wait(), util.Timeout())
+		return
+	}
+
 	// start the monitoring loop
 	control.Monitor = monitor.Start(mainCtx)
 
@@ -82,6 +133,11 @@ func main() {
 func terminate(control *Control) {
 	// close the main process context
 	control.Cancel()
+
+	// write the final state of the model
+	if control.Store != nil {
+		control.Store.Flush()
+	}
 }
 
 //------------------------------------------------------------------------------
@@ -2,24 +2,70 @@ package controller
 
 //------------------------------------------------------------------------------
 
+// ProtocolVersion is the version of the controller protocol spoken by SOLAR.
+// Version 1 requests only carry the desired state and configuration of the
+// instance. Version 2 adds the relationships, the peer instances, the sizing
+// of the cluster and the configurations of the element and the solution as
+// well as the bind and unbind actions. Version 3 adds asynchronous
+// operations: a controller may answer a request with 202 Accepted and the id
+// of an operation whose result is polled or reported by a callback to the url
+// provided by the request.
+// Controllers which do not report a protocol version in their responses are
+// regarded to speak version 1, they ignore the additional fields of a request.
+const ProtocolVersion int = 3
+
+//------------------------------------------------------------------------------
+
+// RelationshipState describes the current state of a relationship.
+type RelationshipState struct {
+  Relationship  string `yaml:"Relationship"`  // name of relationship
+  Dependency    string `yaml:"Dependency"`    // name of dependency
+  State         string `yaml:"State"`         // state of relationship (active if bound)
+  Configuration string `yaml:"Configuration"` // configuration information
+  Endpoint      string `yaml:"Endpoint"`      // endpoint information of the related cluster in yaml format
+}
+
+//------------------------------------------------------------------------------
+
+// InstanceState describes the current state of a peer instance.
+type InstanceState struct {
+  Instance string `yaml:"Instance"` // id of an instance
+  State    string `yaml:"State"`    // state of an instance
+  Endpoint string `yaml:"Endpoint"` // endpoint information in yaml format
+}
+
+//------------------------------------------------------------------------------
+
 // Request sent to controller.
 type Request struct {
-  Request       string `yaml:"Request"`               // request ID
-  Domain        string `yaml:"Domain"`                // name of the domain
-  Solution      string `yaml:"Solution"`              // name of solution
-	Version       string `yaml:"Version"`               // version of solution
-  Element       string `yaml:"Element"`               // name of element
-  Cluster       string `yaml:"Cluster"`               // name of cluster
-  Instance      string `yaml:"Instance"`              // name of instance
-  Component     string `yaml:"Component"`             // component type of instance
-  State         string `yaml:"State"`                 // state of instance
-	Configuration string `yaml:"Configuration"`         // configuration of instance
+  Protocol              int                 `yaml:"Protocol"`                // version of the controller protocol
+  Request               string              `yaml:"Request"`                 // request ID
+  Domain                string              `yaml:"Domain"`                  // name of the domain
+  Solution              string              `yaml:"Solution"`                // name of solution
+  Version               string              `yaml:"Version"`                 // version of solution
+  Element               string              `yaml:"Element"`                 // name of element
+  Cluster               string              `yaml:"Cluster"`                 // name of cluster
+  Instance              string              `yaml:"Instance"`                // name of instance
+  Component             string              `yaml:"Component"`               // component type of instance
+  State                 string              `yaml:"State"`                   // state of instance
+  Configuration         string              `yaml:"Configuration"`           // configuration of instance
+  Min                   int                 `yaml:"Min"`                     // min. size of the solution element cluster
+  Max                   int                 `yaml:"Max"`                     // max. size of the solution element cluster
+  Size                  int                 `yaml:"Size"`                    // size of the solution element cluster
+  ElementConfiguration  string              `yaml:"ElementConfiguration"`    // configuration of the solution element
+  SolutionConfiguration string              `yaml:"SolutionConfiguration"`   // configuration of the solution
+  Relationships         []RelationshipState `yaml:"Relationships"`           // current state of all relationships of the cluster
+  Instances             []InstanceState     `yaml:"Instances"`               // current state of all instances of the cluster
+  Relationship          string              `yaml:"Relationship,omitempty"`  // relationship to be bound or unbound (bind/unbind)
+  Endpoint              string              `yaml:"Endpoint,omitempty"`      // endpoint of the cluster referenced by the relationship (bind/unbind)
+  Callback              string              `yaml:"Callback,omitempty"`      // url to which the result of an asynchronous operation is posted as <url>/<operation>
 }
 
 //------------------------------------------------------------------------------
 
 // Response received from controller.
 type Response struct {
+  Protocol      int    `yaml:"Protocol,omitempty"`    // version of the controller protocol spoken by the controller (1 if undefined)
   Request       string `yaml:"Request"`               // request ID
   Action        string `yaml:"Action"`                // requested action
   Code          int    `yaml:"Code"`                  // response code
@@ -34,6 +80,8 @@ type Response struct {
   State         string `yaml:"State"`                 // state of instance
 	Configuration string `yaml:"Configuration"`         // configuration of instance
 	Endpoint      string `yaml:"Endpoint"`              // endpoint of instance
+  Operation     string `yaml:"Operation,omitempty"`   // id of the asynchronous operation (202 Accepted)
+  Timeout       string `yaml:"Timeout,omitempty"`     // max. duration of the asynchronous operation, e.g. "10m"
 }
 
 //------------------------------------------------------------------------------
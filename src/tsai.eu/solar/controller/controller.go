@@ -2,6 +2,8 @@ package controller
 
 import (
 	"sync"
+	"errors"
+	"strings"
 	// "context"
 
 	"tsai.eu/solar/model"
@@ -21,44 +23,107 @@ type Controller interface {
 	Start(       setup *model.TargetState) (status *model.CurrentState, err error)
 	Stop(        setup *model.TargetState) (status *model.CurrentState, err error)
 	Reset(       setup *model.TargetState) (status *model.CurrentState, err error)
+	Bind(        setup *model.TargetState) (status *model.CurrentState, err error)
+	Unbind(      setup *model.TargetState) (status *model.CurrentState, err error)
+	Poll(        setup *model.TargetState, operation string) (status *model.CurrentState, err error)
 }
 
 //------------------------------------------------------------------------------
 
-var controllers   map[string]Controller   // controller map
-var defController Controller              // default controller
-var initCtrls     sync.Once               // initialisation guard
+// InternalController is the name of the built-in controller
+const InternalController string = "Internal"
+
+//------------------------------------------------------------------------------
+
+var controllers   map[string]*RestController // cache of rest controllers
+var controllersX  sync.RWMutex                // mutex for the cache
+var defController Controller                  // default controller
+var initCtrls     sync.Once                   // initialisation guard
 
 var port          int                     // next free port
 var initPort      sync.Once               // initialisation guard
 
 //------------------------------------------------------------------------------
 
-// GetController retrieves a controller for a specific version of a controller.
-func GetController(controllerVersion string) (Controller, error) {
+// GetController retrieves the controller for a component controller
+// reference of the form "<controller>:<version>" within a domain.
+func GetController(domainName string, controllerNameVersion string) (Controller, error) {
 	// initialise singleton once
 	initCtrls.Do(func() {
-		// create empty map of controllers
-		controllers = map[string]Controller{}
+		// create empty cache of controllers
+		controllers = map[string]*RestController{}
 
 		// initialise the default internal controller
 		defController = internalController.NewController()
-		controllers["internal:V1.0.0"] = defController
 		util.LogInfo("main", "CTL", "internal - controller active")
 	})
 
-	// determine controller (if unknown use the default controller)
-	controller, found := controllers[controllerVersion]
-	if found {
-		return controller, nil
+	// determine name and version of the controller
+	controllerName, controllerVersion := splitControllerName(controllerNameVersion)
+
+	// offer the internal controller if requested
+	if controllerName == "" || strings.EqualFold(controllerName, InternalController) {
+		return defController, nil
+	}
+
+	key := domainName + "/" + controllerName + ":" + controllerVersion
+
+	// determine the controller registered within the domain
+	ctrl, err := model.GetController(domainName, controllerName, controllerVersion)
+	if err != nil {
+		forgetController(key)
+		return nil, errors.New("unknown controller: '" + controllerNameVersion + "' within domain: '" + domainName + "'")
+	}
+
+	// check if the controller is available
+	if ctrl.Status != model.ActiveState || ctrl.URL == "" {
+		forgetController(key)
+		return nil, errors.New("controller: '" + controllerNameVersion + "' within domain: '" + domainName + "' is not active")
 	}
 
-	// offer the default internal controller
-	return defController, nil
+	// reuse the cached client if the controller has not been relocated
+	controllersX.RLock()
+	client, found := controllers[key]
+	controllersX.RUnlock()
 
+	if found && client.URL == ctrl.URL {
+		return client, nil
+	}
+
+	// create a new client for the controller
+	client, err = newRestController(controllerName, controllerVersion, ctrl.URL)
+	if err != nil {
+		forgetController(key)
+		return nil, err
+	}
+
+	controllersX.Lock()
+	controllers[key] = client
+	controllersX.Unlock()
+
+	// success
+	return client, nil
+}
+
+//------------------------------------------------------------------------------
+
+// forgetController removes a controller client from the cache.
+func forgetController(key string) {
+	controllersX.Lock()
+	delete(controllers, key)
+	controllersX.Unlock()
+}
+
+//------------------------------------------------------------------------------
+
+// splitControllerName splits a controller reference into name and version.
+func splitControllerName(controllerNameVersion string) (string, string) {
+	parts := strings.SplitN(controllerNameVersion, ":", 2)
+	if len(parts) == 2 {
+		return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+	}
 
-	// TODO: log unknown controller type and error
-	// return nil, errors.New("unknown type")
+	return strings.TrimSpace(controllerNameVersion), ""
 }
 
 //------------------------------------------------------------------------------
@@ -5,8 +5,12 @@ import (
   "io"
   "os"
   "time"
+  "io/ioutil"
+  "net/http"
+  "net/http/httptest"
 
   "tsai.eu/solar/model"
+  "tsai.eu/solar/util"
 )
 
 //------------------------------------------------------------------------------
@@ -39,9 +43,9 @@ func TestController01(t *testing.T) {
   time.Sleep(time.Millisecond)
 
   // GetController test
-  ctrl, err := GetController("default:V1.0.0")
+  ctrl, err := GetController("demo", "Internal:V1.0.0")
   if err != nil {
-    t.Errorf("GetController is unable to find default controller")
+    t.Errorf("GetController is unable to find the internal controller")
   }
 
   // load model
@@ -54,7 +58,9 @@ func TestController01(t *testing.T) {
   ctrl.Create(s)
   ctrl.Configure(s)
   ctrl.Start(s)
+  ctrl.Bind(s)
   ctrl.Reconfigure(s)
+  ctrl.Unbind(s)
   ctrl.Stop(s)
   ctrl.Destroy(s)
   ctrl.Reset(s)
@@ -62,3 +68,258 @@ func TestController01(t *testing.T) {
 }
 
 //------------------------------------------------------------------------------
+
+// TestController02 evaluates the resolution of rest controllers
+func TestController02(t *testing.T) {
+  // simulate an external controller
+  server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    if r.Method == "GET" {
+      w.Write([]byte("SOLAR:Test:V1.0.0"))
+      return
+    }
+    w.Write([]byte("Code: 200\nState: active\n"))
+  }))
+  defer server.Close()
+
+  // register the controller within a domain
+  domain, _ := model.NewDomain("rest")
+  model.GetModel().AddDomain(domain)
+  defer model.GetModel().DeleteDomain("rest")
+
+  ctrl, _ := model.NewController("Test", "V1.0.0")
+  domain.AddController(ctrl)
+
+  // unknown controllers must not fall back to the internal controller
+  _, err := GetController("rest", "Unknown:V1.0.0")
+  if err == nil {
+    t.Errorf("GetController should have complained about an unknown controller")
+  }
+
+  // inactive controllers must not be used
+  _, err = GetController("rest", "Test:V1.0.0")
+  if err == nil {
+    t.Errorf("GetController should have complained about an inactive controller")
+  }
+
+  // active controllers are resolved to a rest controller
+  ctrl.URL    = server.URL
+  ctrl.Status = model.ActiveState
+
+  c, err := GetController("rest", "Test:V1.0.0")
+  if err != nil {
+    t.Errorf("GetController should have returned the active controller:\n%s", err)
+  }
+
+  restController, ok := c.(*RestController)
+  if !ok || restController.URL != server.URL {
+    t.Errorf("GetController should have returned a rest controller for: %s", server.URL)
+  }
+
+  s := &model.TargetState{Domain: "rest", State: model.ActiveState}
+
+  currentState, err := c.Create(s)
+  if err != nil || currentState.State != model.ActiveState {
+    t.Errorf("RestController should have returned the state reported by the controller")
+  }
+
+  // relocated controllers are resolved again
+  ctrl.URL = server.URL + "/"
+
+  c, err = GetController("rest", "Test:V1.0.0")
+  if err != nil || c.(*RestController).URL != ctrl.URL {
+    t.Errorf("GetController should have followed the relocated controller")
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// TestController03 evaluates the binding of relationships by rest controllers
+func TestController03(t *testing.T) {
+  action  := ""
+  request := Request{}
+
+  // simulate an external controller
+  server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    if r.Method == "GET" {
+      w.Write([]byte("SOLAR:Test:V1.0.0"))
+      return
+    }
+    body, _ := ioutil.ReadAll(r.Body)
+    util.ConvertFromYAML(string(body), &request)
+    action = r.URL.Path
+
+    w.Write([]byte("Protocol: 2\nCode: 200\nState: active\n"))
+  }))
+  defer server.Close()
+
+  c, err := newRestController("Test", "V1.0.0", server.URL)
+  if err != nil {
+    t.Fatalf("newRestController should have accepted the controller:\n%s", err)
+  }
+
+  s := &model.TargetState{
+    Domain:        "rest",
+    State:         model.ActiveState,
+    Relationship:  "db",
+    Relationships: []model.RelationshipState{
+      {Relationship: "cache", Endpoint: "Host: cache"},
+      {Relationship: "db",    Endpoint: "Host: db"},
+    },
+  }
+
+  if _, err = c.Bind(s); err != nil || action != "/bind" {
+    t.Errorf("RestController should have requested the binding:\n%s", err)
+  }
+
+  if request.Relationship != "db" || request.Endpoint != "Host: db" {
+    t.Errorf("RestController should have sent the relationship and the endpoint of the related cluster: %s - %s", request.Relationship, request.Endpoint)
+  }
+
+  if _, err = c.Unbind(s); err != nil || action != "/unbind" {
+    t.Errorf("RestController should have requested the release of the binding:\n%s", err)
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// TestController04 evaluates the protocol versions of rest controllers
+func TestController04(t *testing.T) {
+  actions := []string{}
+  request := Request{}
+
+  // simulate an external controller which speaks protocol version 1
+  server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    if r.Method == "GET" {
+      w.Write([]byte("SOLAR:Test:V1.0.0"))
+      return
+    }
+    body, _ := ioutil.ReadAll(r.Body)
+    util.ConvertFromYAML(string(body), &request)
+    actions = append(actions, r.URL.Path)
+
+    w.Write([]byte("Code: 200\nState: active\n"))
+  }))
+  defer server.Close()
+
+  c, err := newRestController("Test", "V1.0.0", server.URL)
+  if err != nil {
+    t.Fatalf("newRestController should have accepted the controller:\n%s", err)
+  }
+
+  s := &model.TargetState{
+    Domain:                "rest",
+    State:                 model.ActiveState,
+    Min:                   1,
+    Max:                   3,
+    Size:                  2,
+    ElementConfiguration:  "Element: db",
+    SolutionConfiguration: "Solution: app",
+    Relationship:          "db",
+    Relationships:         []model.RelationshipState{
+      {Relationship: "db", Dependency: "database", State: model.ActiveState, Endpoint: "Host: db"},
+    },
+    Instances:             []model.InstanceState{
+      {Instance: "a", State: model.ActiveState, Endpoint: "Host: a"},
+      {Instance: "b", State: model.InitialState},
+    },
+  }
+
+  if _, err = c.Status(s); err != nil {
+    t.Fatalf("RestController should have requested the status:\n%s", err)
+  }
+
+  if request.Protocol != ProtocolVersion || request.Min != 1 || request.Max != 3 || request.Size != 2 {
+    t.Errorf("RestController should have sent the protocol version and the sizing of the cluster")
+  }
+
+  if request.ElementConfiguration != "Element: db" || request.SolutionConfiguration != "Solution: app" {
+    t.Errorf("RestController should have sent the configurations of the element and the solution")
+  }
+
+  if len(request.Relationships) != 1 || request.Relationships[0].State != model.ActiveState || request.Relationships[0].Endpoint != "Host: db" {
+    t.Errorf("RestController should have sent the state of the relationships")
+  }
+
+  if len(request.Instances) != 2 || request.Instances[0].Endpoint != "Host: a" || request.Instances[1].State != model.InitialState {
+    t.Errorf("RestController should have sent the state of the peer instances")
+  }
+
+  // bindings are reduced to status requests for older controllers
+  if _, err = c.Bind(s); err != nil || actions[len(actions) - 1] != "/status" {
+    t.Errorf("RestController should have requested the status instead of the binding: %v", actions)
+  }
+
+  if _, err = c.Unbind(s); err != nil || actions[len(actions) - 1] != "/status" {
+    t.Errorf("RestController should have requested the status instead of the release of the binding: %v", actions)
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// TestController05 evaluates asynchronous operations of rest controllers
+func TestController05(t *testing.T) {
+  polls := 0
+
+  // simulate an external controller which executes the creation asynchronously
+  server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    switch {
+    case r.Method == "GET" && r.URL.Path == "/":
+      w.Write([]byte("SOLAR:Test:V1.0.0"))
+    case r.Method == "POST":
+      w.WriteHeader(http.StatusAccepted)
+      w.Write([]byte("Protocol: 3\nCode: 202\nOperation: op-42\nTimeout: 10m\n"))
+    case r.URL.Path == "/operation/op-42" && polls == 0:
+      polls++
+      w.WriteHeader(http.StatusAccepted)
+      w.Write([]byte("Protocol: 3\nCode: 202\nOperation: op-42\n"))
+    case r.URL.Path == "/operation/op-42":
+      polls++
+      w.Write([]byte("Protocol: 3\nCode: 200\nDomain: rest\nState: active\n"))
+    default:
+      w.WriteHeader(http.StatusNotFound)
+      w.Write([]byte("Code: 404\nStatus: unknown operation\n"))
+    }
+  }))
+  defer server.Close()
+
+  c, err := newRestController("Test", "V1.0.0", server.URL)
+  if err != nil {
+    t.Fatalf("newRestController should have accepted the controller:\n%s", err)
+  }
+
+  s := &model.TargetState{Domain: "rest", State: model.ActiveState}
+
+  // the controller accepts the operation
+  currentState, err := c.Create(s)
+  if err != nil || currentState.Operation != "op-42" || currentState.Timeout != "10m" {
+    t.Fatalf("RestController should have reported the pending operation: %v\n%v", currentState, err)
+  }
+
+  // the operation is still running
+  currentState, err = c.Poll(s, "op-42")
+  if err != nil || currentState.Operation != "op-42" {
+    t.Errorf("RestController should have reported the running operation: %v\n%v", currentState, err)
+  }
+
+  // the operation has finished
+  currentState, err = c.Poll(s, "op-42")
+  if err != nil || currentState.Operation != "" || currentState.State != model.ActiveState {
+    t.Errorf("RestController should have reported the result of the operation: %v\n%v", currentState, err)
+  }
+
+  // unknown operations are rejected
+  if _, err = c.Poll(s, "op-43"); err == nil {
+    t.Errorf("RestController should have rejected an unknown operation")
+  }
+
+  // requests carry the callback url if the url of the REST API is configured
+  if url := callbackURL("http://solar:80/", "demo"); url != "http://solar:80/operation/demo" {
+    t.Errorf("callbackURL should have appended the domain: %s", url)
+  }
+
+  if url := callbackURL("", "demo"); url != "" {
+    t.Errorf("callbackURL should not have provided a callback without url: %s", url)
+  }
+}
+
+//------------------------------------------------------------------------------
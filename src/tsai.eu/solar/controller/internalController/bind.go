@@ -0,0 +1,14 @@
+package internalController
+
+import (
+	"tsai.eu/solar/model"
+)
+
+//------------------------------------------------------------------------------
+
+// Bind binds an instance to the cluster referenced by a relationship
+func (c *Controller) Bind(targetState *model.TargetState) (currentState *model.CurrentState, err error) {
+	return c.Status(targetState)
+}
+
+//------------------------------------------------------------------------------
@@ -125,6 +125,16 @@ func TestController(t *testing.T) {
 			if err != nil {
 				t.Errorf("Unable to reset: %s\n%s", entry, err)
 			}
+		case "bind":
+			_, err = dc.Bind(state)
+			if err != nil {
+				t.Errorf("Unable to bind: %s\n%s", entry, err)
+			}
+		case "unbind":
+			_, err = dc.Unbind(state)
+			if err != nil {
+				t.Errorf("Unable to unbind: %s\n%s", entry, err)
+			}
 		default:
 			t.Errorf("Unknown action: %s", entry.Action)
 		}
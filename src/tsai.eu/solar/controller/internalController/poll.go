@@ -0,0 +1,15 @@
+package internalController
+
+import (
+	"tsai.eu/solar/model"
+)
+
+//------------------------------------------------------------------------------
+
+// Poll determines the result of an asynchronous operation. The internal
+// controller completes all operations synchronously.
+func (c *Controller) Poll(targetState *model.TargetState, operation string) (currentState *model.CurrentState, err error) {
+	return c.Status(targetState)
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,14 @@
+package internalController
+
+import (
+	"tsai.eu/solar/model"
+)
+
+//------------------------------------------------------------------------------
+
+// Unbind releases the binding of an instance to the cluster referenced by a relationship
+func (c *Controller) Unbind(targetState *model.TargetState) (currentState *model.CurrentState, err error) {
+	return c.Status(targetState)
+}
+
+//------------------------------------------------------------------------------
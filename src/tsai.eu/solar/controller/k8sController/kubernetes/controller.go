@@ -68,6 +68,10 @@ func Process(request *common.Request, response *common.Response) {
     status(request, response)
   case "reset":
     status(request, response)
+  case "bind":
+    status(request, response)
+  case "unbind":
+    status(request, response)
   case "status":
     status(request, response)
   }
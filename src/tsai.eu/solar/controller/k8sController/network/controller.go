@@ -138,6 +138,10 @@ func Process(request *common.Request, response *common.Response) {
     reconfigure(request, response)
   case "reset":
     destroy(request, response)
+  case "bind":
+    status(request, response)
+  case "unbind":
+    status(request, response)
   case "status":
     status(request, response)
   }
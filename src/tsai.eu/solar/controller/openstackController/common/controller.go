@@ -2,6 +2,11 @@ package common
 
 //------------------------------------------------------------------------------
 
+// ProtocolVersion is the version of the controller protocol spoken by the controller
+const ProtocolVersion int = 2
+
+//------------------------------------------------------------------------------
+
 // UndefinedState indicates a component state is undefined
 const UndefinedState string = "undefined"
 
@@ -61,12 +66,19 @@ const ResetAction string = "reset"
 // StatusAction requests the status of a resource
 const StatusAction string = "status"
 
+// BindAction requests the binding of a resource to a related resource
+const BindAction string = "bind"
+
+// UnbindAction requests the release of the binding of a resource to a related resource
+const UnbindAction string = "unbind"
+
 //------------------------------------------------------------------------------
 
 // RelationshipState describes the current state of a relationship
 type RelationshipState struct {
   Relationship  string  `yaml:"Relationship"`  // name of relationship
   Dependency    string  `yaml:"Dependency"`    // name of dependency
+  State         string  `yaml:"State"`         // state of relationship (active if bound)
   Configuration string  `yaml:"Configuration"` // configuration information
   Endpoint      string  `yaml:"Endpoint"`      // endpoint information in yaml format
 }
@@ -84,27 +96,33 @@ type InstanceState struct {
 
 // Request sent to controller.
 type Request struct {
-  Request       string              `yaml:"Request"`       // request ID
-  Domain        string              `yaml:"Domain"`        // name of the domain
-  Solution      string              `yaml:"Solution"`      // name of solution
-	Version       string              `yaml:"Version"`       // version of solution
-  Element       string              `yaml:"Element"`       // name of element
-  Cluster       string              `yaml:"Cluster"`       // name of cluster
-  Instance      string              `yaml:"Instance"`      // name of instance
-  Component     string              `yaml:"Component"`     // component type of instance
-  State         string              `yaml:"State"`         // state of instance
-  Min           int                 `yaml:"Min"`           // min. size of the solution element cluster
-	Max           int                 `yaml:"Max"`           // max. size of the solution element cluster
-	Size          int                 `yaml:"Size"`          // size of the solution element cluster
-	Configuration string              `yaml:"Configuration"` // configuration of instance
-  Relationships []RelationshipState `yaml:"Relationships"` // current state of all relationships
-  Instances     []InstanceState     `yaml:"Instances"`     // current state of all instances
+  Protocol              int                 `yaml:"Protocol"`              // version of the controller protocol (1 if undefined)
+  Request               string              `yaml:"Request"`               // request ID
+  Domain                string              `yaml:"Domain"`                // name of the domain
+  Solution              string              `yaml:"Solution"`              // name of solution
+	Version               string              `yaml:"Version"`               // version of solution
+  Element               string              `yaml:"Element"`               // name of element
+  Cluster               string              `yaml:"Cluster"`               // name of cluster
+  Instance              string              `yaml:"Instance"`              // name of instance
+  Component             string              `yaml:"Component"`             // component type of instance
+  State                 string              `yaml:"State"`                 // state of instance
+  Min                   int                 `yaml:"Min"`                   // min. size of the solution element cluster
+	Max                   int                 `yaml:"Max"`                   // max. size of the solution element cluster
+	Size                  int                 `yaml:"Size"`                  // size of the solution element cluster
+	Configuration         string              `yaml:"Configuration"`         // configuration of instance
+  ElementConfiguration  string              `yaml:"ElementConfiguration"`  // configuration of the solution element
+  SolutionConfiguration string              `yaml:"SolutionConfiguration"` // configuration of the solution
+  Relationships         []RelationshipState `yaml:"Relationships"`         // current state of all relationships
+  Instances             []InstanceState     `yaml:"Instances"`             // current state of all instances
+  Relationship          string              `yaml:"Relationship"`          // relationship to be bound or unbound (bind/unbind)
+  Endpoint              string              `yaml:"Endpoint"`              // endpoint of the resource referenced by the relationship (bind/unbind)
 }
 
 //------------------------------------------------------------------------------
 
 // Response received from controller.
 type Response struct {
+  Protocol      int    `yaml:"Protocol"`              // version of the controller protocol
   Request       string `yaml:"Request"`               // request ID
   Action        string `yaml:"Action"`                // requested action
   Code          int    `yaml:"Code"`                  // response code
@@ -90,6 +90,7 @@ func process(w http.ResponseWriter, r *http.Request) {
 
   // construct initial response from request
   response := &common.Response{
+    Protocol:       common.ProtocolVersion,
     Request:        request.Request,
     Action:         mux.Vars(r)["action"],
     Code:           http.StatusTeapot,
@@ -114,6 +115,8 @@ func process(w http.ResponseWriter, r *http.Request) {
      response.Action != common.ConfigureAction   &&
      response.Action != common.ReconfigureAction &&
      response.Action != common.ResetAction       &&
+     response.Action != common.BindAction        &&
+     response.Action != common.UnbindAction      &&
      response.Action != common.StatusAction {
 
     response.Code   = http.StatusBadRequest
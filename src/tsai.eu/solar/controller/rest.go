@@ -1,8 +1,10 @@
 package controller
 
 import (
+	"sync"
 	"errors"
 	"strings"
+	"net/url"
 	"net/http"
 	"io/ioutil"
 
@@ -14,9 +16,11 @@ import (
 
 // RestController is an gRPC based implementation of the Controller interface
 type RestController struct {
-	Type    string  // type of controller
-	Version string  // version of the controller
-	URL     string  // address to which the controller listens
+	Type      string        // type of controller
+	Version   string        // version of the controller
+	URL       string        // address to which the controller listens
+	Protocol  int           // protocol version spoken by the controller (0 if unknown)
+	ProtocolX sync.RWMutex  // mutex for the protocol version
 }
 
 //------------------------------------------------------------------------------
@@ -46,16 +50,46 @@ func newRestController(Type string, Version string, URL string) (*RestController
 func (c *RestController)process(action string, targetState *model.TargetState) (currentState *model.CurrentState, err error) {
 	// convert targetState into a request
 	request := Request{
-		Request:         util.UUID(),
-		Domain:          targetState.Domain,
-		Solution:        targetState.Solution,
-		Version:         targetState.Version,
-		Element:         targetState.Element,
-		Cluster:         targetState.Cluster,
-		Instance:        targetState.Instance,
-		Component:       targetState.Component,
-		State:           targetState.State,
-		Configuration:   targetState.Configuration,
+		Protocol:              ProtocolVersion,
+		Request:               util.UUID(),
+		Domain:                targetState.Domain,
+		Solution:              targetState.Solution,
+		Version:               targetState.Version,
+		Element:               targetState.Element,
+		Cluster:               targetState.Cluster,
+		Instance:              targetState.Instance,
+		Component:             targetState.Component,
+		State:                 targetState.State,
+		Configuration:         targetState.Configuration,
+		Min:                   targetState.Min,
+		Max:                   targetState.Max,
+		Size:                  targetState.Size,
+		ElementConfiguration:  targetState.ElementConfiguration,
+		SolutionConfiguration: targetState.SolutionConfiguration,
+		Relationships:         []RelationshipState{},
+		Instances:             []InstanceState{},
+		Relationship:          targetState.Relationship,
+	}
+
+	// add the current state of the relationships and the peer instances
+	for _, relationship := range targetState.Relationships {
+		request.Relationships = append(request.Relationships, RelationshipState(relationship))
+	}
+
+	for _, instance := range targetState.Instances {
+		request.Instances = append(request.Instances, InstanceState(instance))
+	}
+
+	// add the endpoint of the cluster related by the relationship to be bound
+	for _, relationship := range targetState.Relationships {
+		if relationship.Relationship == targetState.Relationship && targetState.Relationship != "" {
+			request.Endpoint = relationship.Endpoint
+		}
+	}
+
+	// asynchronous operations may be reported by a callback
+	if configuration, _ := util.GetConfiguration(); configuration != nil {
+		request.Callback = callbackURL(configuration.CORE.URL, targetState.Domain)
 	}
 
 	// trigger request
@@ -65,7 +99,17 @@ func (c *RestController)process(action string, targetState *model.TargetState) (
 	if err != nil {
 		return nil, err
 	}
+	defer rsp.Body.Close()
 
+	return c.evaluate(rsp)
+}
+
+//------------------------------------------------------------------------------
+
+// evaluate converts the response of the controller into a currentState. The
+// currentState of an operation which the controller has accepted to execute
+// asynchronously only carries the id of the operation.
+func (c *RestController)evaluate(rsp *http.Response) (*model.CurrentState, error) {
 	data, err := ioutil.ReadAll(rsp.Body)
 	if err != nil {
 		return nil, err
@@ -77,8 +121,27 @@ func (c *RestController)process(action string, targetState *model.TargetState) (
 		return nil, err
 	}
 
-	// convert response into currentState
-	currentState = &model.CurrentState{
+	// remember the protocol version spoken by the controller
+	c.setProtocol(response.Protocol)
+
+	// check if the controller has accepted the request
+	switch {
+	case rsp.StatusCode == http.StatusOK:
+	case rsp.StatusCode == http.StatusAccepted && response.Operation != "":
+		return &model.CurrentState{Operation: response.Operation, Timeout: response.Timeout}, nil
+	default:
+		return nil, errors.New("controller: " + c.Type + ":" + c.Version + " has rejected the request: " + response.Status)
+	}
+
+	// success
+	return NewCurrentState(response), nil
+}
+
+//------------------------------------------------------------------------------
+
+// NewCurrentState converts the final response of a controller into a currentState.
+func NewCurrentState(response *Response) *model.CurrentState {
+	return &model.CurrentState{
 		Domain         : response.Domain,
 		Solution       : response.Solution,
 		Version        : response.Version,
@@ -90,18 +153,45 @@ func (c *RestController)process(action string, targetState *model.TargetState) (
 		Configuration  : response.Configuration,
 		Endpoint       : response.Endpoint,
 	}
+}
+
+//------------------------------------------------------------------------------
+
+// getProtocol delivers the protocol version spoken by the controller (0 if unknown)
+func (c *RestController) getProtocol() int {
+	c.ProtocolX.RLock()
+	defer c.ProtocolX.RUnlock()
+
+	return c.Protocol
+}
+
+//------------------------------------------------------------------------------
+
+// setProtocol records the protocol version reported by the controller.
+// Controllers which do not report a version speak version 1.
+func (c *RestController) setProtocol(protocol int) {
+	if protocol <= 0 {
+		protocol = 1
+	}
 
-	return currentState, nil
+	c.ProtocolX.Lock()
+	c.Protocol = protocol
+	c.ProtocolX.Unlock()
 }
 
 //------------------------------------------------------------------------------
 
 // Check checks availability of controller
 func (c *RestController) Check() bool {
-	_, err := http.Get(c.URL + "/check")
+	rsp, err := http.Get(c.URL)
 	if err != nil {
 		return false
 	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return false
+	}
 
 	// success
 	return true
@@ -165,3 +255,65 @@ func (c *RestController)Reset(targetState *model.TargetState) (*model.CurrentSta
 }
 
 //------------------------------------------------------------------------------
+
+// Bind binds an instance to the cluster referenced by a relationship
+func (c *RestController)Bind(targetState *model.TargetState) (*model.CurrentState, error) {
+	if !c.supportsBinding(targetState) {
+		return c.process("status", targetState)
+	}
+
+	return c.process("bind", targetState)
+}
+
+//------------------------------------------------------------------------------
+
+// Unbind releases the binding of an instance to the cluster referenced by a relationship
+func (c *RestController)Unbind(targetState *model.TargetState) (*model.CurrentState, error) {
+	if !c.supportsBinding(targetState) {
+		return c.process("status", targetState)
+	}
+
+	return c.process("unbind", targetState)
+}
+
+//------------------------------------------------------------------------------
+
+// supportsBinding checks if the controller speaks a protocol version which
+// knows the bind and unbind actions. If the version is not known yet it is
+// determined by a status request. Bindings with controllers of older versions
+// are reduced to status requests.
+func (c *RestController)supportsBinding(targetState *model.TargetState) bool {
+	if c.getProtocol() == 0 {
+		c.process("status", targetState)
+	}
+
+	return c.getProtocol() >= 2
+}
+
+//------------------------------------------------------------------------------
+
+// Poll determines the result of an asynchronous operation. The controller
+// answers with 202 Accepted as long as the operation is still running.
+func (c *RestController)Poll(targetState *model.TargetState, operation string) (*model.CurrentState, error) {
+	rsp, err := http.Get(c.URL + "/operation/" + url.PathEscape(operation))
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	return c.evaluate(rsp)
+}
+
+//------------------------------------------------------------------------------
+
+// callbackURL determines the url to which controllers post the results of
+// asynchronous operations (empty if the url of the REST API is unknown).
+func callbackURL(base string, domainName string) string {
+	if base == "" {
+		return ""
+	}
+
+	return strings.TrimSuffix(base, "/") + "/operation/" + url.PathEscape(domainName)
+}
+
+//------------------------------------------------------------------------------
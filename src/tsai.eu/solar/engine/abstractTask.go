@@ -2,23 +2,24 @@ package engine
 
 import (
 	"tsai.eu/solar/model"
+	"tsai.eu/solar/util"
 )
 
 //------------------------------------------------------------------------------
 
 // TerminateTask handles the termination of the task
 func TerminateTask(task *model.Task) {
-	// get event channel
-	channel := GetEventChannel()
+	// get event queue
+	queue := GetEventQueue()
 
 	// check if task is regarded to be executing
-	if task.Status == model.TaskStatusInitial || task.Status == model.TaskStatusExecuting {
+	if task.GetStatus() == model.TaskStatusInitial || task.GetStatus() == model.TaskStatusExecuting {
 		// update status
-		task.Status = model.TaskStatusTerminated
+		task.SetStatus(model.TaskStatusTerminated)
 
 		// terminate all subtasks
 		for _, subtask := range task.Subtasks {
-			channel <- model.NewEvent(task.Domain, subtask, model.EventTypeTaskTermination, task.UUID, "")
+			queue.Push(model.NewEvent(task.Domain, subtask, model.EventTypeTaskTermination, task.UUID, ""))
 		}
 	}
 }
@@ -27,17 +28,17 @@ func TerminateTask(task *model.Task) {
 
 // FailedTask handles the failure of the task
 func FailedTask(task *model.Task) {
-	// get event channel
-	channel := GetEventChannel()
+	// get event queue
+	queue := GetEventQueue()
 
 	// check if task is regarded to be executing
-	if task.Status == model.TaskStatusInitial || task.Status == model.TaskStatusExecuting {
+	if task.GetStatus() == model.TaskStatusInitial || task.GetStatus() == model.TaskStatusExecuting {
 		// update status
-		task.Status = model.TaskStatusFailed
+		task.SetStatus(model.TaskStatusFailed)
 
 		// retrigger execution of parent
 		if task.Parent != "" && task.Parent != task.UUID {
-			channel <- model.NewEvent(task.Domain, task.Parent, model.EventTypeTaskFailure, task.UUID, "")
+			queue.Push(model.NewEvent(task.Domain, task.Parent, model.EventTypeTaskFailure, task.UUID, ""))
 		}
 	}
 }
@@ -52,17 +53,17 @@ func ExecuteTask(task *model.Task) {
 
 // TimeoutTask handles the timeout of the task
 func TimeoutTask(task *model.Task) {
-	// get event channel
-	channel := GetEventChannel()
+	// get event queue
+	queue := GetEventQueue()
 
 	// check if task is regarded to be executing
-	if task.Status == model.TaskStatusInitial || task.Status == model.TaskStatusExecuting {
+	if task.GetStatus() == model.TaskStatusInitial || task.GetStatus() == model.TaskStatusExecuting {
 		// update status
-		task.Status = model.TaskStatusTimeout
+		task.SetStatus(model.TaskStatusTimeout)
 
 		// signal timeout to parent
 		if task.Parent != "" && task.Parent != task.UUID {
-			channel <- model.NewEvent(task.Domain, task.Parent, model.EventTypeTaskTimeout, task.UUID, "")
+			queue.Push(model.NewEvent(task.Domain, task.Parent, model.EventTypeTaskTimeout, task.UUID, ""))
 		}
 	}
 }
@@ -71,19 +72,34 @@ func TimeoutTask(task *model.Task) {
 
 // CompletedTask handles the completion of the task
 func CompletedTask(task *model.Task) {
-	// get event channel
-	channel := GetEventChannel()
+	// get event queue
+	queue := GetEventQueue()
 
 	// check if task is regarded to be executing
-	if task.Status == model.TaskStatusInitial || task.Status == model.TaskStatusExecuting {
+	if task.GetStatus() == model.TaskStatusInitial || task.GetStatus() == model.TaskStatusExecuting {
 			// update status
-		task.Status = model.TaskStatusCompleted
+		task.SetStatus(model.TaskStatusCompleted)
 
 		// retrigger execution of parent
 		if task.Parent != "" && task.Parent != task.UUID {
-			channel <- model.NewEvent(task.Domain, task.Parent, model.EventTypeTaskExecution, task.UUID, "")
+			queue.Push(model.NewEvent(task.Domain, task.Parent, model.EventTypeTaskExecution, task.UUID, ""))
 		}
 	}
 }
 
 //------------------------------------------------------------------------------
+
+// ReportProgress records the progress of a task as an event of the task.
+func ReportProgress(task *model.Task, comment string) {
+	util.LogInfo(task.UUID, "ENG", comment)
+
+	domain, err := model.GetDomain(task.Domain)
+	if err != nil {
+		return
+	}
+
+	event := model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskProgress, "", comment)
+	domain.AddEvent(&event)
+}
+
+//------------------------------------------------------------------------------
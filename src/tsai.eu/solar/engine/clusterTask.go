@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"sort"
 	"errors"
 
 	"tsai.eu/solar/util"
@@ -25,7 +26,7 @@ func NewClusterTask(domain string, parent string, solution string, version strin
 	task.Action       = ""
 	task.UUID         = util.UUID()
 	task.Parent       = parent
-	task.Status       = model.TaskStatusInitial
+	task.SetStatus(model.TaskStatusInitial)
 	task.Phase        = 0
 	task.Subtasks     = []string{}
 
@@ -56,8 +57,8 @@ func NewClusterTask(domain string, parent string, solution string, version strin
 
 // ExecuteClusterTask is the main task execution routine.
 func ExecuteClusterTask(task *model.Task) {
-	// get event channel
-	channel := GetEventChannel()
+	// get event queue
+	queue := GetEventQueue()
 
 	// check and update status
 	status := task.GetStatus()
@@ -67,18 +68,35 @@ func ExecuteClusterTask(task *model.Task) {
 	}
 
 	if status == model.TaskStatusInitial {
-		task.Status = model.TaskStatusExecuting
+		task.SetStatus(model.TaskStatusExecuting)
 	}
 
 	// determine context
-	cluster, _  := model.GetCluster(task.Domain, task.Solution, task.Element, task.Cluster)
+	cluster, err := model.GetCluster(task.Domain, task.Solution, task.Element, task.Cluster)
+	if err != nil {
+		util.LogError(task.UUID, "ENG", "unknown cluster: " + task.Element + " - " + task.Cluster)
+		queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "unknown cluster: " + task.Element + " - " + task.Cluster))
+		return
+	}
 
 	// validate sizing
 	if cluster.Min > cluster.Max || cluster.Size < cluster.Min || cluster.Max < cluster.Size {
-		channel <- model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "inconsistent sizing of cluster: '" + task.Element + " - " + task.Cluster + " of solution: '" + task.Solution + "' within domain:'" + task.Domain)
+		queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "inconsistent sizing of cluster: '" + task.Element + " - " + task.Cluster + " of solution: '" + task.Solution + "' within domain:'" + task.Domain))
 		return
 	}
 
+	// release the bindings before the cluster is deactivated
+	if cluster.Target != model.ActiveState {
+		// bindings of the cluster to other clusters
+		if unbindRelationships(task, cluster) {
+			return
+		}
+
+		// bindings of other clusters to the cluster
+		if unbindConsumers(task) {
+			return
+		}
+	}
 
 	// evaluate relationships
 	switch cluster.Target {
@@ -93,11 +111,15 @@ func ExecuteClusterTask(task *model.Task) {
 			}
 
 			// check if the related cluster is in the desired state
-			refCluster, _ := model.GetCluster(relationship.Domain, relationship.Solution, relationship.Element, relationship.Version)
+			refCluster, err := model.GetCluster(relationship.Domain, relationship.Solution, relationship.Element, relationship.Version)
+			if err != nil {
+				queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "unknown context dependency: " + relationship.Element + " - " + relationship.Version))
+				return
+			}
 			if refCluster.State != model.ActiveState {
 				// check if the desired target state is active (otherwise we have a configuration mismatch)
 				if refCluster.Target != model.ActiveState {
-					channel <- model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "unable to establish context dependency: " + relationship.Element + " - " + relationship.Version)
+					queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "unable to establish context dependency: " + relationship.Element + " - " + relationship.Version))
 					return
 				}
 
@@ -118,11 +140,15 @@ func ExecuteClusterTask(task *model.Task) {
 				continue
 			}
 
-			refCluster, _ := model.GetCluster(relationship.Domain, relationship.Solution, relationship.Element, relationship.Version)
+			refCluster, err := model.GetCluster(relationship.Domain, relationship.Solution, relationship.Element, relationship.Version)
+			if err != nil {
+				queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "unknown service dependency: " + relationship.Element + " - " + relationship.Version))
+				return
+			}
 			if refCluster.State != model.ActiveState	{
 				// check if the desired target state is active (otherwise we have a configuration mismatch)
 				if refCluster.Target != model.ActiveState {
-					channel <- model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "unable to establish service dependency: " + relationship.Element + " - " + relationship.Version)
+					queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "unable to establish service dependency: " + relationship.Element + " - " + relationship.Version))
 					return
 				}
 
@@ -135,140 +161,266 @@ func ExecuteClusterTask(task *model.Task) {
 		}
 	}
 
-	// adjust instances
-	switch cluster.Target {
-	case model.InitialState:
-		// one by one identify the instances which need to be reset
-		instanceNames, _ := cluster.ListInstances()
-		for _, instanceName := range instanceNames {
-			instance, _ := cluster.GetInstance(instanceName)
-			if instance.State != cluster.Target {
-				// update the related instance
-				triggerInstanceTask(task, instanceName, cluster.Target)
+	// one by one identify the instances which need to be adjusted
+	instanceName, state, found := cluster.Step()
+	if found {
+		// update the related instance
+		triggerInstanceTask(task, instanceName, state)
 
-				// return and wait for next event
-				return
-			}
-		}
+		// return and wait for next event
+		return
+	}
 
-		// cluster has reached the desired state
-		cluster.State = model.InitialState
-	case model.InactiveState:
-		_, inactive, active, _, _ := cluster.Pools()
+	// apply changed configurations to the instances one by one
+	if reconfigureInstances(task, cluster) {
+		// return and wait for next event
+		return
+	}
 
-		// one by one identify the instances which need to be reset
-		instanceNames, _ := cluster.ListInstances()
-		for _, instanceName := range instanceNames {
-			instance, _ := cluster.GetInstance(instanceName)
+	// bind the relationships once the cluster and the related clusters are active
+	if cluster.Target == model.ActiveState && bindRelationships(task, cluster) {
+		// return and wait for next event
+		return
+	}
 
-			// cleanup failed instances
-			if instance.State == model.FailureState {
-				triggerInstanceTask(task, instanceName, model.InitialState)
+	// cluster has reached the desired state
+	if cluster.State != cluster.Target {
+		cluster.State = cluster.Target
+		model.PublishState(model.NotificationCluster, task.Domain + "/" + task.Solution + "/" + task.Element + "/" + task.Cluster, cluster.State)
+	}
 
-				return
-			}
+	// execution has completed
+	queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskCompletion, task.UUID, ""))
+}
 
-			// deactivate active instances
-			if instance.State == model.ActiveState {
-				triggerInstanceTask(task, instanceName, model.InactiveState)
+//------------------------------------------------------------------------------
 
-				return
-			}
+// countInstances counts how many instances of a cluster are in a specific state.
+func countInstances(cluster *model.Cluster, state string) int {
+	count := 0
 
-			// ensure that the number of inactive nodes matches the cluster size
-			if inactive < cluster.Size {
-				// activate the amount of required instances
-			  if instance.State != model.InactiveState {
-					triggerInstanceTask(task, instanceName, model.InactiveState)
+	// loop over all instances
+	instanceNames, _ := cluster.ListInstances()
+	for _, instanceName := range instanceNames {
+		instance, _ := cluster.GetInstance(instanceName)
+		if instance.State == state {
+			count++
+		}
+	}
 
-					return
-				}
-			} else if active > cluster.Size {
-				// activate the amount of required instances
-			  if instance.State == model.InactiveState {
-					triggerInstanceTask(task, instanceName, model.InitialState)
+	return count
+}
 
-					return
-				}
-			}
+//------------------------------------------------------------------------------
 
-			// cluster has reached the desired state
-			cluster.State = model.InactiveState
+// reconfigureInstances triggers a task to apply the changed configuration to
+// the next instance whose configuration differs from the rendered
+// configuration of the cluster. Each instance is reconfigured at most once by
+// a cluster task. It reports if a task has been triggered.
+func reconfigureInstances(task *model.Task, cluster *model.Cluster) bool {
+	component, err := model.GetComponent2(task.Domain, task.Solution, task.Element, task.Cluster)
+	if err != nil {
+		return false
+	}
+
+	for _, instanceName := range cluster.Outdated() {
+		if reconfiguredInstance(task, instanceName) {
+			continue
 		}
-	case model.ActiveState:
-		_, inactive, active, _, _ := cluster.Pools()
 
-		// one by one identify the instances which need to be reset
-		instanceNames, _ := cluster.ListInstances()
-		for _, instanceName := range instanceNames {
-			instance, _ := cluster.GetInstance(instanceName)
+		instance, _ := cluster.GetInstance(instanceName)
+
+		transition, err := model.GetReconfiguration(instance.State, component.Replace)
+		if err != nil {
+			continue
+		}
 
-			// cleanup failed instances
-			if instance.State == model.FailureState {
-				triggerInstanceTask(task, instanceName, model.InitialState)
+		// replaced instances are reset and recreated by the next steps
+		state := instance.State
+		if transition == "replace" {
+			state = model.InitialState
+		}
 
-				return
-			}
+		util.LogInfo(task.UUID, "ENG", "Instance: " + task.Domain + "/" + task.Element + "/" + task.Cluster + "/" + instanceName + " has a changed configuration (" + transition + ")")
 
-			// ensure that the number of active nodes matches the cluster size
-			if active < cluster.Size {
-				// activate the amount of required instances
-			  if instance.State != cluster.Target {
-					triggerInstanceTask(task, instanceName, model.ActiveState)
+		triggerReconfigurationTask(task, instanceName, state, transition)
+		return true
+	}
 
-					return
-				}
-			} else if active == cluster.Size {
-				// remove excess inactive instances
-				if inactive > (cluster.Max - cluster.Size) {
-					if instance.State == model.InactiveState {
-						triggerInstanceTask(task, instanceName, model.InitialState)
+	return false
+}
 
-						return
-					}
-				}
-			} else if active > cluster.Size {
-				// deactivate excess active instances
-				if instance.State == model.ActiveState {
-					triggerInstanceTask(task, instanceName, model.InactiveState)
+//------------------------------------------------------------------------------
 
-					return
-				}
-			}
+// reconfiguredInstance checks if a reconfiguration of an instance has already
+// been triggered by the task.
+func reconfiguredInstance(task *model.Task, instanceName string) bool {
+	for _, subtaskUUID := range task.Subtasks {
+		subtask, err := task.GetSubtask(subtaskUUID)
+		if err == nil && subtask.Instance == instanceName && subtask.Action != "" {
+			return true
 		}
-
-		// cluster has reached the desired state
-		cluster.State = model.ActiveState
 	}
 
-	// execution has completed
-	channel <- model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskCompletion, task.UUID, "")
+	return false
 }
 
 //------------------------------------------------------------------------------
 
-// countInstances counts how many instances of a cluster are in a specific state.
-func countInstances(cluster *model.Cluster, state string) int {
-	count := 0
+// bindRelationships triggers a task to bind the next unbound context or service
+// relationship of a cluster or a relationship to which not all active instances
+// have been bound yet, e.g. after the cluster has been scaled out or instances
+// have been replaced. It reports if a task has been triggered.
+func bindRelationships(task *model.Task, cluster *model.Cluster) bool {
+	relationshipNames, _ := cluster.ListRelationships()
+	sort.Strings(relationshipNames)
 
-	// loop over all instances
+	for _, relationshipName := range relationshipNames {
+		relationship, _ := cluster.GetRelationship(relationshipName)
+
+		if relationship.Type != model.ContextRelationship && relationship.Type != model.ServiceRelationship {
+			continue
+		}
+
+		if relationship.State != model.ActiveState || !boundInstances(task, cluster, relationshipName) {
+			triggerRelationshipTask(task, task.Element, task.Cluster, relationshipName, model.ActiveState)
+			return true
+		}
+	}
+
+	return false
+}
+
+//------------------------------------------------------------------------------
+
+// boundInstances checks if all active instances of a cluster have been bound
+// to the cluster referenced by a relationship. Instances whose binding has
+// already been attempted by a relationship task of the task are regarded to
+// be bound to avoid endless retries.
+func boundInstances(task *model.Task, cluster *model.Cluster, relationshipName string) bool {
 	instanceNames, _ := cluster.ListInstances()
 	for _, instanceName := range instanceNames {
 		instance, _ := cluster.GetInstance(instanceName)
-		if instance.State == state {
-			count++
+
+		if instance.State == model.ActiveState && !instance.IsBound(relationshipName) && !boundByTask(task, relationshipName, instanceName) {
+			return false
 		}
 	}
 
-	return count
+	return true
+}
+
+//------------------------------------------------------------------------------
+
+// boundByTask checks if a relationship task of the task has already attempted
+// to bind an instance.
+func boundByTask(task *model.Task, relationshipName string, instanceName string) bool {
+	for _, subtaskUUID := range task.Subtasks {
+		subtask, err := task.GetSubtask(subtaskUUID)
+		if err != nil || subtask.Type != "Relationship" || subtask.Relationship != relationshipName || subtask.State != model.ActiveState {
+			continue
+		}
+
+		if handledInstance(subtask, instanceName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//------------------------------------------------------------------------------
+
+// unbindRelationships triggers a task to release the next binding of a cluster
+// to another cluster. It reports if a task has been triggered.
+func unbindRelationships(task *model.Task, cluster *model.Cluster) bool {
+	relationshipNames, _ := cluster.ListRelationships()
+	sort.Strings(relationshipNames)
+
+	for _, relationshipName := range relationshipNames {
+		relationship, _ := cluster.GetRelationship(relationshipName)
+
+		if relationship.State == model.ActiveState {
+			triggerRelationshipTask(task, task.Element, task.Cluster, relationshipName, model.InitialState)
+			return true
+		}
+	}
+
+	return false
+}
+
+//------------------------------------------------------------------------------
+
+// unbindConsumers triggers a task to release the next binding of another
+// cluster to the cluster of the task. The consumers are resolved by the domain
+// and solution referenced by their relationships, they may belong to any
+// solution of the domain. It reports if a task has been triggered.
+func unbindConsumers(task *model.Task) bool {
+	domain, err := model.GetDomain(task.Domain)
+	if err != nil {
+		return false
+	}
+
+	solutionNames, _ := domain.ListSolutions()
+	sort.Strings(solutionNames)
+
+	for _, solutionName := range solutionNames {
+		solution, _ := domain.GetSolution(solutionName)
+
+		elementNames, _ := solution.ListElements()
+		sort.Strings(elementNames)
+
+		for _, elementName := range elementNames {
+			element, _ := solution.GetElement(elementName)
+
+			clusterNames, _ := element.ListClusters()
+			sort.Strings(clusterNames)
+
+			for _, clusterName := range clusterNames {
+				cluster, _ := element.GetCluster(clusterName)
+
+				relationshipNames, _ := cluster.ListRelationships()
+				sort.Strings(relationshipNames)
+
+				for _, relationshipName := range relationshipNames {
+					relationship, _ := cluster.GetRelationship(relationshipName)
+
+					if relationship.Domain == task.Domain && relationship.Solution == task.Solution &&
+					   relationship.Element == task.Element && relationship.Version == task.Cluster &&
+					   relationship.State == model.ActiveState {
+						triggerConsumerTask(task, solution, elementName, clusterName, relationshipName)
+						return true
+					}
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+//------------------------------------------------------------------------------
+
+// triggerConsumerTask triggers a task to release the binding of a cluster of
+// a possibly different solution to the cluster of the task.
+func triggerConsumerTask(task *model.Task, solution *model.Solution, element string, cluster string, relationship string) {
+	// get event queue
+	queue := GetEventQueue()
+
+	// create task to release the binding
+	subtask, _ := NewRelationshipTask(task.Domain, task.UUID, solution.Solution, solution.Version, element, cluster, relationship, model.InitialState)
+	task.AddSubtask(&subtask)
+
+	// trigger the task
+	queue.Push(model.NewEvent(task.Domain, subtask.UUID, model.EventTypeTaskExecution, task.UUID, ""))
 }
 
 //------------------------------------------------------------------------------
 
 // triggerClusterTask triggers a task to update a cluster.
 func triggerClusterTask(task *model.Task, relationship *model.Relationship)  {
-	// get event channel
-	channel := GetEventChannel()
+	// get event queue
+	queue := GetEventQueue()
 
 	// create task to update the cluster
 	subtask, _ := NewClusterTask(relationship.Domain, task.UUID, task.Solution, task.Version, relationship.Element, relationship.Version)
@@ -276,22 +428,37 @@ func triggerClusterTask(task *model.Task, relationship *model.Relationship)  {
 	task.AddSubtask(&subtask)
 
 	// trigger the task
-	channel <- model.NewEvent(task.Domain, subtask.UUID, model.EventTypeTaskExecution, task.UUID, "")
+	queue.Push(model.NewEvent(task.Domain, subtask.UUID, model.EventTypeTaskExecution, task.UUID, ""))
 }
 
 //------------------------------------------------------------------------------
 
 // triggerInstanceTask triggers a task to update an instance to a specific state.
 func triggerInstanceTask(task *model.Task, instance string, state string)  {
-	// get event channel
-	channel := GetEventChannel()
+	// get event queue
+	queue := GetEventQueue()
 
 	// create task to update the instance
 	subtask, _ := NewInstanceTask(task.Domain, task.UUID, task.Solution, task.Version, task.Element, task.Cluster, instance, state )
 	task.AddSubtask(&subtask)
 
 	// trigger the task
-	channel <- model.NewEvent(task.Domain, subtask.UUID, model.EventTypeTaskExecution, task.UUID, "")
+	queue.Push(model.NewEvent(task.Domain, subtask.UUID, model.EventTypeTaskExecution, task.UUID, ""))
+}
+
+//------------------------------------------------------------------------------
+
+// triggerReconfigurationTask triggers a task to apply a changed configuration to an instance.
+func triggerReconfigurationTask(task *model.Task, instance string, state string, action string)  {
+	// get event queue
+	queue := GetEventQueue()
+
+	// create task to reconfigure or replace the instance
+	subtask, _ := NewReconfigurationTask(task.Domain, task.UUID, task.Solution, task.Version, task.Element, task.Cluster, instance, state, action)
+	task.AddSubtask(&subtask)
+
+	// trigger the task
+	queue.Push(model.NewEvent(task.Domain, subtask.UUID, model.EventTypeTaskExecution, task.UUID, ""))
 }
 
 //------------------------------------------------------------------------------
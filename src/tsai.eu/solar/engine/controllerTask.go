@@ -1,7 +1,9 @@
 package engine
 
 import (
+	"time"
 	"errors"
+	"strconv"
 
 	ctrl "tsai.eu/solar/controller"
 	"tsai.eu/solar/model"
@@ -13,23 +15,39 @@ import (
 
 // NewControllerTask creates a new controller task
 func NewControllerTask(domain string, parent string, solution string, version string, element string, cluster string, instance string, state string, action string) (model.Task, error) {
+	return newControllerTask(domain, parent, solution, version, element, cluster, instance, "", state, action)
+}
+
+//------------------------------------------------------------------------------
+
+// NewBindingTask creates a new controller task binding an instance to the
+// cluster referenced by a relationship or releasing the binding.
+func NewBindingTask(domain string, parent string, solution string, version string, element string, cluster string, instance string, relationship string, action string) (model.Task, error) {
+	return newControllerTask(domain, parent, solution, version, element, cluster, instance, relationship, model.ActiveState, action)
+}
+
+//------------------------------------------------------------------------------
+
+// newControllerTask creates a new controller task
+func newControllerTask(domain string, parent string, solution string, version string, element string, cluster string, instance string, relationship string, state string, action string) (model.Task, error) {
 	var task model.Task
 
 	// TODO: check parameters if context exists
-	task.Type     = "Controller"
-	task.Domain   = domain
-	task.Solution = solution
-	task.Version  = version
-	task.Element  = element
-	task.Cluster  = cluster
-	task.Instance = instance
-	task.State    = state
-	task.Action   = action
-	task.UUID     = util.UUID()
-	task.Parent   = parent
-	task.Status   = model.TaskStatusInitial
-	task.Phase    = 0
-	task.Subtasks = []string{}
+	task.Type         = "Controller"
+	task.Domain       = domain
+	task.Solution     = solution
+	task.Version      = version
+	task.Element      = element
+	task.Cluster      = cluster
+	task.Instance     = instance
+	task.Relationship = relationship
+	task.State        = state
+	task.Action       = action
+	task.UUID         = util.UUID()
+	task.Parent       = parent
+	task.SetStatus(model.TaskStatusInitial)
+	task.Phase        = 0
+	task.Subtasks     = []string{}
 
 	// add handlers
 	task.SetExecute(ExecuteControllerTask)
@@ -60,24 +78,28 @@ func NewControllerTask(domain string, parent string, solution string, version st
 
 // ExecuteControllerTask is the main task execution routine.
 func ExecuteControllerTask(task *model.Task) {
-	// get event channel
-	channel := GetEventChannel()
+	// get event queue
+	queue := GetEventQueue()
 
-	// check status
+	// check status (terminated or timed out tasks are not retried)
 	taskStatus := task.GetStatus()
 
 	if taskStatus != model.TaskStatusInitial && taskStatus != model.TaskStatusExecuting {
 		return
 	}
 
+	// determine timeout and retries
+	policy, _ := model.GetPolicy(task.Domain, task.Solution, task.Element, task.Cluster)
+
 	// initialize if needed
 	if taskStatus == model.TaskStatusInitial {
 		// update status
-		task.Status = model.TaskStatusExecuting
+		task.SetStatus(model.TaskStatusExecuting)
+		task.SetOperation("", time.Now().Add(policy.GetTimeout()).UnixNano())
 	}
 
 	// determine desired target state
-	targetState, _ := model.GetTargetState(
+	targetState, err := model.GetTargetState(
 											task.GetDomain(),
 	                    task.GetSolution(),
 										  task.GetVersion(),
@@ -85,35 +107,67 @@ func ExecuteControllerTask(task *model.Task) {
 										  task.GetCluster(),
 										  task.GetInstance() )
 
+	// determine the relationship to be bound or unbound
+	targetState.Relationship = task.Relationship
+
+	if err == model.ErrSecret {
+		util.LogError(task.UUID, "ENG", err.Error())
+		queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, err.Error()))
+		return
+	}
+
 	// determine the required controller for the instance
-	instance, _     := model.GetInstance(task.Domain, task.Solution, task.Element, task.Cluster, task.Instance)
-	component, _    := model.GetComponent2(task.Domain, task.Solution, task.Element, task.Cluster)
-	controller, err := ctrl.GetController(component.Controller)
+	instance, err := model.GetInstance(task.Domain, task.Solution, task.Element, task.Cluster, task.Instance)
 	if err != nil {
-		util.LogError(task.UUID, "ENG", "unknown controller: " + component.Component + ":" + task.GetCluster())
-		channel <- model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "unknown controller: " + component.Component)
+		util.LogError(task.UUID, "ENG", "unknown instance: " + task.Element + " - " + task.Cluster + " - " + task.Instance)
+		queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "unknown instance: " + task.Element + " - " + task.Cluster + " - " + task.Instance))
 		return
 	}
 
-	// execute the required transition
-	var currentState *model.CurrentState
+	component, err := model.GetComponent2(task.Domain, task.Solution, task.Element, task.Cluster)
+	if err != nil {
+		util.LogError(task.UUID, "ENG", "unknown component of cluster: " + task.GetElement() + " - " + task.GetCluster())
+		queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "unknown component of cluster: " + task.GetElement() + " - " + task.GetCluster()))
+		return
+	}
+
+	controller, err := ctrl.GetController(task.Domain, component.Controller)
+	if err != nil {
+		util.LogError(task.UUID, "ENG", "no active controller for component: " + component.Component + ":" + component.Version + "\n" + err.Error())
+		queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "no active controller for component: " + component.Component + ":" + component.Version + " (" + err.Error() + ")"))
+		return
+	}
 
+	// check the required transition
 	switch task.Action {
-	case "create":
-		currentState, err = controller.Create(targetState)
-	case "start":
-		currentState, err = controller.Start(targetState)
-	case "stop":
-		currentState, err = controller.Stop(targetState)
-	case "destroy":
-		currentState, err = controller.Destroy(targetState)
-	case "reset":
-		currentState, err = controller.Reset(targetState)
-	case "configure":
-		currentState, err = controller.Configure(targetState)
+	case "create", "start", "stop", "destroy", "reset", "configure", "reconfigure", "bind", "unbind":
 	default:
 		util.LogError(task.UUID, "ENG", "invalid transition")
-		channel <- model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "invalid transition")
+		queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "invalid transition"))
+		return
+	}
+
+	// execute the required transition or determine the result of a pending
+	// asynchronous operation
+	var currentState *model.CurrentState
+
+	if task.GetOperation() == "" {
+		currentState, err = executeTransition(controller, task.Action, targetState)
+	} else {
+		currentState, err = resumeOperation(task, controller, targetState)
+	}
+
+	// the task remains executing while the operation is pending
+	if err == nil && currentState != nil && currentState.Operation != "" {
+		awaitOperation(task, currentState, policy)
+		return
+	}
+
+	// the operation has finished
+	task.SetOperation("", task.GetDeadline())
+
+	// retry the transition after a delay
+	if err != nil && retryControllerTask(task, policy, err) {
 		return
 	}
 
@@ -129,18 +183,84 @@ func ExecuteControllerTask(task *model.Task) {
 		if instance.State != instanceState {
 			util.LogInfo(task.UUID, "ENG", "Instance: " + currentState.Domain + "/" + currentState.Element + "/" + currentState.Cluster + "/" + currentState.Instance + " has new state:" + instance.State)
 			msg.Notify( "Instance", currentState.Domain + "/" + currentState.Element + "/" + currentState.Cluster + "/" + currentState.Instance + "/" + instance.State)
+			model.PublishState(model.NotificationInstance, currentState.Domain + "/" + currentState.Solution + "/" + currentState.Element + "/" + currentState.Cluster + "/" + currentState.Instance, instance.State)
 		}
 	}
 
 	// check for errors and reexecute the task until the desired state has been reached
 	if err != nil {
 		util.LogError(task.UUID, "ENG", "controller has reported an error:\n" + err.Error())
-		channel <- model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, err.Error())
+		queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, err.Error()))
 		return
 	}
 
+	// record the binding of the instance
+	if instance != nil && (task.Action == "bind" || task.Action == "unbind") {
+		instance.SetBinding(task.Relationship, task.Action == "bind")
+	}
+
 	// success
-	channel <- model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskCompletion, task.UUID, "")
+	queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskCompletion, task.UUID, ""))
+}
+
+//------------------------------------------------------------------------------
+
+// retryControllerTask schedules the next execution of a controller task after
+// a failed transition. The worker of the task is not blocked while waiting, the
+// retry is skipped if the task has been terminated or has timed out meanwhile.
+// No retry is scheduled if the retries are exhausted or the retry would exceed
+// the deadline of the task.
+func retryControllerTask(task *model.Task, policy model.Policy, err error) bool {
+	if task.Retries >= policy.GetRetries() {
+		return false
+	}
+
+	// give up if the retry would exceed the timeout
+	delay := policy.GetDelay(task.Retries)
+	if time.Now().Add(delay).UnixNano() > task.GetDeadline() {
+		return false
+	}
+
+	task.Retries++
+
+	util.LogWarn(task.UUID, "ENG", "controller has reported an error - retry " + strconv.Itoa(task.Retries) + " of " + strconv.Itoa(policy.GetRetries()) + " in " + delay.String() + ":\n" + err.Error())
+
+	domain := task.Domain
+	uuid   := task.UUID
+
+	time.AfterFunc(delay, func() {
+		GetEventQueue().Push(model.NewEvent(domain, uuid, model.EventTypeTaskExecution, uuid, "retry"))
+	})
+
+	return true
+}
+
+//------------------------------------------------------------------------------
+
+// executeTransition triggers the controller to perform a transition.
+func executeTransition(controller ctrl.Controller, action string, targetState *model.TargetState) (*model.CurrentState, error) {
+	switch action {
+	case "create":
+		return controller.Create(targetState)
+	case "start":
+		return controller.Start(targetState)
+	case "stop":
+		return controller.Stop(targetState)
+	case "destroy":
+		return controller.Destroy(targetState)
+	case "reset":
+		return controller.Reset(targetState)
+	case "configure":
+		return controller.Configure(targetState)
+	case "reconfigure":
+		return controller.Reconfigure(targetState)
+	case "bind":
+		return controller.Bind(targetState)
+	case "unbind":
+		return controller.Unbind(targetState)
+	}
+
+	return nil, errors.New("invalid transition")
 }
 
 //------------------------------------------------------------------------------
@@ -0,0 +1,172 @@
+package engine
+
+import (
+	"errors"
+	"time"
+
+	"tsai.eu/solar/model"
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// rollbackInterval is the time between two checks whether the tasks of a failed
+// deployment have stopped and the solution can be rolled back.
+var rollbackInterval = 500 * time.Millisecond
+
+//------------------------------------------------------------------------------
+
+// Deploy updates the solution of an architecture and starts a solution task
+// which converges the solution to the new target state.
+func Deploy(domainName string, architecture *model.Architecture) (string, error) {
+	return deploy(domainName, architecture, false)
+}
+
+//------------------------------------------------------------------------------
+
+// Rollback re-applies the last successful deployment of a solution.
+func Rollback(domainName string, solutionName string) (string, error) {
+	solution, err := model.GetSolution(domainName, solutionName)
+	if err != nil {
+		return "", err
+	}
+
+	deployment, err := solution.GetRollback()
+	if err != nil {
+		return "", err
+	}
+
+	architecture, err := model.GetArchitecture(domainName, deployment.Architecture, deployment.Version)
+	if err != nil {
+		return "", err
+	}
+
+	util.LogInfo(solutionName, "ENG", "rolling back to version: " + deployment.Version)
+
+	return deploy(domainName, architecture, true)
+}
+
+//------------------------------------------------------------------------------
+
+// deploy updates a solution and records the deployment in its history.
+func deploy(domainName string, architecture *model.Architecture, rollback bool) (string, error) {
+	// determine domain
+	domain, err := model.GetDomain(domainName)
+	if err != nil {
+		return "", errors.New("domain can not be identified")
+	}
+
+	// determine solution (create new solution if not found)
+	solution, err := domain.GetSolution(architecture.Architecture)
+	if err != nil {
+		solution, _ = model.NewSolution(architecture.Architecture, architecture.Version, "")
+
+		domain.AddSolution(solution)
+	}
+
+	// update the target state of the solution
+	if err = solution.Update(domain.Name, architecture); err != nil {
+		return "", errors.New("unable to create or update the solution:\n" + err.Error())
+	}
+
+	// create task and start it by signalling an event
+	task, err := NewSolutionTask(domain.Name, "", solution)
+	if err != nil {
+		return "", errors.New("task can not be created:\n" + err.Error())
+	}
+
+	// record the deployment
+	solution.AddDeployment(architecture.Architecture, architecture.Version, task.UUID, rollback)
+
+	// get event queue
+	queue := GetEventQueue()
+
+	// create event
+	queue.Push(model.NewEvent(domain.Name, task.UUID, model.EventTypeTaskExecution, "", "initial"))
+
+	// success
+	return task.UUID, nil
+}
+
+//------------------------------------------------------------------------------
+
+// recordDeployment updates the outcome of the deployment executed by a solution
+// task and rolls back a failed deployment if requested by the architecture.
+func recordDeployment(task *model.Task) {
+	solution, err := model.GetSolution(task.Domain, task.Solution)
+	if err != nil {
+		return
+	}
+
+	if solution.SetOutcome(task.UUID, task.GetStatus()) != nil {
+		return
+	}
+
+	// check if the deployment has failed
+	if task.GetStatus() != model.TaskStatusFailed && task.GetStatus() != model.TaskStatusTimeout {
+		return
+	}
+
+	deployment, _ := solution.GetDeployment(task.UUID)
+	if deployment.Rollback {
+		return
+	}
+
+	architecture, err := model.GetArchitecture(task.Domain, deployment.Architecture, deployment.Version)
+	if err != nil || !architecture.AutoRollback {
+		return
+	}
+
+	// stop the remaining subtasks of the failed deployment
+	queue := GetEventQueue()
+	for _, subtask := range task.Subtasks {
+		queue.Push(model.NewEvent(task.Domain, subtask, model.EventTypeTaskTermination, task.UUID, ""))
+	}
+
+	// roll back to the previous version once the subtasks have stopped
+	rollbackDeployment(task)
+}
+
+//------------------------------------------------------------------------------
+
+// rollbackDeployment rolls back the solution of a failed deployment as soon as
+// no other task of the solution is being executed anymore.
+func rollbackDeployment(task *model.Task) {
+	domain, err := model.GetDomain(task.Domain)
+	if err != nil {
+		return
+	}
+
+	// wait for the remaining tasks of the solution to stop
+	if runningTasks(domain, task.Solution) {
+		time.AfterFunc(rollbackInterval, func() {
+			rollbackDeployment(task)
+		})
+		return
+	}
+
+	if _, err = Rollback(task.Domain, task.Solution); err != nil {
+		util.LogError(task.UUID, "ENG", "unable to roll back the solution: " + task.Solution + "\n" + err.Error())
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// runningTasks checks if any task of a solution is currently being executed.
+func runningTasks(domain *model.Domain, solutionName string) bool {
+	taskNames, _ := domain.ListTasks()
+	for _, taskName := range taskNames {
+		task, err := domain.GetTask(taskName)
+		if err != nil || task.GetSolution() != solutionName {
+			continue
+		}
+
+		if task.GetStatus() == model.TaskStatusInitial || task.GetStatus() == model.TaskStatusExecuting {
+			return true
+		}
+	}
+
+	return false
+}
+
+//------------------------------------------------------------------------------
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"sync"
 	"context"
 	"time"
 
@@ -11,9 +12,11 @@ import (
 
 //------------------------------------------------------------------------------
 
-// Dispatcher receives events from a channel and triggers a task coroutine.
+// Dispatcher receives events from a queue and triggers a task coroutine.
 type Dispatcher struct {
-	Channel chan model.Event       // the channel for event notification
+	Queue    *EventQueue              // the queue for event notification
+	workers  map[string][]model.Event // pending events of the active tasks
+	workersX sync.Mutex               // mutex for the workers
 }
 
 //------------------------------------------------------------------------------
@@ -22,15 +25,35 @@ type Dispatcher struct {
 func Start(c context.Context) (*Dispatcher) {
 	// create the dispatcher
 	dispatcher := Dispatcher{
-		Channel: GetEventChannel(),
+		Queue:   GetEventQueue(),
+		workers: map[string][]model.Event{},
 	}
 
 	// preload the controllers
-	controller.GetController("default:V1.0.0")
+	controller.GetController("", controller.InternalController)
+
+	// restore the undispatched events
+	events := []model.Event{}
+
+	configuration, _ := util.GetConfiguration()
+	if configuration != nil && configuration.QUEUE.Log != "" {
+		var err error
+
+		events, err = dispatcher.Queue.OpenLog(configuration.QUEUE.Log)
+		if err != nil {
+			util.LogError("main", "ENG", err.Error())
+		}
+	}
+
+	// restore the handlers of all known tasks
+	attachAllHandlers()
 
 	// start the dispatcher
 	go dispatcher.Run(c)
 
+	// resume processing of the restored events
+	go dispatcher.Queue.Replay(events)
+
 	util.LogInfo("main", "ENG", "engine active")
 
 	return &dispatcher
@@ -47,12 +70,15 @@ func (d *Dispatcher) Run(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		// get next event
-		case event := <-d.Channel:
+		case event := <-d.Queue.Channel:
 			// terminate if domain is empty = exit request
 			if event.Domain == "" {
 				return
 			}
 
+			// acknowledge reception of the event
+			d.Queue.Dispatched(event)
+
 			// get corresponding domain from the model
 			domain, err := model.GetDomain(event.Domain)
 			if err != nil {
@@ -65,59 +91,116 @@ func (d *Dispatcher) Run(ctx context.Context) {
 
 			// get task
 			task, err := domain.GetTask(event.Task)
+
+			// inform the subscribers
+			model.PublishEvent(&event, task)
+
 			if err != nil {
 				util.LogError("main", "engine", "Unknown error:\n" + err.Error() + "\nTask: " + event.Task)
 				continue
 			}
 
-			// determine action by type of event
-			// Event types: execute, completed, failed, timeout, terminate
-			// Task types can be:
-			// - set component state
-			// - set instance state
-			// - transition component
-			// - transition instance
-			// - parallel execute tasks
-			// - sequentially execute tasks
-			switch event.Type {
-			// execute the task
-			case model.EventTypeTaskExecution:
-				// monitor execution of new tasks
-				if task.GetStatus() == model.TaskStatusInitial {
-					go monitorTask(ctx, task, d.Channel)
-				}
-				go task.Execute(ctx)
-
-			// handle task completion
-			case model.EventTypeTaskCompletion:
-				go task.Completed(ctx)
-
-			// handle task failure
-			case model.EventTypeTaskFailure:
-				go task.Failed(ctx)
-
-			// handle timeout of a task
-			case model.EventTypeTaskTimeout:
-				go task.Timeout(ctx)
-
-			// handle termination of a task
-			case model.EventTypeTaskTermination:
-				go task.Terminate(ctx)
-			}
+			// deliver the event to the task
+			d.deliver(ctx, task, event)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// deliver hands an event over to the worker of a task. Events of the same task
+// are processed one after another in the order in which they have been received.
+func (d *Dispatcher) deliver(ctx context.Context, task *model.Task, event model.Event) {
+	d.workersX.Lock()
+	events, active := d.workers[task.UUID]
+	d.workers[task.UUID] = append(events, event)
+	d.workersX.Unlock()
+
+	// start a worker for the task if none is active
+	if !active {
+		go d.work(ctx, task)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// work processes the pending events of a task until none are left.
+func (d *Dispatcher) work(ctx context.Context, task *model.Task) {
+	for {
+		// determine next event
+		d.workersX.Lock()
+		events := d.workers[task.UUID]
+		if len(events) == 0 {
+			delete(d.workers, task.UUID)
+			d.workersX.Unlock()
+			return
+		}
+		event := events[0]
+		d.workers[task.UUID] = events[1:]
+		d.workersX.Unlock()
+
+		d.handle(ctx, task, event)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// handle triggers the event handler of a task.
+func (d *Dispatcher) handle(ctx context.Context, task *model.Task, event model.Event) {
+	// determine action by type of event
+	// Event types: execute, completed, failed, timeout, terminate
+	// Task types can be:
+	// - set component state
+	// - set instance state
+	// - transition component
+	// - transition instance
+	// - parallel execute tasks
+	// - sequentially execute tasks
+	switch event.Type {
+	// execute the task
+	case model.EventTypeTaskExecution:
+		// monitor execution of new tasks
+		if task.GetStatus() == model.TaskStatusInitial {
+			go monitorTask(ctx, task, d.Queue)
 		}
+		task.Execute(ctx)
+
+	// handle task completion
+	case model.EventTypeTaskCompletion:
+		task.Completed(ctx)
+
+	// handle task failure
+	case model.EventTypeTaskFailure:
+		task.Failed(ctx)
+
+	// handle timeout of a task
+	case model.EventTypeTaskTimeout:
+		task.Timeout(ctx)
+
+	// handle termination of a task
+	case model.EventTypeTaskTermination:
+		task.Terminate(ctx)
 	}
 }
 
 //------------------------------------------------------------------------------
 
-// monitorTask creates a context for timeout and cancelation of a task
-func monitorTask(ctx context.Context, task *model.Task, channel chan model.Event) {
-	// derive new timeout context
-	monitorCtx, cancel := context.WithTimeout(ctx, 10 * time.Second)
-	defer cancel()
+// monitorTask creates a context for timeout and cancelation of a task. The
+// timeout is extended to the deadline of a pending asynchronous operation.
+func monitorTask(ctx context.Context, task *model.Task, queue *EventQueue) {
+	// determine the timeout of the task
+	timeout := taskTimeout(task)
+	if timeout <= 0 {
+		return
+	}
+
+	for {
+		// derive new timeout context
+		monitorCtx, cancel := context.WithTimeout(ctx, timeout)
+		<- monitorCtx.Done()
+		err := monitorCtx.Err()
+		cancel()
 
-	select {
-	case <- monitorCtx.Done():
 		// check status of task
 		status := task.GetStatus()
 
@@ -126,15 +209,40 @@ func monitorTask(ctx context.Context, task *model.Task, channel chan model.Event
 		}
 
 		// task may still be active
-		switch monitorCtx.Err().Error() {
-		case "context canceled":               // termination of processes
+		switch err {
+		case context.Canceled:                 // termination of processes
 			util.LogInfo(task.UUID, "ENG", "termination")
-			channel <- model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskTermination, task.UUID, "termination")
+			queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskTermination, task.UUID, "termination"))
 		default:                               // timeout
+			// pending asynchronous operations are limited by their own deadline
+			if timeout = operationTimeout(task); timeout > 0 {
+				continue
+			}
+
 			util.LogInfo(task.UUID, "ENG", "timeout")
-			channel <- model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskTimeout, task.UUID, "timeout")
+			queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskTimeout, task.UUID, "timeout"))
 		}
+
+		return
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// taskTimeout determines the timeout of a task. Only controller tasks are
+// limited by the policy of their component, all other tasks wait for the
+// completion, failure or timeout of their subtasks.
+func taskTimeout(task *model.Task) time.Duration {
+	if task.Type != "Controller" {
+		return 0
 	}
+
+	policy, err := model.GetPolicy(task.Domain, task.Solution, task.Element, task.Cluster)
+	if err != nil {
+		return model.DefaultTimeout
+	}
+
+	return policy.GetTimeout()
 }
 
 //------------------------------------------------------------------------------
@@ -25,14 +25,14 @@ func NewElementTask(domain string, parent string, solution string, version strin
 	task.Action       = ""
 	task.UUID         = util.UUID()
 	task.Parent       = parent
-	task.Status       = model.TaskStatusInitial
+	task.SetStatus(model.TaskStatusInitial)
 	task.Phase        = 0
 	task.Subtasks     = []string{}
 
 	// add handlers
 	task.SetExecute(ExecuteElementTask)
 	task.SetTerminate(TerminateTask)
-	task.SetFailed(FailedTask)
+	task.SetFailed(FailedElementTask)
 	task.SetTimeout(TimeoutTask)
 	task.SetCompleted(CompletedTask)
 
@@ -56,8 +56,8 @@ func NewElementTask(domain string, parent string, solution string, version strin
 
 // ExecuteElementTask is the main task execution routine.
 func ExecuteElementTask(task *model.Task) {
-	// get event channel
-	channel := GetEventChannel()
+	// get event queue
+	queue := GetEventQueue()
 
 	// check status
 	status := task.GetStatus()
@@ -67,11 +67,22 @@ func ExecuteElementTask(task *model.Task) {
 	}
 
 	if status == model.TaskStatusInitial {
-		task.Status = model.TaskStatusExecuting
+		task.SetStatus(model.TaskStatusExecuting)
 	}
 
 	// determine context
-	element, _ := model.GetElement(task.Domain, task.Solution, task.Element)
+	element, err := model.GetElement(task.Domain, task.Solution, task.Element)
+	if err != nil {
+		util.LogError(task.UUID, "ENG", "unknown element: " + task.Element)
+		queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "unknown element: " + task.Element))
+		return
+	}
+
+	// replace the old cluster step by step
+	if element.Upgrade != nil && element.Upgrade.Status == model.UpgradeRunning {
+		executeUpgrade(task, element)
+		return
+	}
 
 	// one by one identify the cluster which may need to be changed
 	clusterNames, _ := element.ListClusters()
@@ -85,7 +96,7 @@ func ExecuteElementTask(task *model.Task) {
 			task.AddSubtask(&subtask)
 
 			// trigger the task
-			channel <- model.NewEvent(task.Domain, subtask.UUID, model.EventTypeTaskExecution, task.UUID, "")
+			queue.Push(model.NewEvent(task.Domain, subtask.UUID, model.EventTypeTaskExecution, task.UUID, ""))
 
 			// return and wait for next event
 			return
@@ -93,7 +104,69 @@ func ExecuteElementTask(task *model.Task) {
 	}
 
 	// execution has completed
-	channel <- model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskCompletion, task.UUID, "")
+	queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskCompletion, task.UUID, ""))
+}
+
+//------------------------------------------------------------------------------
+
+// executeUpgrade performs the next step of an upgrade between clusters.
+func executeUpgrade(task *model.Task, element *model.Element) {
+	// get event queue
+	queue := GetEventQueue()
+
+	upgrade    := element.Upgrade
+	from, err1 := element.GetCluster(upgrade.From)
+	to, err2   := element.GetCluster(upgrade.To)
+
+	if err1 != nil || err2 != nil {
+		queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "clusters of upgrade can not be identified"))
+		return
+	}
+
+	// wait until both clusters have converged
+	for _, cluster := range []*model.Cluster{to, from} {
+		if !cluster.OK() {
+			// create task to update the cluster
+			subtask, _ := NewClusterTask(task.Domain, task.UUID, task.Solution, task.Version, task.Element, cluster.Version)
+			task.AddSubtask(&subtask)
+
+			// trigger the task
+			queue.Push(model.NewEvent(task.Domain, subtask.UUID, model.EventTypeTaskExecution, task.UUID, ""))
+
+			// return and wait for next event
+			return
+		}
+	}
+
+	// adjust the sizes of the clusters for the next step
+	comment, _ := upgrade.Advance(from, to)
+	ReportProgress(task, comment)
+
+	// continue with the next step
+	queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskExecution, task.UUID, "upgrade"))
+}
+
+//------------------------------------------------------------------------------
+
+// FailedElementTask pauses or aborts an upgrade in progress before it handles
+// the failure of the task.
+func FailedElementTask(task *model.Task) {
+	// check if task is regarded to be executing
+	if task.GetStatus() != model.TaskStatusInitial && task.GetStatus() != model.TaskStatusExecuting {
+		return
+	}
+
+	element, _ := model.GetElement(task.Domain, task.Solution, task.Element)
+	if element != nil && element.Upgrade != nil && element.Upgrade.Status == model.UpgradeRunning {
+		from, _ := element.GetCluster(element.Upgrade.From)
+		to, _   := element.GetCluster(element.Upgrade.To)
+
+		if from != nil && to != nil {
+			ReportProgress(task, element.Upgrade.Fail(from, to))
+		}
+	}
+
+	FailedTask(task)
 }
 
 //------------------------------------------------------------------------------
@@ -3,15 +3,26 @@ package engine
 import (
   "testing"
   "context"
+  "errors"
   "os"
   "io"
   "time"
+  "sync"
+  "runtime"
+  "sync/atomic"
+  "path/filepath"
 
   "tsai.eu/solar/model"
+  ctrl "tsai.eu/solar/controller"
 )
 
 //------------------------------------------------------------------------------
 
+// dispatcher shared by the tests (started by TestEngine001)
+var dispatcher *Dispatcher
+
+//------------------------------------------------------------------------------
+
 // copyFile simply copies an existing file to a not yet existing destination
 func copyFile(src string, dest string) {
   srcFile, _ := os.Open(src)
@@ -26,6 +37,37 @@ func copyFile(src string, dest string) {
 
 //------------------------------------------------------------------------------
 
+// loadSolution loads the test model and adds the empty solution "app" to the
+// domain "demo" together with the architecture "app - V0.0.0".
+func loadSolution() (*model.Domain, *model.Solution, *model.Architecture) {
+  waitForIdle()
+
+  m := model.GetModel()
+  m.Load("testdata/testdata1.yaml")
+
+  domain, _       := model.GetDomain("demo")
+  solution, _     := model.NewSolution("app", "V0.0.0", "")
+  architecture, _ := model.GetArchitecture("demo", "app", "V0.0.0")
+
+  domain.AddSolution(solution)
+
+  return domain, solution, architecture
+}
+
+//------------------------------------------------------------------------------
+
+// setupSolution loads the test model and adds the solution "app" based on the
+// architecture "app - V0.0.0" to the domain "demo".
+func setupSolution() (*model.Domain, *model.Solution, *model.Architecture) {
+  domain, solution, architecture := loadSolution()
+
+  solution.Update("demo", architecture)
+
+  return domain, solution, architecture
+}
+
+//------------------------------------------------------------------------------
+
 // TestEngine001 tests the basic execution functions
 func TestEngine001(t *testing.T) {
   filename := "testdata/testdata1.yaml"
@@ -39,7 +81,7 @@ func TestEngine001(t *testing.T) {
   // cleanup routine
   defer func() {os.Remove(destConfig)}()
 
-  Start(context.Background())
+  dispatcher = Start(context.Background())
 
   // load model
   m := model.GetModel()
@@ -64,13 +106,617 @@ func TestEngine001(t *testing.T) {
     t.Errorf("task can not be created")
   }
 
-  // get event channel
-  channel := GetEventChannel()
+  // get event queue
+  queue := GetEventQueue()
 
   // create event
-  channel <- model.NewEvent("demo", task.UUID, model.EventTypeTaskExecution, "", "initial")
+  queue.Push(model.NewEvent("demo", task.UUID, model.EventTypeTaskExecution, "", "initial"))
 
   time.Sleep(time.Millisecond)
 }
 
 //------------------------------------------------------------------------------
+
+// TestEngine002 tests the recovery of interrupted tasks
+func TestEngine002(t *testing.T) {
+  filename := "testdata/testdata1.yaml"
+
+  waitForIdle()
+
+  // load model
+  m := model.GetModel()
+  m.Load(filename)
+
+  domain, _ := model.GetDomain("demo")
+
+  // restore a task which has been executing without any handlers
+  var task model.Task
+
+  task.Load2("Type: Solution\nDomain: demo\nSolution: app\nVersion: V0.0.0\nUUID: interrupted\nStatus: executing\n")
+  domain.AddTask(&task)
+
+  var unknown model.Task
+
+  unknown.Load2("Type: Unknown\nDomain: demo\nUUID: unknown\nStatus: executing\n")
+  domain.AddTask(&unknown)
+
+  if err := AttachHandlers(&unknown); err == nil {
+    t.Errorf("AttachHandlers should have complained about an unknown type of task")
+  }
+
+  Restore()
+
+  time.Sleep(100 * time.Millisecond)
+
+  if task.GetStatus() != model.TaskStatusFailed {
+    t.Errorf("Restore should have failed the interrupted task but its status is: %s", task.GetStatus())
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// TestEngine003 tests the buffering and the write ahead log of the event queue
+func TestEngine003(t *testing.T) {
+  filename := filepath.Join(t.TempDir(), "queue", "events.log")
+
+  queue := NewEventQueue(1)
+
+  _, err := queue.OpenLog(filename)
+  if err != nil {
+    t.Fatalf("<queue>.OpenLog should have created the event log:\n%s", err)
+  }
+
+  event1 := model.NewEvent("demo", "task1", model.EventTypeTaskExecution, "", "")
+  event2 := model.NewEvent("demo", "task2", model.EventTypeTaskExecution, "", "")
+
+  queue.Push(event1)
+
+  // second event has to wait for free capacity
+  pushed := make(chan bool)
+  go func() {
+    queue.Push(event2)
+    close(pushed)
+  }()
+
+  deadline := time.Now().Add(5 * time.Second)
+  for queue.Metrics().Blocked == 0 {
+    if time.Now().After(deadline) {
+      t.Fatalf("<queue>.Push should have waited for free capacity")
+    }
+    runtime.Gosched()
+  }
+
+  queue.Dispatched(<-queue.Channel)
+
+  select {
+  case <-pushed:
+  case <-time.After(5 * time.Second):
+    t.Fatalf("<queue>.Push should have buffered the event after the capacity has been freed")
+  }
+
+  metrics := queue.Metrics()
+  if metrics.Capacity != 1 || metrics.Depth != 1 || metrics.Enqueued != 2 || metrics.Dispatched != 1 || metrics.Blocked != 1 || metrics.Pending != 1 {
+    t.Errorf("<queue>.Metrics reports unexpected values: %+v", metrics)
+  }
+
+  // undispatched events are restored from the log
+  queue2 := NewEventQueue(1)
+
+  events, err := queue2.OpenLog(filename)
+  if err != nil {
+    t.Fatalf("<queue>.OpenLog should have read the event log:\n%s", err)
+  }
+
+  if len(events) != 1 || events[0].UUID != event2.UUID {
+    t.Fatalf("<queue>.OpenLog should have restored the undispatched event only")
+  }
+
+  if !queue2.Replayed("task2") || queue2.Replayed("task1") {
+    t.Errorf("<queue>.Replayed should only report tasks with restored events")
+  }
+
+  // the log is emptied as soon as all events have been dispatched
+  queue2.Replay(events)
+  queue2.Dispatched(<-queue2.Channel)
+
+  if queue2.Metrics().Pending != 0 {
+    t.Errorf("<queue>.Dispatched should have acknowledged the restored event")
+  }
+
+  info, _ := os.Stat(filename)
+  if info == nil || info.Size() != 0 {
+    t.Errorf("event log should have been truncated")
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// TestEngine004 tests the ordered delivery of events to a task
+func TestEngine004(t *testing.T) {
+  domain, _ := model.GetDomain("demo")
+
+  // a task which records the order of its events
+  var task model.Task
+
+  task.Load2("Type: Solution\nDomain: demo\nUUID: ordered\nStatus: executing\n")
+  domain.AddTask(&task)
+
+  var mutex sync.Mutex
+
+  order  := []string{}
+  active := int32(0)
+  failed := int32(0)
+
+  record := func(name string) model.TaskHandler {
+    return func(task *model.Task) {
+      if atomic.AddInt32(&active, 1) > 1 {
+        atomic.StoreInt32(&failed, 1)
+      }
+      time.Sleep(5 * time.Millisecond)
+
+      mutex.Lock()
+      order = append(order, name)
+      mutex.Unlock()
+
+      atomic.AddInt32(&active, -1)
+    }
+  }
+
+  task.SetExecute(record("execute"))
+  task.SetCompleted(record("completed"))
+  task.SetFailed(record("failed"))
+
+  queue := GetEventQueue()
+  queue.Push(model.NewEvent("demo", "ordered", model.EventTypeTaskExecution, "", ""))
+  queue.Push(model.NewEvent("demo", "ordered", model.EventTypeTaskCompletion, "", ""))
+  queue.Push(model.NewEvent("demo", "ordered", model.EventTypeTaskFailure, "", ""))
+
+  time.Sleep(100 * time.Millisecond)
+
+  mutex.Lock()
+  defer mutex.Unlock()
+
+  if atomic.LoadInt32(&failed) != 0 || len(order) != 3 || order[0] != "execute" || order[1] != "completed" || order[2] != "failed" {
+    t.Errorf("events of a task should have been processed one after another: %v", order)
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// TestEngine005 tests the retry of timed out controller tasks
+func TestEngine005(t *testing.T) {
+  domain, solution, _ := setupSolution()
+
+  elementNames, _ := solution.ListElements()
+  element, _      := solution.GetElement(elementNames[0])
+  clusterNames, _ := element.ListClusters()
+  cluster, _      := element.GetCluster(clusterNames[0])
+
+  // instance task with a timed out controller task
+  task, _    := NewInstanceTask("demo", "", "app", "V0.0.0", element.Element, cluster.Version, "instance", model.ActiveState)
+  subtask, _ := NewControllerTask("demo", task.UUID, "app", "V0.0.0", element.Element, cluster.Version, "instance", model.ActiveState, "create")
+
+  instanceTask, _ := domain.GetTask(task.UUID)
+  instanceTask.AddSubtask(&subtask)
+  instanceTask.SetStatus(model.TaskStatusExecuting)
+
+  controllerTask, _ := domain.GetTask(subtask.UUID)
+  controllerTask.SetStatus(model.TaskStatusTimeout)
+
+  // the transition is retried
+  retries := 1
+  cluster.Policy = model.Policy{Retries: &retries, Backoff: "1h"}
+
+  TimeoutInstanceTask(instanceTask)
+
+  if instanceTask.Status != model.TaskStatusExecuting {
+    t.Errorf("TimeoutInstanceTask should have retried the transition")
+  }
+
+  // the retries are exhausted
+  cluster.Policy = model.Policy{}
+
+  TimeoutInstanceTask(instanceTask)
+
+  if instanceTask.Status != model.TaskStatusTimeout {
+    t.Errorf("TimeoutInstanceTask should have given up after the retries had been exhausted")
+  }
+
+  // failed controller tasks are retried without blocking their worker
+  policy := model.Policy{Retries: &retries, Backoff: "1h"}
+
+  controllerTask.SetStatus(model.TaskStatusExecuting)
+  controllerTask.SetOperation("", time.Now().Add(time.Minute).UnixNano())
+
+  if retryControllerTask(controllerTask, policy, errors.New("failure")) {
+    t.Errorf("retryControllerTask should not have retried beyond the deadline of the task")
+  }
+
+  controllerTask.SetOperation("", time.Now().Add(2 * time.Hour).UnixNano())
+
+  if !retryControllerTask(controllerTask, policy, errors.New("failure")) || controllerTask.Retries != 1 {
+    t.Errorf("retryControllerTask should have scheduled a retry")
+  }
+
+  if retryControllerTask(controllerTask, policy, errors.New("failure")) {
+    t.Errorf("retryControllerTask should have given up after the retries had been exhausted")
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// TestEngine006 tests the failure handling of an upgrade.
+func TestEngine006(t *testing.T) {
+  domain, solution, _ := setupSolution()
+
+  element, _ := solution.GetElement("app")
+  from, _    := element.GetCluster("V1.0.0")
+  to, _      := model.NewCluster("V2.0.0", model.ActiveState, 3, 3, 3, "")
+
+  element.AddCluster(to)
+  element.Upgrade = &model.Upgrade{From: "V1.0.0", To: "V2.0.0", Size: 3, Restore: from.Size, Status: model.UpgradeRunning}
+
+  // a failed element task pauses the upgrade
+  task, _ := NewElementTask("demo", "", "app", "V0.0.0", "app")
+
+  elementTask, _ := domain.GetTask(task.UUID)
+  elementTask.SetStatus(model.TaskStatusExecuting)
+
+  FailedElementTask(elementTask)
+
+  if elementTask.Status != model.TaskStatusFailed || element.Upgrade.Status != model.UpgradePaused {
+    t.Errorf("FailedElementTask should have paused the upgrade")
+  }
+
+  if len(elementTask.Events) != 1 {
+    t.Errorf("FailedElementTask should have recorded the progress of the upgrade")
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// TestEngine007 tests the automatic rollback of a failed deployment.
+func TestEngine007(t *testing.T) {
+  domain, solution, architecture := loadSolution()
+
+  // a successful deployment followed by a failed deployment
+  solution.AddDeployment("app", "V0.0.0", "previous", false)
+  solution.SetOutcome("previous", model.TaskStatusCompleted)
+
+  task, _ := NewSolutionTask("demo", "", solution)
+  solution.AddDeployment("app", "V0.0.0", task.UUID, false)
+
+  solutionTask, _ := domain.GetTask(task.UUID)
+  solutionTask.SetStatus(model.TaskStatusExecuting)
+
+  // a subtask of the failed deployment is still running
+  subtask, _     := NewElementTask("demo", task.UUID, "app", "V0.0.0", "app")
+  elementTask, _ := domain.GetTask(subtask.UUID)
+  elementTask.SetStatus(model.TaskStatusExecuting)
+  solutionTask.AddSubtask(elementTask)
+
+  architecture.AutoRollback = true
+
+  FailedSolutionTask(solutionTask)
+
+  deployment, _ := solution.GetDeployment(task.UUID)
+  if deployment.Outcome != model.TaskStatusFailed {
+    t.Errorf("FailedSolutionTask should have recorded the outcome of the deployment")
+  }
+
+  history := func() []*model.Deployment {
+    solution.HistoryX.RLock()
+    defer solution.HistoryX.RUnlock()
+
+    return append([]*model.Deployment{}, solution.History...)
+  }
+
+  if len(history()) != 2 {
+    t.Fatalf("FailedSolutionTask should not have rolled back the solution while a subtask is running")
+  }
+
+  // the rollback starts once the subtask has stopped
+  elementTask.SetStatus(model.TaskStatusTerminated)
+
+  for i := 0; i < 100 && len(history()) == 2; i++ {
+    time.Sleep(20 * time.Millisecond)
+  }
+
+  deployments := history()
+  latest      := deployments[len(deployments) - 1]
+  if len(deployments) != 3 || !latest.Rollback || latest.Version != "V0.0.0" {
+    t.Fatalf("FailedSolutionTask should have rolled back the solution")
+  }
+
+  // a failed rollback is not rolled back again
+  rollbackTask, _ := domain.GetTask(latest.Task)
+  rollbackTask.SetStatus(model.TaskStatusExecuting)
+
+  FailedSolutionTask(rollbackTask)
+
+  if len(history()) != 3 {
+    t.Errorf("FailedSolutionTask should not have rolled back a rollback")
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// TestEngine008 tests the binding of relationships.
+func TestEngine008(t *testing.T) {
+  domain, solution, _ := setupSolution()
+
+  // deploy the solution
+  task, _ := NewSolutionTask("demo", "", solution)
+  GetEventQueue().Push(model.NewEvent("demo", task.UUID, model.EventTypeTaskExecution, "", "bind"))
+
+  if !waitForTask(domain, task.UUID) {
+    t.Fatalf("solution task should have completed")
+  }
+
+  cluster, _      := model.GetCluster("demo", "app", "app", "V1.0.0")
+  relationship, _ := cluster.GetRelationship("db")
+
+  if relationship.State != model.ActiveState || relationship.Target != model.ActiveState {
+    t.Errorf("solution task should have bound the relationship: %s", relationship.State)
+  }
+
+  if countTasks(domain, "Relationship", "bind") == 0 {
+    t.Errorf("solution task should have bound the relationships by controller tasks")
+  }
+
+  // instances which become active later are bound as well
+  cluster.Resize(cluster.Min, cluster.Max + 1, cluster.Size + 1)
+
+  task, _ = NewClusterTask("demo", "", "app", "V0.0.0", "app", "V1.0.0")
+  GetEventQueue().Push(model.NewEvent("demo", task.UUID, model.EventTypeTaskExecution, "", "scale out"))
+
+  if !waitForTask(domain, task.UUID) {
+    t.Fatalf("cluster task should have completed")
+  }
+
+  instanceNames, _ := cluster.ListInstances()
+  for _, instanceName := range instanceNames {
+    instance, _ := cluster.GetInstance(instanceName)
+    if instance.State == model.ActiveState && !instance.IsBound("db") {
+      t.Errorf("cluster task should have bound the new instance: %s", instanceName)
+    }
+  }
+
+  // deactivating the related cluster releases the binding
+  db, _ := model.GetCluster("demo", "app", "db", "V1.0.0")
+  db.Target = model.InactiveState
+
+  task, _ = NewClusterTask("demo", "", "app", "V0.0.0", "db", "V1.0.0")
+  GetEventQueue().Push(model.NewEvent("demo", task.UUID, model.EventTypeTaskExecution, "", "unbind"))
+
+  if !waitForTask(domain, task.UUID) {
+    t.Fatalf("cluster task should have completed")
+  }
+
+  if relationship.State != model.InitialState || relationship.Endpoint != "" {
+    t.Errorf("cluster task should have released the binding: %s", relationship.State)
+  }
+
+  if countTasks(domain, "Relationship", "unbind") == 0 {
+    t.Errorf("cluster task should have released the binding by controller tasks")
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// TestEngine009 tests the reconfiguration of instances with a changed configuration.
+func TestEngine009(t *testing.T) {
+  domain, solution, _ := setupSolution()
+
+  // deploy the solution
+  task, _ := NewSolutionTask("demo", "", solution)
+  GetEventQueue().Push(model.NewEvent("demo", task.UUID, model.EventTypeTaskExecution, "", "deploy"))
+
+  if !waitForTask(domain, task.UUID) {
+    t.Fatalf("solution task should have completed")
+  }
+
+  cluster, _ := model.GetCluster("demo", "app", "app", "V1.0.0")
+  if !cluster.OK() {
+    t.Fatalf("solution task should have converged the cluster")
+  }
+
+  // a changed configuration is applied to the active instances
+  cluster.Configuration = "Port: 8080"
+  reconfigured := countTasks(domain, "Instance", "reconfigure")
+
+  if cluster.OK() {
+    t.Errorf("<cluster>.OK should have reported the changed configuration")
+  }
+
+  task, _ = NewClusterTask("demo", "", "app", "V0.0.0", "app", "V1.0.0")
+  GetEventQueue().Push(model.NewEvent("demo", task.UUID, model.EventTypeTaskExecution, "", "reconfigure"))
+
+  if !waitForTask(domain, task.UUID) {
+    t.Fatalf("cluster task should have completed")
+  }
+
+  if countTasks(domain, "Instance", "reconfigure") - reconfigured != cluster.Size || !cluster.OK() {
+    t.Errorf("cluster task should have reconfigured the active instances")
+  }
+
+  // components may require the replacement of the instances
+  component, _ := model.GetComponent2("demo", "app", "app", "V1.0.0")
+  component.Replace = true
+  defer func() {component.Replace = false}()
+
+  cluster.Configuration = "Port: 9090"
+  reconfigured = countTasks(domain, "Instance", "reconfigure")
+  stopped     := countTasks(domain, "Instance", "stop")
+
+  task, _ = NewClusterTask("demo", "", "app", "V0.0.0", "app", "V1.0.0")
+  GetEventQueue().Push(model.NewEvent("demo", task.UUID, model.EventTypeTaskExecution, "", "replace"))
+
+  if !waitForTask(domain, task.UUID) {
+    t.Fatalf("cluster task should have completed")
+  }
+
+  if countTasks(domain, "Instance", "reconfigure") != reconfigured || countTasks(domain, "Instance", "stop") == stopped {
+    t.Errorf("cluster task should have replaced the instances instead of reconfiguring them")
+  }
+
+  if !cluster.OK() {
+    t.Errorf("cluster task should have converged the cluster with the replaced instances")
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// waitForTask waits until a task has finished and reports if it has completed.
+func waitForTask(domain *model.Domain, uuid string) bool {
+  for i := 0; i < 500; i++ {
+    task, _ := domain.GetTask(uuid)
+    if task.GetStatus() != model.TaskStatusInitial && task.GetStatus() != model.TaskStatusExecuting {
+      return task.GetStatus() == model.TaskStatusCompleted
+    }
+    time.Sleep(20 * time.Millisecond)
+  }
+
+  return false
+}
+
+//------------------------------------------------------------------------------
+
+// waitForIdle waits until the dispatcher has handled all events so that the
+// model can be reloaded without interfering with the tasks of a previous test.
+func waitForIdle() {
+  if dispatcher == nil {
+    return
+  }
+
+  for idle := 0; idle < 5; {
+    dispatcher.workersX.Lock()
+    workers := len(dispatcher.workers)
+    dispatcher.workersX.Unlock()
+
+    idle++
+    if workers > 0 || len(dispatcher.Queue.Channel) > 0 {
+      idle = 0
+    }
+    time.Sleep(10 * time.Millisecond)
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// countTasks counts the controller tasks with an action issued by tasks of a type.
+func countTasks(domain *model.Domain, parentType string, action string) int {
+  count := 0
+
+  taskNames, _ := domain.ListTasks()
+  for _, taskName := range taskNames {
+    task, _ := domain.GetTask(taskName)
+    if task.Type != "Controller" || task.Action != action {
+      continue
+    }
+
+    parent, err := domain.GetTask(task.Parent)
+    if err == nil && parent.Type == parentType {
+      count++
+    }
+  }
+
+  return count
+}
+
+//------------------------------------------------------------------------------
+
+// TestEngine010 tests controller tasks waiting for asynchronous operations
+func TestEngine010(t *testing.T) {
+  domain, solution, _ := setupSolution()
+
+  // deploy the solution
+  task, _ := NewSolutionTask("demo", "", solution)
+  GetEventQueue().Push(model.NewEvent("demo", task.UUID, model.EventTypeTaskExecution, "", "deploy"))
+
+  if !waitForTask(domain, task.UUID) {
+    t.Fatalf("solution task should have completed")
+  }
+
+  cluster, _       := model.GetCluster("demo", "app", "app", "V1.0.0")
+  instanceNames, _ := cluster.ListInstances()
+  if len(instanceNames) == 0 {
+    t.Fatalf("cluster should have had instances")
+  }
+  instance, _ := cluster.GetInstance(instanceNames[0])
+
+  // a task waiting for an operation is resumed by the callback of the controller
+  controllerTask, _ := NewControllerTask("demo", "", "app", "V0.0.0", "app", "V1.0.0", instanceNames[0], model.ActiveState, "start")
+  pending, _        := domain.GetTask(controllerTask.UUID)
+
+  pending.SetStatus(model.TaskStatusExecuting)
+  pending.SetOperation("op-1", time.Now().Add(time.Minute).UnixNano())
+
+  if CompleteOperation("demo", "op-2", &ctrl.Response{Code: 200}) == nil {
+    t.Errorf("CompleteOperation should have rejected an unknown operation")
+  }
+
+  response := ctrl.Response{
+    Code:     200,
+    Domain:   "demo",
+    Solution: "app",
+    Version:  "V0.0.0",
+    Element:  "app",
+    Cluster:  "V1.0.0",
+    Instance: instanceNames[0],
+    State:    model.ActiveState,
+  }
+
+  if err := CompleteOperation("demo", "op-1", &response); err != nil {
+    t.Fatalf("CompleteOperation should have resumed the task:\n%s", err)
+  }
+
+  if !waitForTask(domain, pending.UUID) {
+    t.Fatalf("controller task should have completed after the callback")
+  }
+
+  if pending.Operation != "" || instance.State != model.ActiveState {
+    t.Errorf("controller task should have applied the result of the operation: %s", instance.State)
+  }
+
+  // a callback cancels the scheduled poll and may omit the code if it reports the final state
+  controllerTask, _ = NewControllerTask("demo", "", "app", "V0.0.0", "app", "V1.0.0", instanceNames[0], model.ActiveState, "start")
+  pending, _        = domain.GetTask(controllerTask.UUID)
+
+  pending.SetStatus(model.TaskStatusExecuting)
+  pending.SetOperation("op-2", time.Now().Add(time.Minute).UnixNano())
+
+  awaitOperation(pending, &model.CurrentState{Operation: "op-2"}, model.Policy{})
+
+  response.Code = 0
+  if err := CompleteOperation("demo", "op-2", &response); err != nil {
+    t.Fatalf("CompleteOperation should have resumed the task:\n%s", err)
+  }
+
+  operationsX.Lock()
+  _, scheduled := polls[pending.UUID]
+  operationsX.Unlock()
+
+  if scheduled {
+    t.Errorf("callback should have cancelled the scheduled poll")
+  }
+
+  if !waitForTask(domain, pending.UUID) || pending.GetStatus() != model.TaskStatusCompleted {
+    t.Errorf("controller task should have accepted the callback without code: %s", pending.GetStatus())
+  }
+
+  // a task times out once the deadline of the operation has passed
+  controllerTask, _ = NewControllerTask("demo", "", "app", "V0.0.0", "app", "V1.0.0", instanceNames[0], model.ActiveState, "start")
+  pending, _        = domain.GetTask(controllerTask.UUID)
+
+  pending.SetStatus(model.TaskStatusExecuting)
+  pending.SetOperation("op-3", time.Now().Add(-time.Second).UnixNano())
+
+  awaitOperation(pending, &model.CurrentState{Operation: "op-3"}, model.Policy{})
+
+  if waitForTask(domain, pending.UUID) || pending.GetStatus() != model.TaskStatusTimeout {
+    t.Errorf("controller task should have timed out: %s", pending.GetStatus())
+  }
+}
+
+//------------------------------------------------------------------------------
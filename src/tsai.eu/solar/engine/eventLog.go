@@ -0,0 +1,172 @@
+package engine
+
+import (
+	"os"
+	"sync"
+	"bufio"
+	"errors"
+	"encoding/json"
+	"path/filepath"
+
+	"tsai.eu/solar/model"
+)
+
+//------------------------------------------------------------------------------
+
+// eventRecord is a single entry of the write ahead log.
+type eventRecord struct {
+	Operation string       `json:"op"`              // "push" or "ack"
+	UUID      string       `json:"uuid"`            // uuid of the event
+	Event     *model.Event `json:"event,omitempty"` // event (only for "push")
+}
+
+//------------------------------------------------------------------------------
+
+// eventLog records all events which have been added to the event queue but
+// which have not been dispatched yet.
+type eventLog struct {
+	file     *os.File        // log file opened for appending
+	encoder  *json.Encoder   // encoder for log entries
+	pending  map[string]bool // uuids of undispatched events
+	mutex    sync.Mutex      // mutex for the log
+}
+
+//------------------------------------------------------------------------------
+
+// openEventLog opens a write ahead log and returns the undispatched events in
+// the order in which they had been added.
+func openEventLog(path string) (*eventLog, []model.Event, error) {
+	if path == "" {
+		return nil, nil, errors.New("path of event log is undefined")
+	}
+
+	events, err := readEventLog(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// compact the log so that it only contains the undispatched events
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return nil, nil, errors.New("unable to create directory of event log:\n" + err.Error())
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, nil, errors.New("unable to open event log:\n" + err.Error())
+	}
+
+	log := eventLog{
+		file:    file,
+		encoder: json.NewEncoder(file),
+		pending: map[string]bool{},
+	}
+
+	for index := range events {
+		if err = log.append(events[index]); err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+	}
+
+	// success
+	return &log, events, nil
+}
+
+//------------------------------------------------------------------------------
+
+// readEventLog reads the undispatched events from an existing log.
+func readEventLog(path string) ([]model.Event, error) {
+	events := []model.Event{}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return events, nil
+	}
+	if err != nil {
+		return nil, errors.New("unable to read event log:\n" + err.Error())
+	}
+	defer file.Close()
+
+	// replay the log
+	pushed := []*model.Event{}
+	acked  := map[string]bool{}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var record eventRecord
+
+		// ignore incomplete entries written during a crash
+		if json.Unmarshal(scanner.Bytes(), &record) != nil {
+			continue
+		}
+
+		switch record.Operation {
+		case "push":
+			if record.Event != nil {
+				pushed = append(pushed, record.Event)
+			}
+		case "ack":
+			acked[record.UUID] = true
+		}
+	}
+
+	for _, event := range pushed {
+		if !acked[event.UUID] {
+			events = append(events, *event)
+		}
+	}
+
+	// success
+	return events, nil
+}
+
+//------------------------------------------------------------------------------
+
+// append records an event which has been added to the queue.
+func (log *eventLog) append(event model.Event) error {
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+
+	log.pending[event.UUID] = true
+
+	return log.encoder.Encode(eventRecord{Operation: "push", UUID: event.UUID, Event: &event})
+}
+
+//------------------------------------------------------------------------------
+
+// ack records an event which has been dispatched. The log is truncated as soon
+// as no undispatched events are left.
+func (log *eventLog) ack(event model.Event) error {
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+
+	if !log.pending[event.UUID] {
+		return nil
+	}
+	delete(log.pending, event.UUID)
+
+	// all events have been dispatched
+	if len(log.pending) == 0 {
+		if err := log.file.Truncate(0); err != nil {
+			return err
+		}
+		_, err := log.file.Seek(0, 0)
+		return err
+	}
+
+	return log.encoder.Encode(eventRecord{Operation: "ack", UUID: event.UUID})
+}
+
+//------------------------------------------------------------------------------
+
+// size provides the number of undispatched events.
+func (log *eventLog) size() int {
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+
+	return len(log.pending)
+}
+
+//------------------------------------------------------------------------------
@@ -1,7 +1,9 @@
 package engine
 
 import (
+	"time"
 	"errors"
+	"strconv"
 
 	"tsai.eu/solar/model"
 	"tsai.eu/solar/util"
@@ -11,6 +13,23 @@ import (
 
 // NewInstanceTask creates a new instance task
 func NewInstanceTask(domain string, parent string, solution string, version string, element string, cluster string, instance string, state string) (model.Task, error) {
+	return newInstanceTask(domain, parent, solution, version, element, cluster, instance, state, "")
+}
+
+//------------------------------------------------------------------------------
+
+// NewReconfigurationTask creates a new instance task applying a changed
+// configuration to an instance. The action is either a transition of the
+// controller (configure/reconfigure) or a replacement of the instance which
+// resets the instance (state initial).
+func NewReconfigurationTask(domain string, parent string, solution string, version string, element string, cluster string, instance string, state string, action string) (model.Task, error) {
+	return newInstanceTask(domain, parent, solution, version, element, cluster, instance, state, action)
+}
+
+//------------------------------------------------------------------------------
+
+// newInstanceTask creates a new instance task
+func newInstanceTask(domain string, parent string, solution string, version string, element string, cluster string, instance string, state string, action string) (model.Task, error) {
 	var task model.Task
 
 	// TODO: check parameters if context exists
@@ -22,10 +41,10 @@ func NewInstanceTask(domain string, parent string, solution string, version stri
 	task.Cluster  = cluster
 	task.Instance = instance
 	task.State    = state
-	task.Action   = ""
+	task.Action   = action
 	task.UUID     = util.UUID()
 	task.Parent   = parent
-	task.Status   = model.TaskStatusInitial
+	task.SetStatus(model.TaskStatusInitial)
 	task.Phase    = 0
 	task.Subtasks = []string{}
 
@@ -33,7 +52,7 @@ func NewInstanceTask(domain string, parent string, solution string, version stri
 	task.SetExecute(ExecuteInstanceTask)
 	task.SetTerminate(TerminateTask)
 	task.SetFailed(FailedTask)
-	task.SetTimeout(TimeoutTask)
+	task.SetTimeout(TimeoutInstanceTask)
 	task.SetCompleted(CompletedTask)
 
 	// get domain
@@ -58,8 +77,8 @@ func NewInstanceTask(domain string, parent string, solution string, version stri
 
 // ExecuteInstanceTask is the main task execution routine.
 func ExecuteInstanceTask(task *model.Task) {
-	// get event channel
-	channel := GetEventChannel()
+	// get event queue
+	queue := GetEventQueue()
 
 	// check status
 	taskStatus := task.GetStatus()
@@ -71,7 +90,7 @@ func ExecuteInstanceTask(task *model.Task) {
 	// initialize if needed
 	if taskStatus == model.TaskStatusInitial {
 		// update status
-		task.Status = model.TaskStatusExecuting
+		task.SetStatus(model.TaskStatusExecuting)
 	}
 
 	// TODO: implement and proper error handling
@@ -84,14 +103,25 @@ func ExecuteInstanceTask(task *model.Task) {
 	// - in case of error trigger failure
 
 	// determine context
-	instance, _  := model.GetInstance(task.Domain, task.Solution, task.Element, task.Cluster, task.Instance)
+	instance, err := model.GetInstance(task.Domain, task.Solution, task.Element, task.Cluster, task.Instance)
+	if err != nil {
+		util.LogError(task.UUID, "ENG", "unknown instance: " + task.Element + " - " + task.Cluster + " - " + task.Instance)
+		queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "unknown instance: " + task.Element + " - " + task.Cluster + " - " + task.Instance))
+		return
+	}
 
 	// update target state of instance
 	instance.Target = task.State
 
+	// apply a changed configuration by the controller
+	if task.Action == "configure" || task.Action == "reconfigure" {
+		executeReconfiguration(task, instance)
+		return
+	}
+
 	// check if the target state has been reached
 	if instance.State == instance.Target {
-		channel <- model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskCompletion, task.UUID, "")
+		queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskCompletion, task.UUID, ""))
 		return
 	}
 
@@ -100,7 +130,7 @@ func ExecuteInstanceTask(task *model.Task) {
 
 	if err != nil {
 		util.LogError(task.UUID, "ENG", "invalid transition")
-		channel <- model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "invalid transition")
+		queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "invalid transition"))
 		return
 	}
 
@@ -109,7 +139,67 @@ func ExecuteInstanceTask(task *model.Task) {
 	task.AddSubtask(&subtask)
 
 	// trigger the task
-	channel <- model.NewEvent(task.Domain, subtask.UUID, model.EventTypeTaskExecution, task.UUID, "")
+	queue.Push(model.NewEvent(task.Domain, subtask.UUID, model.EventTypeTaskExecution, task.UUID, ""))
+}
+
+//------------------------------------------------------------------------------
+
+// executeReconfiguration triggers the controller to apply the changed
+// configuration to an instance once.
+func executeReconfiguration(task *model.Task, instance *model.Instance) {
+	// get event queue
+	queue := GetEventQueue()
+
+	// check if the configuration has been applied
+	for _, subtaskUUID := range task.Subtasks {
+		subtask, err := task.GetSubtask(subtaskUUID)
+		if err == nil && subtask.GetStatus() == model.TaskStatusCompleted {
+			queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskCompletion, task.UUID, ""))
+			return
+		}
+	}
+
+	// create controller task for the reconfiguration
+	subtask, _ := NewControllerTask(task.Domain, task.UUID, task.Solution, task.Version, task.Element, task.Cluster, instance.UUID, task.State, task.Action)
+	task.AddSubtask(&subtask)
+
+	// trigger the task
+	queue.Push(model.NewEvent(task.Domain, subtask.UUID, model.EventTypeTaskExecution, task.UUID, ""))
+}
+
+//------------------------------------------------------------------------------
+
+// TimeoutInstanceTask handles the timeout of a controller subtask by retrying
+// the transition until the retries defined by the policy are exhausted.
+func TimeoutInstanceTask(task *model.Task) {
+	// check if task is regarded to be executing
+	if task.GetStatus() != model.TaskStatusInitial && task.GetStatus() != model.TaskStatusExecuting {
+		return
+	}
+
+	// determine number of previous retries
+	retries := 0
+	for _, subtaskUUID := range task.Subtasks {
+		subtask, err := task.GetSubtask(subtaskUUID)
+		if err == nil && subtask.GetStatus() == model.TaskStatusTimeout {
+			retries++
+		}
+	}
+
+	policy, _ := model.GetPolicy(task.Domain, task.Solution, task.Element, task.Cluster)
+	if retries > policy.GetRetries() {
+		TimeoutTask(task)
+		return
+	}
+
+	// retrigger execution of the task after a delay
+	delay := policy.GetDelay(retries - 1)
+
+	util.LogWarn(task.UUID, "ENG", "controller has timed out - retry " + strconv.Itoa(retries) + " of " + strconv.Itoa(policy.GetRetries()) + " in " + delay.String())
+
+	time.AfterFunc(delay, func() {
+		GetEventQueue().Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskExecution, task.UUID, "retry"))
+	})
 }
 
 //------------------------------------------------------------------------------
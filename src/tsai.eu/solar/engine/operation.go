@@ -0,0 +1,153 @@
+package engine
+
+import (
+	"sync"
+	"time"
+	"errors"
+	"net/http"
+
+	ctrl "tsai.eu/solar/controller"
+	"tsai.eu/solar/model"
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// pollInterval is the time between two polls of a pending asynchronous operation.
+var pollInterval = 2 * time.Second
+
+//------------------------------------------------------------------------------
+
+var operations  = map[string]*ctrl.Response{} // results of asynchronous operations reported by callbacks by task
+var polls       = map[string]*time.Timer{}     // scheduled polls of pending operations by task
+var operationsX sync.Mutex                     // mutex for operations and polls
+
+//------------------------------------------------------------------------------
+
+// CompleteOperation records the result of an asynchronous operation reported
+// by the callback of a controller and resumes the controller task waiting for
+// the operation.
+func CompleteOperation(domainName string, operation string, response *ctrl.Response) error {
+	domain, err := model.GetDomain(domainName)
+	if err != nil {
+		return err
+	}
+
+	// determine the task waiting for the operation
+	taskNames, _ := domain.ListTasks()
+	for _, taskName := range taskNames {
+		task, _ := domain.GetTask(taskName)
+
+		if task == nil || task.Type != "Controller" || task.GetOperation() != operation || task.GetStatus() != model.TaskStatusExecuting {
+			continue
+		}
+
+		operationsX.Lock()
+		operations[task.UUID] = response
+
+		// the scheduled poll is obsolete
+		if timer, found := polls[task.UUID]; found {
+			timer.Stop()
+			delete(polls, task.UUID)
+		}
+		operationsX.Unlock()
+
+		// resume the task
+		GetEventQueue().Push(model.NewEvent(domainName, task.UUID, model.EventTypeTaskExecution, "", "operation: " + operation))
+
+		// success
+		return nil
+	}
+
+	return errors.New("unknown operation: " + operation)
+}
+
+//------------------------------------------------------------------------------
+
+// resumeOperation determines the result of the pending operation of a task.
+// A result reported by a callback takes precedence over polling the controller.
+// A callback without a response code is accepted if it reports the final state.
+func resumeOperation(task *model.Task, controller ctrl.Controller, targetState *model.TargetState) (*model.CurrentState, error) {
+	operationsX.Lock()
+	response, found := operations[task.UUID]
+	delete(operations, task.UUID)
+	operationsX.Unlock()
+
+	if found {
+		if response.Code != http.StatusOK && (response.Code != 0 || response.State == "") {
+			return nil, errors.New("operation: " + task.Operation + " has failed: " + response.Status)
+		}
+		return ctrl.NewCurrentState(response), nil
+	}
+
+	return controller.Poll(targetState, task.Operation)
+}
+
+//------------------------------------------------------------------------------
+
+// awaitOperation records the pending operation of a task and schedules the
+// next poll, which is cancelled by the callback of the controller. The task remains executing until the operation has finished or
+// its deadline has passed. The deadline is derived from the timeout reported
+// by the controller or from the policy of the component.
+func awaitOperation(task *model.Task, currentState *model.CurrentState, policy model.Policy) {
+	queue := GetEventQueue()
+
+	// a new operation has been accepted by the controller
+	if task.GetOperation() != currentState.Operation {
+		timeout, err := time.ParseDuration(currentState.Timeout)
+		if err != nil || timeout <= 0 {
+			timeout = policy.GetTimeout()
+		}
+
+		task.SetOperation(currentState.Operation, time.Now().Add(timeout).UnixNano())
+
+		util.LogInfo(task.UUID, "ENG", "controller has accepted operation: " + task.Operation + " (timeout: " + timeout.String() + ")")
+	}
+
+	// the operation has exceeded its deadline
+	if time.Now().UnixNano() > task.GetDeadline() {
+		util.LogInfo(task.UUID, "ENG", "operation: " + task.Operation + " has timed out")
+		queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskTimeout, task.UUID, "timeout"))
+		return
+	}
+
+	// poll the operation again unless a callback has reported the result meanwhile
+	domain := task.Domain
+	uuid   := task.UUID
+
+	operationsX.Lock()
+	defer operationsX.Unlock()
+
+	if timer, found := polls[uuid]; found {
+		timer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(pollInterval, func() {
+		operationsX.Lock()
+		scheduled := polls[uuid] == timer
+		if scheduled {
+			delete(polls, uuid)
+		}
+		operationsX.Unlock()
+
+		if scheduled {
+			queue.Push(model.NewEvent(domain, uuid, model.EventTypeTaskExecution, "", "poll"))
+		}
+	})
+	polls[uuid] = timer
+}
+
+//------------------------------------------------------------------------------
+
+// operationTimeout determines the remaining time until the deadline of the
+// pending operation of a task (0 if no operation is pending).
+func operationTimeout(task *model.Task) time.Duration {
+	if task.GetOperation() == "" {
+		return 0
+	}
+
+	return time.Until(time.Unix(0, task.GetDeadline()))
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,198 @@
+package engine
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"tsai.eu/solar/model"
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// QueueMetrics describes the load of the event queue.
+type QueueMetrics struct {
+	Capacity   int    `yaml:"Capacity"`   // maximum number of buffered events
+	Depth      int    `yaml:"Depth"`      // number of currently buffered events
+	HighWater  int    `yaml:"HighWater"`  // maximum number of buffered events observed
+	Enqueued   uint64 `yaml:"Enqueued"`   // number of events added to the queue
+	Dispatched uint64 `yaml:"Dispatched"` // number of events taken from the queue
+	Blocked    uint64 `yaml:"Blocked"`    // number of events which had to wait for free capacity
+	Waiting    int64  `yaml:"Waiting"`    // accumulated waiting time for free capacity in nsecs
+	Pending    int    `yaml:"Pending"`    // number of undispatched events in the write ahead log
+}
+
+//------------------------------------------------------------------------------
+
+// EventQueue buffers the events for the dispatcher. Senders are blocked if the
+// capacity of the queue has been exhausted.
+type EventQueue struct {
+	Channel    chan model.Event // bounded buffer of events
+	log        *eventLog        // optional write ahead log
+	replayed   map[string]bool  // tasks with events restored from the write ahead log
+	replayedX  sync.RWMutex     // mutex for replayed tasks
+	highWater  int64
+	enqueued   uint64
+	dispatched uint64
+	blocked    uint64
+	waiting    int64
+}
+
+//------------------------------------------------------------------------------
+
+var eventQueue *EventQueue // the queue for event notification
+var eventQueueOnce sync.Once
+
+//------------------------------------------------------------------------------
+
+// GetEventQueue initialises and returns the queue for model.Event objects.
+func GetEventQueue() *EventQueue {
+	// initialise singleton once
+	eventQueueOnce.Do(func() {
+		size := 0
+
+		configuration, _ := util.GetConfiguration()
+		if configuration != nil {
+			size = configuration.QUEUE.Size
+		}
+
+		eventQueue = NewEventQueue(size)
+	})
+
+	return eventQueue
+}
+
+//------------------------------------------------------------------------------
+
+// NewEventQueue creates an event queue with a given capacity.
+func NewEventQueue(size int) *EventQueue {
+	if size <= 0 {
+		size = 1000
+	}
+
+	return &EventQueue{
+		Channel:  make(chan model.Event, size),
+		replayed: map[string]bool{},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// OpenLog attaches a write ahead log to the queue and returns the events
+// which had not been dispatched before the log was closed.
+func (queue *EventQueue) OpenLog(path string) ([]model.Event, error) {
+	log, events, err := openEventLog(path)
+	if err != nil {
+		return nil, err
+	}
+
+	queue.log = log
+
+	// remember the tasks which will be resumed
+	queue.replayedX.Lock()
+	for _, event := range events {
+		queue.replayed[event.Task] = true
+	}
+	queue.replayedX.Unlock()
+
+	// success
+	return events, nil
+}
+
+//------------------------------------------------------------------------------
+
+// Replay adds events restored from the write ahead log to the queue.
+func (queue *EventQueue) Replay(events []model.Event) {
+	for _, event := range events {
+		queue.enqueue(event)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// Replayed checks if events of a task have been restored from the write ahead log.
+func (queue *EventQueue) Replayed(task string) bool {
+	queue.replayedX.RLock()
+	defer queue.replayedX.RUnlock()
+
+	return queue.replayed[task]
+}
+
+//------------------------------------------------------------------------------
+
+// Push adds an event to the queue and waits for free capacity if necessary.
+func (queue *EventQueue) Push(event model.Event) {
+	// record the event before it is buffered
+	if queue.log != nil {
+		if err := queue.log.append(event); err != nil {
+			util.LogError(event.Task, "ENG", "unable to write event log:\n" + err.Error())
+		}
+	}
+
+	queue.enqueue(event)
+}
+
+//------------------------------------------------------------------------------
+
+// enqueue buffers an event and records the back-pressure on the sender.
+func (queue *EventQueue) enqueue(event model.Event) {
+	atomic.AddUint64(&queue.enqueued, 1)
+
+	select {
+	case queue.Channel <- event:
+	default:
+		// capacity has been exhausted
+		start := time.Now()
+
+		atomic.AddUint64(&queue.blocked, 1)
+		queue.Channel <- event
+		atomic.AddInt64(&queue.waiting, int64(time.Since(start)))
+	}
+
+	// update high water mark
+	depth := int64(len(queue.Channel))
+	for {
+		highWater := atomic.LoadInt64(&queue.highWater)
+		if depth <= highWater || atomic.CompareAndSwapInt64(&queue.highWater, highWater, depth) {
+			break
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// Dispatched acknowledges that an event has been taken from the queue.
+func (queue *EventQueue) Dispatched(event model.Event) {
+	atomic.AddUint64(&queue.dispatched, 1)
+
+	if queue.log != nil {
+		if err := queue.log.ack(event); err != nil {
+			util.LogError(event.Task, "ENG", "unable to write event log:\n" + err.Error())
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// Metrics provides the current load of the queue.
+func (queue *EventQueue) Metrics() QueueMetrics {
+	metrics := QueueMetrics{
+		Capacity:   cap(queue.Channel),
+		Depth:      len(queue.Channel),
+		HighWater:  int(atomic.LoadInt64(&queue.highWater)),
+		Enqueued:   atomic.LoadUint64(&queue.enqueued),
+		Dispatched: atomic.LoadUint64(&queue.dispatched),
+		Blocked:    atomic.LoadUint64(&queue.blocked),
+		Waiting:    atomic.LoadInt64(&queue.waiting),
+	}
+
+	if queue.log != nil {
+		metrics.Pending = queue.log.size()
+	}
+
+	// success
+	return metrics
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,134 @@
+package engine
+
+import (
+	"errors"
+
+	"tsai.eu/solar/model"
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// Restore fails all tasks of a restored model whose execution has been
+// interrupted by a restart. Tasks with events restored from the write ahead log
+// of the event queue are resumed instead, controller tasks waiting for an
+// asynchronous operation continue to poll the operation.
+func Restore() {
+	// get event queue
+	queue := GetEventQueue()
+
+	// restore the handlers of all known tasks
+	attachAllHandlers()
+
+	interrupted := []*model.Task{}
+
+	for _, task := range listTasks() {
+		// collect tasks which have not been finished
+		if task.GetStatus() == model.TaskStatusInitial || task.GetStatus() == model.TaskStatusExecuting {
+			if queue.Replayed(task.UUID) {
+				continue
+			}
+
+			if task.Type == "Controller" && task.GetStatus() == model.TaskStatusExecuting && task.GetOperation() != "" {
+				util.LogInfo(task.UUID, "ENG", "resuming operation: " + task.Operation)
+				queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskExecution, "", "poll"))
+				continue
+			}
+
+			interrupted = append(interrupted, task)
+		}
+	}
+
+	// fail all interrupted tasks so that the monitor can trigger new tasks
+	for _, task := range interrupted {
+		util.LogInfo(task.UUID, "ENG", "interrupted")
+		queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "interrupted"))
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// attachAllHandlers re-attaches the event handlers to all tasks of the model.
+func attachAllHandlers() {
+	for _, task := range listTasks() {
+		if err := AttachHandlers(task); err != nil {
+			util.LogError(task.UUID, "ENG", err.Error())
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// listTasks collects the tasks of all domains.
+func listTasks() []*model.Task {
+	tasks := []*model.Task{}
+
+	domainNames, _ := model.GetDomains()
+	for _, domainName := range domainNames {
+		domain, err := model.GetDomain(domainName)
+		if err != nil {
+			continue
+		}
+
+		taskNames, _ := domain.ListTasks()
+		for _, taskName := range taskNames {
+			task, err := domain.GetTask(taskName)
+			if err == nil {
+				tasks = append(tasks, task)
+			}
+		}
+	}
+
+	// success
+	return tasks
+}
+
+//------------------------------------------------------------------------------
+
+// AttachHandlers defines the event handlers of a task according to its type.
+func AttachHandlers(task *model.Task) error {
+	switch task.Type {
+	case "Solution":
+		task.SetExecute(ExecuteSolutionTask)
+	case "Element":
+		task.SetExecute(ExecuteElementTask)
+	case "Cluster":
+		task.SetExecute(ExecuteClusterTask)
+	case "Instance":
+		task.SetExecute(ExecuteInstanceTask)
+	case "Relationship":
+		task.SetExecute(ExecuteRelationshipTask)
+	case "Controller":
+		task.SetExecute(ExecuteControllerTask)
+	default:
+		return errors.New("unknown type of task: " + task.Type)
+	}
+
+	task.SetTerminate(TerminateTask)
+	task.SetFailed(FailedTask)
+	task.SetTimeout(TimeoutTask)
+	task.SetCompleted(CompletedTask)
+
+	// instance tasks retry timed out transitions
+	if task.Type == "Instance" {
+		task.SetTimeout(TimeoutInstanceTask)
+	}
+
+	// solution tasks record the outcome of their deployment
+	if task.Type == "Solution" {
+		task.SetTerminate(TerminateSolutionTask)
+		task.SetFailed(FailedSolutionTask)
+		task.SetTimeout(TimeoutSolutionTask)
+		task.SetCompleted(CompletedSolutionTask)
+	}
+
+	// element tasks pause or abort upgrades after a failure
+	if task.Type == "Element" {
+		task.SetFailed(FailedElementTask)
+	}
+
+	// success
+	return nil
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,173 @@
+package engine
+
+import (
+	"sort"
+	"errors"
+
+	"tsai.eu/solar/model"
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// NewRelationshipTask creates a new task binding a relationship of a cluster
+// (state active) or releasing the binding (state initial).
+func NewRelationshipTask(domain string, parent string, solution string, version string, element string, cluster string, relationship string, state string) (model.Task, error) {
+	var task model.Task
+
+	// TODO: check parameters if context exists
+	task.Type         = "Relationship"
+	task.Domain       = domain
+	task.Solution     = solution
+	task.Version      = version
+	task.Element      = element
+	task.Cluster      = cluster
+	task.Instance     = ""
+	task.Relationship = relationship
+	task.State        = state
+	task.Action       = ""
+	task.UUID         = util.UUID()
+	task.Parent       = parent
+	task.SetStatus(model.TaskStatusInitial)
+	task.Phase        = 0
+	task.Subtasks     = []string{}
+
+	// add handlers
+	task.SetExecute(ExecuteRelationshipTask)
+	task.SetTerminate(TerminateTask)
+	task.SetFailed(FailedTask)
+	task.SetTimeout(TimeoutTask)
+	task.SetCompleted(CompletedTask)
+
+	// get domain
+	d, err := model.GetModel().GetDomain(domain)
+	if err != nil {
+		util.LogError(parent, "ENG", "unknown domain")
+		return task, errors.New("unknown domain")
+	}
+
+	// add task to domain
+	err = d.AddTask(&task)
+	if err != nil {
+		util.LogError(parent, "ENG", "unable to add task")
+		return task, err
+	}
+
+	// success
+	return task, nil
+}
+
+//------------------------------------------------------------------------------
+
+// ExecuteRelationshipTask is the main task execution routine. The active
+// instances of the cluster are bound (or unbound) one by one by controller
+// tasks before the state of the relationship is updated.
+func ExecuteRelationshipTask(task *model.Task) {
+	// get event queue
+	queue := GetEventQueue()
+
+	// check and update status
+	status := task.GetStatus()
+
+	if status != model.TaskStatusInitial && status != model.TaskStatusExecuting {
+		return
+	}
+
+	if status == model.TaskStatusInitial {
+		task.SetStatus(model.TaskStatusExecuting)
+	}
+
+	// determine context
+	cluster, err := model.GetCluster(task.Domain, task.Solution, task.Element, task.Cluster)
+	if err != nil {
+		queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "unknown cluster: " + task.Element + " - " + task.Cluster))
+		return
+	}
+
+	relationship, err := cluster.GetRelationship(task.Relationship)
+	if err != nil {
+		queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "unknown relationship: " + task.Element + " - " + task.Cluster + " / " + task.Relationship))
+		return
+	}
+
+	// update target state of relationship
+	relationship.Target = task.State
+
+	// determine the endpoint of the related cluster
+	endpoint := ""
+	action   := "unbind"
+	if task.State == model.ActiveState {
+		refCluster, err := model.GetCluster(relationship.Domain, relationship.Solution, relationship.Element, relationship.Version)
+		if err != nil || refCluster.State != model.ActiveState {
+			queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "related cluster is not active: " + relationship.Element + " - " + relationship.Version))
+			return
+		}
+
+		endpoint = refCluster.GetEndpoint()
+		action   = "bind"
+	}
+
+	// one by one bind the active instances which have not been bound or
+	// handled yet (unbind all active instances)
+	instanceNames, _ := cluster.ListInstances()
+	sort.Strings(instanceNames)
+
+	for _, instanceName := range instanceNames {
+		instance, _ := cluster.GetInstance(instanceName)
+
+		if instance.State != model.ActiveState || handledInstance(task, instanceName) {
+			continue
+		}
+
+		if action == "bind" && instance.IsBound(task.Relationship) {
+			continue
+		}
+
+		// create controller task for the binding
+		subtask, _ := NewBindingTask(task.Domain, task.UUID, task.Solution, task.Version, task.Element, task.Cluster, instanceName, task.Relationship, action)
+		task.AddSubtask(&subtask)
+
+		// trigger the task and wait for its completion
+		queue.Push(model.NewEvent(task.Domain, subtask.UUID, model.EventTypeTaskExecution, task.UUID, ""))
+		return
+	}
+
+	// relationship has reached the desired state
+	relationship.SetState(task.State, endpoint)
+
+	util.LogInfo(task.UUID, "ENG", "Relationship: " + task.Domain + "/" + task.Element + "/" + task.Cluster + "/" + task.Relationship + " has new state:" + relationship.State)
+
+	// execution has completed
+	queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskCompletion, task.UUID, ""))
+}
+
+//------------------------------------------------------------------------------
+
+// handledInstance checks if a subtask of the task has already been created for an instance.
+func handledInstance(task *model.Task, instanceName string) bool {
+	for _, subtaskUUID := range task.Subtasks {
+		subtask, err := task.GetSubtask(subtaskUUID)
+		if err == nil && subtask.Instance == instanceName {
+			return true
+		}
+	}
+
+	return false
+}
+
+//------------------------------------------------------------------------------
+
+// triggerRelationshipTask triggers a task to bind or unbind a relationship of a cluster.
+func triggerRelationshipTask(task *model.Task, element string, cluster string, relationship string, state string) {
+	// get event queue
+	queue := GetEventQueue()
+
+	// create task to update the relationship
+	subtask, _ := NewRelationshipTask(task.Domain, task.UUID, task.Solution, task.Version, element, cluster, relationship, state)
+	task.AddSubtask(&subtask)
+
+	// trigger the task
+	queue.Push(model.NewEvent(task.Domain, subtask.UUID, model.EventTypeTaskExecution, task.UUID, ""))
+}
+
+//------------------------------------------------------------------------------
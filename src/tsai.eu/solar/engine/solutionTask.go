@@ -25,16 +25,16 @@ func NewSolutionTask(domain string, parent string, solution *model.Solution) (mo
 	task.Action       = ""
 	task.UUID         = util.UUID()
 	task.Parent       = parent
-	task.Status       = model.TaskStatusInitial
+	task.SetStatus(model.TaskStatusInitial)
 	task.Phase        = 0
 	task.Subtasks     = []string{}
 
 	// add handlers
 	task.SetExecute(ExecuteSolutionTask)
-	task.SetTerminate(TerminateTask)
-	task.SetFailed(FailedTask)
-	task.SetTimeout(TimeoutTask)
-	task.SetCompleted(CompletedTask)
+	task.SetTerminate(TerminateSolutionTask)
+	task.SetFailed(FailedSolutionTask)
+	task.SetTimeout(TimeoutSolutionTask)
+	task.SetCompleted(CompletedSolutionTask)
 
 	// get domain
 	d, err := model.GetDomain(domain)
@@ -55,8 +55,8 @@ func NewSolutionTask(domain string, parent string, solution *model.Solution) (mo
 
 // ExecuteSolutionTask is the main task execution routine.
 func ExecuteSolutionTask(task *model.Task) {
-	// get event channel
-	channel := GetEventChannel()
+	// get event queue
+	queue := GetEventQueue()
 
 	// check status
 	status := task.GetStatus()
@@ -66,11 +66,16 @@ func ExecuteSolutionTask(task *model.Task) {
 	}
 
 	if status == model.TaskStatusInitial {
-		task.Status = model.TaskStatusExecuting
+		task.SetStatus(model.TaskStatusExecuting)
 	}
 
 	// determine context
-	solution, _ := model.GetSolution(task.Domain, task.Solution)
+	solution, err := model.GetSolution(task.Domain, task.Solution)
+	if err != nil {
+		util.LogError(task.UUID, "ENG", "unknown solution: " + task.Solution)
+		queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskFailure, task.UUID, "unknown solution: " + task.Solution))
+		return
+	}
 
 	// one by one identify the element which may need to be changed
 	elementNames, _ := solution.ListElements()
@@ -84,7 +89,7 @@ func ExecuteSolutionTask(task *model.Task) {
 			task.AddSubtask(&subtask)
 
 			// trigger the task
-			channel <- model.NewEvent(task.Domain, subtask.UUID, model.EventTypeTaskExecution, task.UUID, "")
+			queue.Push(model.NewEvent(task.Domain, subtask.UUID, model.EventTypeTaskExecution, task.UUID, ""))
 
 			// return and wait for next event
 			return
@@ -92,7 +97,49 @@ func ExecuteSolutionTask(task *model.Task) {
 	}
 
 	// execution has completed
-	channel <- model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskCompletion, task.UUID, "")
+	queue.Push(model.NewEvent(task.Domain, task.UUID, model.EventTypeTaskCompletion, task.UUID, ""))
+}
+
+//------------------------------------------------------------------------------
+
+// TerminateSolutionTask handles the termination of the task.
+func TerminateSolutionTask(task *model.Task) {
+	finishSolutionTask(task, TerminateTask)
+}
+
+//------------------------------------------------------------------------------
+
+// FailedSolutionTask handles the failure of the task.
+func FailedSolutionTask(task *model.Task) {
+	finishSolutionTask(task, FailedTask)
+}
+
+//------------------------------------------------------------------------------
+
+// TimeoutSolutionTask handles the timeout of the task.
+func TimeoutSolutionTask(task *model.Task) {
+	finishSolutionTask(task, TimeoutTask)
+}
+
+//------------------------------------------------------------------------------
+
+// CompletedSolutionTask handles the completion of the task.
+func CompletedSolutionTask(task *model.Task) {
+	finishSolutionTask(task, CompletedTask)
+}
+
+//------------------------------------------------------------------------------
+
+// finishSolutionTask records the outcome of the deployment once the status of
+// the task has been changed by a handler.
+func finishSolutionTask(task *model.Task, handler model.TaskHandler) {
+	status := task.GetStatus()
+
+	handler(task)
+
+	if task.GetStatus() != status {
+		recordDeployment(task)
+	}
 }
 
 //------------------------------------------------------------------------------
@@ -15,6 +15,7 @@ import (
 //   - Architecture
 //   - Version
 //   - Configuration
+//   - AutoRollback
 //   - Elements
 //
 // Functions:
@@ -33,11 +34,12 @@ import (
 
 // Architecture describes the design time configuration of a solution within a domain.
 type Architecture struct {
-	Architecture  string                           `yaml:"Architecture"`        // name of architecture
-	Version       string                           `yaml:"Version"`             // type of solution
-	Configuration string                           `yaml:"Configuration"`       // configuration of the architecture
-	Elements      map[string]*ElementConfiguration `yaml:"Elements"`            // element configurations of solution
-	ElementsX     sync.RWMutex                     `yaml:"ElementsX,omitempty"` // mutex for element configurations
+	Architecture  string                           `yaml:"Architecture"`           // name of architecture
+	Version       string                           `yaml:"Version"`                // type of solution
+	Configuration string                           `yaml:"Configuration"`          // configuration of the architecture
+	AutoRollback  bool                             `yaml:"AutoRollback,omitempty"` // roll back to the previous version if the deployment fails
+	Elements      map[string]*ElementConfiguration `yaml:"Elements"`               // element configurations of solution
+	ElementsX     sync.RWMutex                     `yaml:"ElementsX,omitempty"`    // mutex for element configurations
 }
 
 //------------------------------------------------------------------------------
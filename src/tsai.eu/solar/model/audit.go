@@ -0,0 +1,335 @@
+package model
+
+import (
+	"os"
+	"sort"
+	"sync"
+	"time"
+	"bufio"
+	"strings"
+	"encoding/json"
+	"path/filepath"
+
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+// Audit
+// =====
+//
+// The audit log records every modification of the model together with the
+// actor, the source and the difference between the state of the modified
+// entity before and after the modification. Entries are never modified or
+// removed once they have been recorded. If a log file has been configured the
+// entries are only kept in the file and read from it on demand, otherwise the
+// most recent entries are kept in memory.
+//
+// Paths:
+//   - <domain>[/<solution>[/<element>[/<cluster>[/<instance>]]]]
+//   - <domain>/component:<component>/<version>
+//   - <domain>/architecture:<architecture>/<version>
+//   - <domain>/controller:<controller>/<version>
+//   - <domain>/secret:<secret>
+//   - <domain>/task:<uuid>
+//
+// Functions:
+//   - Audit
+//   - GetAudit
+//   - AuditState
+//
+//   - readAuditLog
+//------------------------------------------------------------------------------
+
+// AuditEntry describes a single modification of the model.
+type AuditEntry struct {
+	Time      string `yaml:"Time"           json:"Time"`           // time of the modification
+	Actor     string `yaml:"Actor"          json:"Actor"`          // user or system making the modification
+	Source    string `yaml:"Source"         json:"Source"`         // cli, rest, kafka or monitor
+	Operation string `yaml:"Operation"      json:"Operation"`      // executed operation
+	Path      string `yaml:"Path"           json:"Path"`           // path of the modified entity
+	Diff      string `yaml:"Diff,omitempty" json:"Diff,omitempty"` // difference between the states before and after the modification
+}
+
+//------------------------------------------------------------------------------
+
+// auditLog holds the recent entries or the file they are appended to.
+type auditLog struct {
+	entries []*AuditEntry // recent entries (only if the log is kept in memory)
+	path    string        // path of the log file (empty if the log is kept in memory)
+	encoder *json.Encoder // encoder for the log file
+	mutex   sync.Mutex    // mutex for the log
+}
+
+//------------------------------------------------------------------------------
+
+var theAuditLog *auditLog
+var auditLogInit sync.Once
+
+//------------------------------------------------------------------------------
+
+// getAuditLog opens the log file of the audit log once. The log is kept in
+// memory if no log file has been configured or it can not be opened.
+func getAuditLog() *auditLog {
+	auditLogInit.Do(func() {
+		theAuditLog = &auditLog{entries: []*AuditEntry{}}
+
+		configuration, _ := util.GetConfiguration()
+		if configuration == nil || configuration.AUDIT.Path == "" {
+			return
+		}
+		path := configuration.AUDIT.Path
+
+		// open the log file for appending
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			util.LogError("audit", "CORE", "unable to create directory of audit log:\n" + err.Error())
+			return
+		}
+
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			util.LogError("audit", "CORE", "unable to open audit log:\n" + err.Error())
+			return
+		}
+		theAuditLog.path    = path
+		theAuditLog.encoder = json.NewEncoder(file)
+	})
+
+	return theAuditLog
+}
+
+//------------------------------------------------------------------------------
+
+// Audit records a modification of the entity identified by path. The states
+// before and after the modification are compared line by line.
+func Audit(actor string, source string, operation string, path string, before string, after string) {
+	entry := AuditEntry{
+		Time:      time.Now().UTC().Format(time.RFC3339),
+		Actor:     actor,
+		Source:    source,
+		Operation: operation,
+		Path:      path,
+		Diff:      util.Redact(util.Diff(before, after)),
+	}
+
+	log := getAuditLog()
+
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+
+	if log.encoder != nil {
+		if err := log.encoder.Encode(entry); err != nil {
+			util.LogError("audit", "CORE", "unable to write audit log:\n" + err.Error())
+		}
+		return
+	}
+
+	// keep the most recent entries in memory
+	log.entries = append(log.entries, &entry)
+	if len(log.entries) > MaxAuditEntries {
+		log.entries = log.entries[len(log.entries) - MaxAuditEntries:]
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// GetAudit retrieves the entries of the audit log concerning a domain in the
+// order in which they have been recorded. Modifications of the complete model
+// concern all domains. The first offset entries are skipped and at most limit
+// entries are delivered (all if limit is 0).
+func GetAudit(domainName string, offset int, limit int) []*AuditEntry {
+	result := []*AuditEntry{}
+	index  := 0
+
+	collect := func(entry *AuditEntry) bool {
+		if entry.Path == "" || entry.Path == domainName || strings.HasPrefix(entry.Path, domainName + "/") {
+			if index >= offset {
+				result = append(result, entry)
+			}
+			index++
+		}
+		return limit <= 0 || len(result) < limit
+	}
+
+	log := getAuditLog()
+
+	// read the entries from the log file
+	if log.path != "" {
+		if err := readAuditLog(log.path, collect); err != nil {
+			util.LogError("audit", "CORE", "unable to read audit log:\n" + err.Error())
+		}
+		return result
+	}
+
+	// entries kept in memory
+	log.mutex.Lock()
+	entries := log.entries
+	log.mutex.Unlock()
+
+	for _, entry := range entries {
+		if !collect(entry) {
+			break
+		}
+	}
+
+	// success
+	return result
+}
+
+//------------------------------------------------------------------------------
+
+// readAuditLog passes the entries of a log file to collect until it returns
+// false. Entries are written with a single write, an incomplete entry written
+// concurrently or during a crash is ignored.
+func readAuditLog(path string, collect func(entry *AuditEntry) bool) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry AuditEntry
+
+		if json.Unmarshal(scanner.Bytes(), &entry) != nil {
+			continue
+		}
+		if !collect(&entry) {
+			break
+		}
+	}
+
+	return scanner.Err()
+}
+
+//------------------------------------------------------------------------------
+
+// domainOverview summarises the artefacts of a domain.
+type domainOverview struct {
+	Components    []string `yaml:"Components"`    // names and versions of the components
+	Architectures []string `yaml:"Architectures"` // names and versions of the architectures
+	Solutions     []string `yaml:"Solutions"`     // names of the solutions
+	Controllers   []string `yaml:"Controllers"`   // names and versions of the controllers
+	Secrets       []string `yaml:"Secrets"`       // names of the secrets
+}
+
+//------------------------------------------------------------------------------
+
+// AuditState determines the state of the entity identified by path which is
+// recorded in the audit log. The values of secrets are never included.
+func AuditState(path string) string {
+	var entity interface{ Show() (string, error) }
+
+	// the complete model
+	if path == "" {
+		domains, _ := GetDomains()
+		sort.Strings(domains)
+		return showAuditState(domains)
+	}
+
+	names := strings.Split(path, "/")
+
+	domain, err := GetDomain(names[0])
+	if err != nil {
+		return ""
+	}
+
+	// the domain itself
+	if len(names) == 1 {
+		return showAuditState(domain.overview())
+	}
+
+	// artefacts of the domain
+	if kind := strings.SplitN(names[1], ":", 2); len(kind) == 2 {
+		name, version := kind[1], ""
+		if len(names) > 2 {
+			version = names[2]
+		}
+
+		switch kind[0] {
+		case "component":
+			entity, err = domain.GetComponent(name, version)
+		case "architecture":
+			entity, err = domain.GetArchitecture(name, version)
+		case "controller":
+			entity, err = domain.GetController(name, version)
+		case "task":
+			entity, err = domain.GetTask(name)
+		case "secret":
+			secrets, _ := domain.ListSecrets()
+			for _, secret := range secrets {
+				if secret == name {
+					return "Secret: " + name + "\n"
+				}
+			}
+			return ""
+		default:
+			return ""
+		}
+	} else {
+		// entities of a solution
+		switch len(names) {
+		case 2:
+			entity, err = domain.GetSolution(names[1])
+		case 3:
+			entity, err = GetElement(names[0], names[1], names[2])
+		case 4:
+			entity, err = GetCluster(names[0], names[1], names[2], names[3])
+		default:
+			entity, err = GetInstance(names[0], names[1], names[2], names[3], names[4])
+		}
+	}
+
+	if err != nil {
+		return ""
+	}
+
+	state, _ := entity.Show()
+
+	// success
+	return state
+}
+
+//------------------------------------------------------------------------------
+
+// showAuditState converts an entity to yaml.
+func showAuditState(entity interface{}) string {
+	state, _ := util.ConvertToYAML(entity)
+	return state
+}
+
+//------------------------------------------------------------------------------
+
+// overview summarises the artefacts of a domain.
+func (domain *Domain) overview() *domainOverview {
+	overview := domainOverview{}
+
+	components, _ := domain.ListComponents()
+	for _, component := range components {
+		overview.Components = append(overview.Components, component[0] + " " + component[1])
+	}
+
+	architectures, _ := domain.ListArchitectures()
+	for _, architecture := range architectures {
+		overview.Architectures = append(overview.Architectures, architecture[0] + " " + architecture[1])
+	}
+
+	controllers, _ := domain.ListControllers()
+	for _, controller := range controllers {
+		overview.Controllers = append(overview.Controllers, controller[0] + " " + controller[1])
+	}
+
+	overview.Solutions, _ = domain.ListSolutions()
+	overview.Secrets, _   = domain.ListSecrets()
+
+	sort.Strings(overview.Components)
+	sort.Strings(overview.Architectures)
+	sort.Strings(overview.Controllers)
+	sort.Strings(overview.Solutions)
+
+	// success
+	return &overview
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"strings"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+// TestAudit01 tests the recording and retrieval of audit entries.
+func TestAudit01(t *testing.T) {
+	model := GetModel()
+
+	model.Load("testdata/testdata1.yaml")
+
+	domain, _ := GetDomain("demo")
+
+	// states of entities
+	if state := AuditState("demo/app/ext/V1.0.0"); !strings.Contains(state, "Version: V1.0.0") {
+		t.Errorf("AuditState should have returned the state of the cluster:\n%s", state)
+	}
+
+	if state := AuditState("demo/unknown"); state != "" {
+		t.Errorf("AuditState should have returned an empty state for an unknown solution:\n%s", state)
+	}
+
+	// secrets
+	path   := "demo/secret:token"
+	before := AuditState(path)
+
+	domain.SetSecret("token", "TestAudit01-value")
+
+	after := AuditState(path)
+	if strings.Contains(after, "TestAudit01-value") {
+		t.Errorf("AuditState should not have revealed the value of the secret")
+	}
+
+	Audit("tester", AuditSourceCLI, "secret set", path, before, after)
+	Audit("tester", AuditSourceCLI, "secret set", "other/secret:token", "", "Secret: token\n")
+
+	entries := GetAudit("demo", 0, 0)
+	if len(entries) == 0 {
+		t.Fatalf("GetAudit should have returned the entry")
+	}
+
+	entry := entries[len(entries)-1]
+	if entry.Actor != "tester" || entry.Source != AuditSourceCLI || entry.Path != path || entry.Diff != "+ Secret: token\n" {
+		t.Errorf("GetAudit should have returned the recorded entry:\n%v", entry)
+	}
+
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Path, "other") {
+			t.Errorf("GetAudit should only have returned the entries of the domain")
+		}
+	}
+
+	// pages of the audit log
+	page := GetAudit("demo", len(entries) - 1, 1)
+	if len(page) != 1 || page[0].Path != path {
+		t.Errorf("GetAudit should have returned the last entry:\n%v", page)
+	}
+
+	if page = GetAudit("demo", len(entries), 0); len(page) != 0 {
+		t.Errorf("GetAudit should not have returned entries beyond the log")
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// TestAudit02 tests reading the entries from the log file.
+func TestAudit02(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	log := "{\"Path\":\"demo\"}\n{\"Path\":\"demo/app\"}\n{\"Path\":\"other\"}\n{\"Path\":"
+	if err := ioutil.WriteFile(path, []byte(log), 0644); err != nil {
+		t.Fatalf("unable to write audit log:\n%s", err)
+	}
+
+	paths := []string{}
+	err   := readAuditLog(path, func(entry *AuditEntry) bool {
+		paths = append(paths, entry.Path)
+		return true
+	})
+	if err != nil || strings.Join(paths, " ") != "demo demo/app other" {
+		t.Errorf("readAuditLog should have skipped the incomplete entry: %v", paths)
+	}
+
+	paths = []string{}
+	readAuditLog(path, func(entry *AuditEntry) bool {
+		paths = append(paths, entry.Path)
+		return len(paths) < 2
+	})
+	if strings.Join(paths, " ") != "demo demo/app" {
+		t.Errorf("readAuditLog should have stopped after two entries: %v", paths)
+	}
+
+	if err = readAuditLog(path + ".unknown", func(entry *AuditEntry) bool { return true }); err == nil {
+		t.Errorf("readAuditLog should have complained about a missing log file")
+	}
+}
+
+//------------------------------------------------------------------------------
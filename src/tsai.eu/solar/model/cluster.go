@@ -3,9 +3,10 @@ package model
 import (
 	"sync"
 	"errors"
-	"regexp"
-	"strings"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"tsai.eu/solar/util"
 )
@@ -23,6 +24,10 @@ import (
 //   - Size
 //   - Configuration
 //   - Endpoint
+//   - Policy
+//   - Scaling
+//   - Scaled
+//   - Metrics
 //   - Relationships
 //   - Instances
 //
@@ -37,7 +42,13 @@ import (
 //   - cluster.Reset
 //   - cluster.OK
 //   - cluster.Pools
+//   - cluster.Step
+//   - cluster.Outdated
 //   - cluster.SetState
+//   - cluster.GetEndpoint
+//
+//   - cluster.GetMetric
+//   - cluster.SetMetric
 //
 //   - cluster.ListRelationships
 //   - cluster.GetRelationship
@@ -60,6 +71,11 @@ type Cluster struct {
 	Size           int                      `yaml:"Size"`                     // size of the solution element cluster
 	Configuration  string                   `yaml:"Configuration"`            // runtime configuration of the solution element cluster
 	Endpoint       string                   `yaml:"Endpoint"`                 // endpoint of the solution element cluster
+	Policy         Policy                   `yaml:"Policy,omitempty"`         // overrides of the policy of the component
+	Scaling        *Scaling                 `yaml:"Scaling,omitempty"`        // scaling policy of the solution element cluster
+	Scaled         int64                    `yaml:"Scaled,omitempty"`         // time of the latest scaling decision since 1.1.1970 in nsecs
+	Metrics        map[string]Measurement   `yaml:"Metrics,omitempty"`        // latest reported metrics of the solution element cluster
+	MetricsX       sync.RWMutex             `yaml:"MetricsX,omitempty"`       // mutex for metrics
 	Relationships  map[string]*Relationship `yaml:"Relationships"`            // relationships of the solution element cluster
 	RelationshipsX sync.RWMutex             `yaml:"RelationshipsX,omitempty"` // mutex for relationships
 	Instances      map[string]*Instance     `yaml:"Instances"`                // instances of the solution element cluster
@@ -84,6 +100,8 @@ func NewCluster(version string, state string, min int, max int, size int, config
 	cluster.RelationshipsX = sync.RWMutex{}
 	cluster.Instances      = map[string]*Instance{}
 	cluster.InstancesX     = sync.RWMutex{}
+	cluster.Metrics        = map[string]Measurement{}
+	cluster.MetricsX       = sync.RWMutex{}
 
 	// success
 	return &cluster, nil
@@ -267,12 +285,12 @@ func (cluster *Cluster) DeleteInstance(uuid string) error {
 //------------------------------------------------------------------------------
 
 // renderConfiguration calculates the configuration from the component template and the parameters defined in the clusterConfiguration.
-func (cluster *Cluster) renderConfiguration(domainName string, solutionName string, version string, element *Element, clusterConfiguration *ClusterConfiguration) {
+func (cluster *Cluster) renderConfiguration(domainName string, solutionName string, version string, element *Element, clusterConfiguration *ClusterConfiguration) error {
 	// determine component
 	component, err := GetComponent(domainName, element.Component, clusterConfiguration.Version)
 	if err != nil {
 		util.LogError("element", "MODEL", "unknown component '" + element.Component + " - " + clusterConfiguration.Version + "' within domain: '" + domainName + "'")
-		return
+		return nil
 	}
 
 	// get parameters
@@ -296,24 +314,19 @@ func (cluster *Cluster) renderConfiguration(domainName string, solutionName stri
 	parameters["max"]       = strconv.Itoa(cluster.Max)
 	parameters["size"]      = strconv.Itoa(cluster.Size)
 
-	// determine all required parameters
-	configuration := component.Configuration
-	r := regexp.MustCompile(`{{([^}]*)}}`)
-	matches := r.FindAllStringSubmatch(configuration, -1)
-	if matches != nil {
-		for _, match := range matches {
-			name := match[1]
-			key  := strings.TrimSpace(name)
-
-			value, ok := parameters[key]
-			if ok {
-				configuration = strings.Replace(configuration, "{{" + name + "}}", value, -1)
-			}
-		}
+	// render the template of the component
+	context := newTemplateContext(domainName, solutionName, version, cluster)
+
+	configuration, err := RenderTemplate(component.Configuration, component.Parameters, parameters, context)
+	if err != nil {
+		return errors.New("invalid parameters of the cluster: '" + element.Element + " - " + cluster.Version + "'\n" + err.Error())
 	}
 
 	// set conifguration of cluster
 	cluster.Configuration = configuration
+
+	// success
+	return nil
 }
 
 //------------------------------------------------------------------------------
@@ -328,8 +341,15 @@ func (cluster *Cluster) Update(domainName string, solutionName string, version s
 	// update target state and sizes
 	cluster.Target = clusterConfiguration.State
 
+	// update policies
+	cluster.Policy  = clusterConfiguration.Policy
+	cluster.Scaling = clusterConfiguration.Scaling
+
 	// update configuration
-	cluster.renderConfiguration(domainName, solutionName, version, element, clusterConfiguration)
+	if err := cluster.renderConfiguration(domainName, solutionName, version, element, clusterConfiguration); err != nil {
+		util.LogError("cluster", "MODEL", err.Error())
+		return err
+	}
 
 	// check compatability of all relationships
 	relationshipNames, _ := clusterConfiguration.ListRelationships()
@@ -395,6 +415,8 @@ func (cluster *Cluster) Resize(min int, max int, size int) {
 		currentSize = currentSize + 1
 	}
 
+	// persist modification
+	Persist()
 }
 
 //------------------------------------------------------------------------------
@@ -438,6 +460,11 @@ func (cluster *Cluster) OK() bool {
 		return false
 	}
 
+	// check if the configuration of any instance needs to be changed
+	if len(cluster.Outdated()) > 0 {
+		return false
+	}
+
 	// check categories
 	switch cluster.Target {
 		case InitialState:
@@ -537,11 +564,157 @@ func (cluster *Cluster) Pools() (initial int, inactive int, active int, failure
 
 //------------------------------------------------------------------------------
 
+// Step determines the next instance which needs to change its state in order
+// to converge the cluster to its target state. The instances are considered
+// one by one in the order of their names.
+func (cluster *Cluster) Step() (instanceName string, state string, found bool) {
+	_, inactive, active, _, _ := cluster.Pools()
+
+	instanceNames, _ := cluster.ListInstances()
+	sort.Strings(instanceNames)
+
+	for _, instanceName := range instanceNames {
+		instance, _ := cluster.GetInstance(instanceName)
+
+		switch cluster.Target {
+		case InitialState:
+			// reset all instances
+			if instance.State != InitialState {
+				return instanceName, InitialState, true
+			}
+		case InactiveState:
+			// cleanup failed instances
+			if instance.State == FailureState {
+				return instanceName, InitialState, true
+			}
+
+			// deactivate active instances
+			if instance.State == ActiveState {
+				return instanceName, InactiveState, true
+			}
+
+			// ensure that the number of inactive nodes matches the cluster size
+			if inactive < cluster.Size {
+				if instance.State != InactiveState {
+					return instanceName, InactiveState, true
+				}
+			} else if active > cluster.Size {
+				if instance.State == InactiveState {
+					return instanceName, InitialState, true
+				}
+			}
+		case ActiveState:
+			// cleanup failed instances
+			if instance.State == FailureState {
+				return instanceName, InitialState, true
+			}
+
+			// ensure that the number of active nodes matches the cluster size
+			if active < cluster.Size {
+				if instance.State != ActiveState {
+					return instanceName, ActiveState, true
+				}
+			} else if active == cluster.Size {
+				// remove excess inactive instances
+				if inactive > (cluster.Max - cluster.Size) && instance.State == InactiveState {
+					return instanceName, InitialState, true
+				}
+			} else if active > cluster.Size {
+				// deactivate excess active instances
+				if instance.State == ActiveState {
+					return instanceName, InactiveState, true
+				}
+			}
+		}
+	}
+
+	// cluster has converged
+	return "", "", false
+}
+
+//------------------------------------------------------------------------------
+
+// Outdated lists the names of the inactive and active instances in the order
+// of their names whose configuration differs from the rendered configuration
+// of the cluster.
+func (cluster *Cluster) Outdated() []string {
+	outdated := []string{}
+
+	instanceNames, _ := cluster.ListInstances()
+	sort.Strings(instanceNames)
+
+	for _, instanceName := range instanceNames {
+		instance, _ := cluster.GetInstance(instanceName)
+
+		if instance.Outdated(cluster.Configuration) {
+			outdated = append(outdated, instanceName)
+		}
+	}
+
+	return outdated
+}
+
+//------------------------------------------------------------------------------
+
 // SetState updates the current state of the cluster
 func (cluster *Cluster) SetState(newState string)  {
 	if newState == InitialState || newState == InactiveState || newState == ActiveState || newState == FailureState {
 		cluster.State = newState
+
+		// persist modification
+		Persist()
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// GetEndpoint determines the endpoint offered by the cluster to related
+// clusters. Without an endpoint of its own the endpoints of the active
+// instances are combined as a sequence of yaml documents.
+func (cluster *Cluster) GetEndpoint() string {
+	if cluster.Endpoint != "" {
+		return cluster.Endpoint
+	}
+
+	endpoints := []string{}
+
+	instanceNames, _ := cluster.ListInstances()
+	sort.Strings(instanceNames)
+
+	for _, instanceName := range instanceNames {
+		instance, _ := cluster.GetInstance(instanceName)
+
+		if instance.State == ActiveState && instance.Endpoint != "" {
+			endpoints = append(endpoints, strings.TrimSuffix(instance.Endpoint, "\n"))
+		}
+	}
+
+	return strings.Join(endpoints, "\n---\n")
+}
+
+//------------------------------------------------------------------------------
+
+// GetMetric retrieves the latest reported value of a metric and the time of its report.
+func (cluster *Cluster) GetMetric(name string) (Measurement, bool) {
+	cluster.MetricsX.RLock()
+	measurement, ok := cluster.Metrics[name]
+	cluster.MetricsX.RUnlock()
+
+	return measurement, ok
+}
+
+//------------------------------------------------------------------------------
+
+// SetMetric records the latest reported value of a metric together with the
+// time of the report. Metrics are runtime information and do not trigger
+// persisting the model.
+func (cluster *Cluster) SetMetric(name string, value float64) {
+	cluster.MetricsX.Lock()
+	if cluster.Metrics == nil {
+		cluster.Metrics = map[string]Measurement{}
 	}
+	cluster.Metrics[name] = Measurement{Value: value, Reported: time.Now().UnixNano()}
+	cluster.MetricsX.Unlock()
 }
 
 //------------------------------------------------------------------------------
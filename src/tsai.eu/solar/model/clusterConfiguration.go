@@ -16,6 +16,8 @@ import (
 //   - State
 //   - Size
 //   - Configuration
+//   - Policy
+//   - Scaling
 //   - Relationships
 //
 // Functions:
@@ -39,6 +41,8 @@ type ClusterConfiguration struct {
 	Max            int                                   `yaml:"Max"`                      // max. size of the solution element cluster
 	Size           int                                   `yaml:"Size"`                     // size of the solution element cluster
 	Configuration  string                                `yaml:"Configuration"`            // runtime configuration of the solution element cluster
+	Policy         Policy                                `yaml:"Policy,omitempty"`         // overrides of the policy of the component
+	Scaling        *Scaling                              `yaml:"Scaling,omitempty"`        // scaling policy of the solution element cluster
 	Relationships  map[string]*RelationshipConfiguration `yaml:"Relationships"`            // relationships of the solution element cluster
 	RelationshipsX sync.RWMutex                          `yaml:"RelationshipsX,omitempty"` // mutex for relationships
 
@@ -3,6 +3,8 @@ package model
 import (
 	"testing"
 	"os"
+
+	"tsai.eu/solar/util"
 )
 
 //------------------------------------------------------------------------------
@@ -133,3 +135,78 @@ func TestCluster04(t *testing.T) {
 }
 
 //------------------------------------------------------------------------------
+
+// TestCluster05 tests the determination of the endpoint of a cluster.
+func TestCluster05(t *testing.T) {
+	cluster, _ := NewCluster("V1.0.0", ActiveState, 1, 3, 2, "")
+
+	first, _  := NewInstance("a", ActiveState, "")
+	second, _ := NewInstance("b", ActiveState, "")
+	third, _  := NewInstance("c", ActiveState, "")
+
+	first.State, first.Endpoint   = ActiveState, "Host: a\n"
+	second.State, second.Endpoint = ActiveState, "Host: b"
+	third.State, third.Endpoint   = InactiveState, "Host: c"
+
+	cluster.AddInstance(first)
+	cluster.AddInstance(second)
+	cluster.AddInstance(third)
+
+	if endpoint := cluster.GetEndpoint(); endpoint != "Host: a\n---\nHost: b" {
+		t.Errorf("<cluster>.GetEndpoint should have combined the endpoints of the active instances:\n%s", endpoint)
+	}
+
+	cluster.Endpoint = "Host: lb"
+	if endpoint := cluster.GetEndpoint(); endpoint != "Host: lb" {
+		t.Errorf("<cluster>.GetEndpoint should have delivered the endpoint of the cluster:\n%s", endpoint)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// TestCluster06 tests the detection of changed configurations of a cluster.
+func TestCluster06(t *testing.T) {
+	cluster, _ := NewCluster("V1.0.0", ActiveState, 1, 3, 2, "Port: 80\nPassword: {{secret:password}}")
+
+	first, _  := NewInstance("a", ActiveState, "Port: 80\nPassword: " + util.Redacted)
+	second, _ := NewInstance("b", ActiveState, "Port: 8080\nPassword: " + util.Redacted)
+	third, _  := NewInstance("c", ActiveState, "")
+
+	first.State, second.State, third.State = ActiveState, ActiveState, ActiveState
+
+	cluster.AddInstance(first)
+	cluster.AddInstance(second)
+	cluster.AddInstance(third)
+
+	if outdated := cluster.Outdated(); len(outdated) != 1 || outdated[0] != "b" {
+		t.Errorf("<cluster>.Outdated should have reported the instance with the changed configuration: %v", outdated)
+	}
+
+	cluster.State, cluster.Size = ActiveState, 3
+	if cluster.OK() {
+		t.Errorf("<cluster>.OK should have reported the changed configuration")
+	}
+
+	second.Configuration = first.Configuration
+	if !cluster.OK() {
+		t.Errorf("<cluster>.OK should have reported the converged cluster")
+	}
+
+	if transition, _ := GetReconfiguration(ActiveState, false); transition != "reconfigure" {
+		t.Errorf("GetReconfiguration should have reconfigured the active instance: %s", transition)
+	}
+
+	if transition, _ := GetReconfiguration(InactiveState, false); transition != "configure" {
+		t.Errorf("GetReconfiguration should have configured the inactive instance: %s", transition)
+	}
+
+	if transition, _ := GetReconfiguration(ActiveState, true); transition != "replace" {
+		t.Errorf("GetReconfiguration should have replaced the instance: %s", transition)
+	}
+
+	if _, err := GetReconfiguration(FailureState, false); err == nil {
+		t.Errorf("GetReconfiguration should have complained about the failed instance")
+	}
+}
+
+//------------------------------------------------------------------------------
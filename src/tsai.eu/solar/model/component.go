@@ -16,6 +16,11 @@ import (
 //   - Version
 //   - Configuration
 //   - Controller
+//   - Policy
+//   - Health
+//   - Replace
+//   - Drift
+//   - Parameters
 //   - Dependencies
 //
 // Functions:
@@ -25,6 +30,7 @@ import (
 //   - component.Load
 //   - component.Load2
 //   - component.Save
+//   - component.Validate
 //
 //   - component.ListDependencies
 //   - component.GetDependency
@@ -38,6 +44,11 @@ type Component struct {
 	Version       string                 `yaml:"Version"`               // version of the component
 	Configuration string                 `yaml:"Configuration"`         // base configuration of the component
 	Controller    string                 `yaml:"Controller"`            // name and version of controller
+	Policy        Policy                 `yaml:"Policy,omitempty"`      // timeout and retry policy of controller operations
+	Health        Health                 `yaml:"Health,omitempty"`      // health checks of the active instances
+	Replace       bool                   `yaml:"Replace,omitempty"`     // changes of the configuration require the replacement of the instances
+	Drift         DriftDetection         `yaml:"Drift,omitempty"`       // detection of configuration drift of the active instances
+	Parameters    []*Parameter           `yaml:"Parameters,omitempty"`  // parameters of the base configuration
 	Dependencies  map[string]*Dependency `yaml:"Dependencies"`          // dependencies of component
 	DependenciesX sync.RWMutex           `yaml:"ComponentsX,omitempty"` // mutex for dependencies
 }
@@ -89,6 +100,39 @@ func (component *Component) Load2(yaml string) error {
 
 //------------------------------------------------------------------------------
 
+// Validate checks the parameters, policy, health checks and drift detection of the component and its dependencies.
+func (component *Component) Validate() error {
+	if err := component.Policy.Validate(); err != nil {
+		return errors.New("invalid policy of component:\n" + err.Error())
+	}
+
+	if err := component.Health.Validate(); err != nil {
+		return errors.New("invalid health check of component:\n" + err.Error())
+	}
+
+	if err := component.Drift.Validate(); err != nil {
+		return errors.New("invalid drift detection of component:\n" + err.Error())
+	}
+
+	if err := ValidateParameters(component.Parameters); err != nil {
+		return errors.New("invalid parameters of component:\n" + err.Error())
+	}
+
+	component.DependenciesX.RLock()
+	defer component.DependenciesX.RUnlock()
+
+	for name, dependency := range component.Dependencies {
+		if err := ValidateParameters(dependency.Parameters); err != nil {
+			return errors.New("invalid parameters of dependency '" + name + "':\n" + err.Error())
+		}
+	}
+
+	// success
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
 // ListDependencies lists all dependencies of a template
 func (component *Component) ListDependencies() ([]string, error) {
 	// collect names
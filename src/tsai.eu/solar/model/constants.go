@@ -73,7 +73,81 @@ const EventTypeTaskFailure string = "failure"
 const EventTypeTaskTimeout string = "timeout"
 // EventTypeTaskTermination resembles an event which should trigger termination handling of a task.
 const EventTypeTaskTermination string = "termination"
+// EventTypeTaskProgress resembles an event which records the progress of a task.
+const EventTypeTaskProgress string = "progress"
 // EventTypeTaskUnknown resembles an unknown event.
 const EventTypeTaskUnknown string = "unknown"
 
 //------------------------------------------------------------------------------
+
+// RollingStrategy replaces the instances of a cluster in batches of a fixed size.
+const RollingStrategy string = "rolling"
+
+// BlueGreenStrategy activates all instances of a new cluster before the old cluster is drained.
+const BlueGreenStrategy string = "blue-green"
+
+// CanaryStrategy replaces a percentage of the instances first and the remaining instances in batches.
+const CanaryStrategy string = "canary"
+
+//------------------------------------------------------------------------------
+
+// UpgradeRunning resembles an upgrade which is being executed
+const UpgradeRunning string = "running"
+
+// UpgradePaused resembles an upgrade which has been paused after a failure
+const UpgradePaused string = "paused"
+
+// UpgradeAborted resembles an upgrade which has been rolled back after a failure
+const UpgradeAborted string = "aborted"
+
+// UpgradeCompleted resembles an upgrade which has been completed
+const UpgradeCompleted string = "completed"
+
+//------------------------------------------------------------------------------
+
+// ParameterString resembles a parameter with an arbitrary string value
+const ParameterString string = "string"
+
+// ParameterInteger resembles a parameter with an integer value
+const ParameterInteger string = "integer"
+
+// ParameterNumber resembles a parameter with a floating point value
+const ParameterNumber string = "number"
+
+// ParameterBoolean resembles a parameter with a boolean value
+const ParameterBoolean string = "boolean"
+
+//------------------------------------------------------------------------------
+
+// AuditSourceCLI resembles a modification made through the command line interface
+const AuditSourceCLI string = "cli"
+
+// AuditSourceREST resembles a modification made through the REST API
+const AuditSourceREST string = "rest"
+
+// AuditSourceKafka resembles a modification received from the message bus
+const AuditSourceKafka string = "kafka"
+
+// MaxAuditEntries is the number of recent entries kept if the audit log is not written to a file
+const MaxAuditEntries int = 1000
+
+//------------------------------------------------------------------------------
+
+// NotificationEvent resembles a notification about an event processed by the dispatcher
+const NotificationEvent string = "Event"
+
+// NotificationElement resembles a notification about a state change of an element
+const NotificationElement string = "Element"
+
+// NotificationCluster resembles a notification about a state change of a cluster
+const NotificationCluster string = "Cluster"
+
+// NotificationInstance resembles a notification about a state change of an instance
+const NotificationInstance string = "Instance"
+
+//------------------------------------------------------------------------------
+
+// AuditSourceMonitor resembles a modification made by the monitoring loop
+const AuditSourceMonitor string = "monitor"
+
+//------------------------------------------------------------------------------
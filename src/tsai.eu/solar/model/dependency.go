@@ -14,6 +14,7 @@ import (
 //   - Component
 //   - Version
 //   - Configuration
+//   - Parameters
 //
 // Functions:
 //   - NewDependency
@@ -25,11 +26,12 @@ import (
 
 // Dependency describes what kind of dependency a component within a domain may have.
 type Dependency struct {
-	Dependency    string `yaml:"Dependency"`    // name of the dependency
-	Type          string `yaml:"Type"`          // type of dependency (service/context)
-	Component     string `yaml:"Component"`     // component to which the dependency refers to
-	Version       string `yaml:"Version"`       // version of the component to which the dependency refers to
-	Configuration string `yaml:"Configuration"` // base configuration of the dependency
+	Dependency    string       `yaml:"Dependency"`           // name of the dependency
+	Type          string       `yaml:"Type"`                 // type of dependency (service/context)
+	Component     string       `yaml:"Component"`            // component to which the dependency refers to
+	Version       string       `yaml:"Version"`              // version of the component to which the dependency refers to
+	Configuration string       `yaml:"Configuration"`        // base configuration of the dependency
+	Parameters    []*Parameter `yaml:"Parameters,omitempty"` // parameters of the base configuration
 }
 
 //------------------------------------------------------------------------------
@@ -0,0 +1,119 @@
+package model
+
+import (
+	"time"
+	"errors"
+)
+
+//------------------------------------------------------------------------------
+// Deployment
+// ==========
+//
+// Attributes:
+//   - Architecture
+//   - Version
+//   - Time
+//   - Task
+//   - Outcome
+//   - Rollback
+//
+// Functions:
+//   - solution.AddDeployment
+//   - solution.SetOutcome
+//   - solution.GetDeployment
+//   - solution.GetRollback
+//------------------------------------------------------------------------------
+
+// MaxDeployments defines the number of deployments kept in the history of a solution.
+const MaxDeployments int = 50
+
+//------------------------------------------------------------------------------
+
+// Deployment records a deployment of an architecture to a solution.
+type Deployment struct {
+	Architecture string `yaml:"Architecture"`       // name of the deployed architecture
+	Version      string `yaml:"Version"`            // version of the deployed architecture
+	Time         int64  `yaml:"Time"`               // time of the deployment since 1.1.1970 in nsecs
+	Task         string `yaml:"Task"`               // uuid of the solution task
+	Outcome      string `yaml:"Outcome"`            // status of the solution task
+	Rollback     bool   `yaml:"Rollback,omitempty"` // deployment has rolled back a failed deployment
+}
+
+//------------------------------------------------------------------------------
+
+// AddDeployment records a new deployment in the history of the solution.
+func (solution *Solution) AddDeployment(architecture string, version string, task string, rollback bool) {
+	deployment := Deployment{
+		Architecture: architecture,
+		Version:      version,
+		Time:         time.Now().UnixNano(),
+		Task:         task,
+		Outcome:      TaskStatusInitial,
+		Rollback:     rollback,
+	}
+
+	solution.HistoryX.Lock()
+	solution.History = append(solution.History, &deployment)
+	if len(solution.History) > MaxDeployments {
+		solution.History = solution.History[len(solution.History) - MaxDeployments:]
+	}
+	solution.HistoryX.Unlock()
+
+	// persist modification
+	Persist()
+}
+
+//------------------------------------------------------------------------------
+
+// SetOutcome updates the outcome of the deployment executed by a task.
+func (solution *Solution) SetOutcome(task string, outcome string) error {
+	solution.HistoryX.Lock()
+	defer solution.HistoryX.Unlock()
+
+	for _, deployment := range solution.History {
+		if deployment.Task == task {
+			deployment.Outcome = outcome
+
+			// persist modification
+			Persist()
+
+			return nil
+		}
+	}
+
+	return errors.New("deployment not found")
+}
+
+//------------------------------------------------------------------------------
+
+// GetDeployment retrieves the deployment executed by a task.
+func (solution *Solution) GetDeployment(task string) (*Deployment, error) {
+	solution.HistoryX.RLock()
+	defer solution.HistoryX.RUnlock()
+
+	for _, deployment := range solution.History {
+		if deployment.Task == task {
+			return deployment, nil
+		}
+	}
+
+	return nil, errors.New("deployment not found")
+}
+
+//------------------------------------------------------------------------------
+
+// GetRollback determines the last successful deployment before the latest deployment.
+func (solution *Solution) GetRollback() (*Deployment, error) {
+	solution.HistoryX.RLock()
+	defer solution.HistoryX.RUnlock()
+
+	for index := len(solution.History) - 2; index >= 0; index-- {
+		if solution.History[index].Outcome == TaskStatusCompleted {
+			return solution.History[index], nil
+		}
+	}
+
+	return nil, errors.New("no previous successful deployment")
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+// TestDeployment01 tests the deployment history of a solution.
+func TestDeployment01(t *testing.T) {
+	solution, _ := NewSolution("app", "V1.0.0", "")
+
+	if _, err := solution.GetRollback(); err == nil {
+		t.Errorf("<solution>.GetRollback should have complained about a missing deployment")
+	}
+
+	solution.AddDeployment("app", "V1.0.0", "task1", false)
+	solution.AddDeployment("app", "V2.0.0", "task2", false)
+
+	if err := solution.SetOutcome("unknown", TaskStatusCompleted); err == nil {
+		t.Errorf("<solution>.SetOutcome should have complained about an unknown task")
+	}
+
+	solution.SetOutcome("task1", TaskStatusCompleted)
+	solution.SetOutcome("task2", TaskStatusFailed)
+
+	deployment, err := solution.GetRollback()
+	if err != nil || deployment.Version != "V1.0.0" {
+		t.Errorf("<solution>.GetRollback should have delivered the last successful deployment")
+	}
+
+	for index := 0; index < MaxDeployments; index++ {
+		solution.AddDeployment("app", "V3.0.0", "task3", false)
+	}
+
+	if len(solution.History) != MaxDeployments {
+		t.Errorf("<solution>.AddDeployment should have limited the size of the history")
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -19,6 +19,8 @@ import (
 //   - Components
 //   - Tasks
 //   - Events
+//   - Controllers
+//   - Secrets
 //
 // Functions:
 //   - NewDomain
@@ -75,6 +77,8 @@ type Domain struct {
 	EventsX        sync.RWMutex             `yaml:"EventsX,omitempty"`        // mutex for events
 	Controllers    map[string]*Controller   `yaml:"Controllers"`              // list of controllers
 	ControllersX   sync.RWMutex             `yaml:"ControllersX,omitempty"`   // mutex for controllers
+	Secrets        map[string]string        `yaml:"Secrets,omitempty"`        // map of encrypted secrets
+	SecretsX       sync.RWMutex             `yaml:"SecretsX,omitempty"`       // mutex for secrets
 }
 
 //------------------------------------------------------------------------------
@@ -96,6 +100,8 @@ func NewDomain(name string) (*Domain, error) {
 	domain.EventsX        = sync.RWMutex{}
 	domain.Controllers    = map[string]*Controller{}
 	domain.ControllersX   = sync.RWMutex{}
+	domain.Secrets        = map[string]string{}
+	domain.SecretsX       = sync.RWMutex{}
 
 	// add internal default controller
 	ctrl, _ := NewController("Internal", "V1.0.0")
@@ -204,6 +210,11 @@ func (domain *Domain) GetComponent(name string, version string) (*Component, err
 
 // AddComponent adds a component to a domain
 func (domain *Domain) AddComponent(component *Component) error {
+	// check the parameters of the component
+	if err := component.Validate(); err != nil {
+		return err
+	}
+
 	// check if component has already been defined
 	domain.ComponentsX.RLock()
 	_, ok := domain.Components[component.Component + " - " + component.Version]
@@ -217,6 +228,9 @@ func (domain *Domain) AddComponent(component *Component) error {
 	domain.Components[component.Component + " - " + component.Version] = component
 	domain.ComponentsX.Unlock()
 
+	// persist modification
+	Persist()
+
 	// success
 	return nil
 }
@@ -239,6 +253,9 @@ func (domain *Domain) DeleteComponent(name string, version string) error {
 	delete(domain.Components, name + " - " + version)
 	domain.ComponentsX.Unlock()
 
+	// persist modification
+	Persist()
+
 	// success
 	return nil
 }
@@ -294,6 +311,9 @@ func (domain *Domain) AddArchitecture(architecture *Architecture) error {
 	domain.Architectures[architecture.Architecture + " - " + architecture.Version] = architecture
 	domain.ArchitecturesX.Unlock()
 
+	// persist modification
+	Persist()
+
 	// success
 	return nil
 }
@@ -316,6 +336,9 @@ func (domain *Domain) DeleteArchitecture(name string, version string) error {
 	delete(domain.Architectures, name + " - " + version)
 	domain.ArchitecturesX.Unlock()
 
+	// persist modification
+	Persist()
+
 	// success
 	return nil
 }
@@ -371,6 +394,9 @@ func (domain *Domain) AddSolution(solution *Solution) error {
 	domain.Solutions[solution.Solution] = solution
 	domain.SolutionsX.Unlock()
 
+	// persist modification
+	Persist()
+
 	// success
 	return nil
 }
@@ -393,6 +419,9 @@ func (domain *Domain) DeleteSolution(name string) error {
 	delete(domain.Solutions, name)
 	domain.SolutionsX.Unlock()
 
+	// persist modification
+	Persist()
+
 	// success
 	return nil
 }
@@ -448,6 +477,9 @@ func (domain *Domain) AddTask(task *Task) error {
 	domain.Tasks[task.GetUUID()] = task
 	domain.TasksX.Unlock()
 
+	// persist modification
+	Persist()
+
 	// success
 	return nil
 }
@@ -470,6 +502,9 @@ func (domain *Domain) DeleteTask(uuid string) error {
 	delete(domain.Tasks, uuid)
 	domain.TasksX.Unlock()
 
+	// persist modification
+	Persist()
+
 	// success
 	return nil
 }
@@ -527,18 +562,25 @@ func (domain *Domain) AddEvent(event *Event) error {
 
 	// register with tasks
 	domain.TasksX.Lock()
-	task := domain.Tasks[event.Task]
-	task.AddEvent(event)
+	task, ok := domain.Tasks[event.Task]
+	if ok {
+		task.AddEvent(event)
+	}
 	domain.TasksX.Unlock()
 
 	// register with tasks
 	if event.Source != "" {
 		domain.TasksX.Lock()
-		task = domain.Tasks[event.Source]
-		task.AddEvent(event)
+		task, ok = domain.Tasks[event.Source]
+		if ok {
+			task.AddEvent(event)
+		}
 		domain.TasksX.Unlock()
 	}
 
+	// persist modification
+	Persist()
+
 	// success
 	return nil
 }
@@ -561,6 +603,9 @@ func (domain *Domain) DeleteEvent(uuid string) error {
 	delete(domain.Events, uuid)
 	domain.EventsX.Unlock()
 
+	// persist modification
+	Persist()
+
 	// success
 	return nil
 }
@@ -623,6 +668,9 @@ func (domain *Domain) AddController(controller *Controller) error {
 	domain.Controllers[controller.Controller + ":" + controller.Version] = controller
 	domain.ControllersX.Unlock()
 
+	// persist modification
+	Persist()
+
 	// success
 	return nil
 }
@@ -645,6 +693,9 @@ func (domain *Domain) DeleteController(controller string, version string) error
 	delete(domain.Controllers, controller + ":" + version)
 	domain.ControllersX.Unlock()
 
+	// persist modification
+	Persist()
+
 	// success
 	return nil
 }
@@ -0,0 +1,121 @@
+package model
+
+import (
+	"time"
+	"sort"
+	"errors"
+)
+
+//------------------------------------------------------------------------------
+// DriftDetection
+// ==============
+//
+// Attributes:
+//   - Interval
+//   - Remediate
+//
+// Functions:
+//   - detection.Validate
+//   - detection.GetInterval
+//
+//   - GetDrift
+//------------------------------------------------------------------------------
+
+// DriftDetection describes how often the configurations reported by the
+// controllers of the active instances of a component are compared with their
+// desired configuration and if drifted instances are reconfigured
+// automatically. Drift detection is disabled if no interval is defined.
+type DriftDetection struct {
+	Interval  string `yaml:"Interval,omitempty"`  // time between two checks of an active instance, e.g. "5m"
+	Remediate bool   `yaml:"Remediate,omitempty"` // reconfigure drifted instances automatically
+}
+
+//------------------------------------------------------------------------------
+
+// Drift describes the difference between the desired configuration of an
+// instance and the configuration reported by its controller.
+type Drift struct {
+	Element  string `yaml:"Element"`  // name of element
+	Cluster  string `yaml:"Cluster"`  // name of cluster
+	Instance string `yaml:"Instance"` // name of instance
+	Detected string `yaml:"Detected"` // time when the drift has been detected
+	Diff     string `yaml:"Diff"`     // line by line diff between the desired and the reported configuration
+}
+
+//------------------------------------------------------------------------------
+
+// Validate checks the settings of a drift detection.
+func (detection DriftDetection) Validate() error {
+	if detection.Interval != "" {
+		if interval, err := time.ParseDuration(detection.Interval); err != nil || interval <= 0 {
+			return errors.New("invalid drift detection interval: " + detection.Interval)
+		}
+	}
+
+	// success
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// GetInterval delivers the time between two checks of an active instance
+// (0 if drift detection is disabled).
+func (detection DriftDetection) GetInterval() time.Duration {
+	interval, err := time.ParseDuration(detection.Interval)
+	if err != nil || interval <= 0 {
+		return 0
+	}
+
+	return interval
+}
+
+//------------------------------------------------------------------------------
+
+// GetDrift lists the drifted instances of a solution in the order of their
+// elements, clusters and names.
+func GetDrift(domainName string, solutionName string) ([]*Drift, error) {
+	drifts := []*Drift{}
+
+	solution, err := GetSolution(domainName, solutionName)
+	if err != nil {
+		return drifts, err
+	}
+
+	elementNames, _ := solution.ListElements()
+	sort.Strings(elementNames)
+
+	for _, elementName := range elementNames {
+		element, _ := solution.GetElement(elementName)
+
+		clusterNames, _ := element.ListClusters()
+		sort.Strings(clusterNames)
+
+		for _, clusterName := range clusterNames {
+			cluster, _ := element.GetCluster(clusterName)
+
+			instanceNames, _ := cluster.ListInstances()
+			sort.Strings(instanceNames)
+
+			for _, instanceName := range instanceNames {
+				instance, _ := cluster.GetInstance(instanceName)
+
+				if instance.Drift == "" {
+					continue
+				}
+
+				drifts = append(drifts, &Drift{
+					Element:  elementName,
+					Cluster:  clusterName,
+					Instance: instanceName,
+					Detected: instance.Drifted,
+					Diff:     instance.Drift,
+				})
+			}
+		}
+	}
+
+	// success
+	return drifts, nil
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+//------------------------------------------------------------------------------
+
+// TestDrift01 tests the basic functions of a drift detection.
+func TestDrift01(t *testing.T) {
+	detection := DriftDetection{Interval: "5m", Remediate: true}
+
+	if err := detection.Validate(); err != nil {
+		t.Errorf("<detection>.Validate should have accepted a valid drift detection:\n%s", err)
+	}
+
+	if err := (DriftDetection{Interval: "-1s"}).Validate(); err == nil {
+		t.Errorf("<detection>.Validate should have complained about an invalid interval")
+	}
+
+	if detection.GetInterval() != 5 * time.Minute || (DriftDetection{}).GetInterval() != 0 {
+		t.Errorf("<detection>.GetInterval should have delivered the configured interval")
+	}
+
+	component, _ := NewComponent("server", "V1.0.0", "", "Internal:V1.0.0")
+	component.Drift = DriftDetection{Interval: "often"}
+
+	if err := component.Validate(); err == nil {
+		t.Errorf("<component>.Validate should have complained about an invalid drift detection")
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// TestDrift02 tests the recording of the drift of an instance.
+func TestDrift02(t *testing.T) {
+	instance, _ := NewInstance("a", ActiveState, "Port: 80")
+
+	if instance.SetDrift("") {
+		t.Errorf("<instance>.SetDrift should not have reported a change without a drift")
+	}
+
+	if !instance.SetDrift("- Port: 80\n+ Port: 8080\n") || instance.Drifted == "" {
+		t.Errorf("<instance>.SetDrift should have recorded the drift")
+	}
+
+	if instance.SetDrift("- Port: 80\n+ Port: 8080\n") {
+		t.Errorf("<instance>.SetDrift should not have reported an unchanged drift")
+	}
+
+	if !instance.SetDrift("") || instance.Drift != "" || instance.Drifted != "" {
+		t.Errorf("<instance>.SetDrift should have resolved the drift")
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -18,6 +18,7 @@ import (
 //   - State
 //   - Configuration
 //   - Endpoint
+//   - Upgrade
 //   - Clusters
 //
 // Functions:
@@ -45,6 +46,7 @@ type Element struct {
 	State          string              `yaml:"State"`               // current state of element
 	Configuration  string              `yaml:"Configuration"`       // runtime configuration of the solution element
 	Endpoint       string              `yaml:"Endpoint"`            // state of the solution element
+	Upgrade        *Upgrade            `yaml:"Upgrade,omitempty"`   // progress of an upgrade between clusters
 	Clusters       map[string]*Cluster `yaml:"Clusters"`            // clusters of the solution element
 	ClustersX      sync.RWMutex        `yaml:"ClustersX,omitempty"` // mutex for clusters
 }
@@ -180,14 +182,32 @@ func (element *Element) Update(domainName string, solutionName string, version s
 		return errors.New("Type of element does match the type defined in the element configuration")
 	}
 
+	// check the upgrade strategy
+	if err := elementConfiguration.Strategy.Validate(); err != nil {
+		util.LogError("element", "MODEL", "Invalid strategy of the element: '" + element.Element + "'\n" + err.Error())
+		return err
+	}
+
 	// update target state
 	element.Target = ActiveState
 
 	// update configuration
 	element.Configuration = elementConfiguration.Configuration
 
+	// remember the clusters which are currently active
+	previous := map[string]bool{}
+
+	clusterNames, _ := element.ListClusters()
+	for _, clusterName := range clusterNames {
+		cluster, _ := element.GetCluster(clusterName)
+
+		previous[clusterName] = cluster.Target == ActiveState
+	}
+
 	// update all clusters defined in the element configuration
-	clusterNames, _ := elementConfiguration.ListClusters()
+	created := []string{}
+
+	clusterNames, _ = elementConfiguration.ListClusters()
 	for _, clusterName := range clusterNames {
 
 		cluster, _              := element.GetCluster(clusterName)
@@ -197,13 +217,48 @@ func (element *Element) Update(domainName string, solutionName string, version s
 		if cluster == nil {
 			cluster, _ = NewCluster(clusterName, clusterConfiguration.State, clusterConfiguration.Min, clusterConfiguration.Max, clusterConfiguration.Size, "")
 			element.AddCluster(cluster)
+
+			created = append(created, clusterName)
 		}
 
+		// keep the size reached by an upgrade in progress
+		size := cluster.Size
+
 		// update the element with the configuration information
 		if err := cluster.Update(domainName, solutionName, version, element, clusterConfiguration); err != nil {
 			util.LogError("element", "MODEL", "Unable to update cluster: '" + clusterName + "' of the element: '" + element.Element + "'\n" + err.Error())
 			return err
 		}
+
+		// resume an upgrade in progress
+		if element.upgrading() && element.Upgrade.To == clusterName {
+			element.Upgrade.Strategy = elementConfiguration.Strategy
+			element.Upgrade.Min      = cluster.Min
+			element.Upgrade.Max      = cluster.Max
+			element.Upgrade.Size     = cluster.Size
+			element.Upgrade.Status   = UpgradeRunning
+
+			cluster.Resize(0, cluster.Max, size)
+		}
+	}
+
+	// replace a single active cluster step by step if a strategy has been defined
+	if elementConfiguration.Strategy.Type != "" && !element.upgrading() {
+		from := []string{}
+		for clusterName, active := range previous {
+			if clusterConfiguration, _ := elementConfiguration.GetCluster(clusterName); active && clusterConfiguration == nil {
+				from = append(from, clusterName)
+			}
+		}
+
+		if len(from) == 1 && len(created) == 1 {
+			fromCluster, _ := element.GetCluster(from[0])
+			toCluster, _   := element.GetCluster(created[0])
+
+			if toCluster.Target == ActiveState {
+				element.Upgrade = newUpgrade(elementConfiguration.Strategy, fromCluster, toCluster)
+			}
+		}
 	}
 
 	// delete all clusters not defined in the element configuration
@@ -212,6 +267,11 @@ func (element *Element) Update(domainName string, solutionName string, version s
 		cluster, _              := element.GetCluster(clusterName)
 		clusterConfiguration, _ := elementConfiguration.GetCluster(clusterName)
 
+		// the old cluster of an upgrade is drained by the engine
+		if element.upgrading() && element.Upgrade.From == clusterName {
+			continue
+		}
+
 		// cluster is not defined in the element configuration
 		if clusterConfiguration == nil {
 			cluster.Reset()
@@ -224,10 +284,22 @@ func (element *Element) Update(domainName string, solutionName string, version s
 
 //------------------------------------------------------------------------------
 
+// upgrading checks if an upgrade between clusters is in progress.
+func (element *Element) upgrading() bool {
+	return element.Upgrade != nil && (element.Upgrade.Status == UpgradeRunning || element.Upgrade.Status == UpgradePaused)
+}
+
+//------------------------------------------------------------------------------
+
 // Reset state of element
 func (element *Element) Reset() {
 	element.Target = InitialState
 
+	// stop an upgrade in progress
+	if element.upgrading() {
+		element.Upgrade.Status = UpgradeAborted
+	}
+
 	// reset all clusters
 	clusterNames, _ := element.ListClusters()
 	for _, clusterName := range clusterNames {
@@ -261,6 +333,9 @@ func (element *Element) OK() bool {
 func (element *Element) SetState(newState string)  {
 	if newState == InitialState || newState == InactiveState || newState == ActiveState || newState == FailureState {
 		element.State = newState
+
+		// persist modification
+		Persist()
 	}
 }
 
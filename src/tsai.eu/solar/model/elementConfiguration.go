@@ -15,6 +15,7 @@ import (
 //   - Element
 //   - Component
 //   - Configuration
+//   - Strategy
 //   - Clusters
 //
 // Functions:
@@ -35,6 +36,7 @@ type ElementConfiguration struct {
 	Element       string                           `yaml:"Element"`             // name of the solution element
 	Component     string                           `yaml:"Component"`           // type of the solution elmenent
 	Configuration string                           `yaml:"Configuration"`       // runtime configuration of the solution element
+	Strategy      Strategy                         `yaml:"Strategy,omitempty"`  // strategy for upgrades between clusters
 	Clusters      map[string]*ClusterConfiguration `yaml:"Clusters"`            // cluster configurations of the solution element
 	ClustersX     sync.RWMutex                     `yaml:"ClustersX,omitempty"` // mutex for cluster configurations
 }
@@ -49,7 +49,7 @@ func NewEvent(domain string, task string, etype string, source string, comment s
 	event.Type    = etype
 	event.Source  = source
 	event.Time    = time.Now().UnixNano()
-	event.Comment = comment
+	event.Comment = util.Redact(comment)
 
 	// success
 	return event
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"time"
+	"errors"
+	"strconv"
+)
+
+//------------------------------------------------------------------------------
+// Health
+// ======
+//
+// Attributes:
+//   - Interval
+//   - Threshold
+//   - Replacements
+//   - Period
+//
+// Functions:
+//   - health.Validate
+//   - health.GetInterval
+//   - health.GetThreshold
+//   - health.GetReplacements
+//   - health.GetPeriod
+//------------------------------------------------------------------------------
+
+// DefaultThreshold defines the number of consecutive failed probes before an instance is replaced.
+const DefaultThreshold int = 3
+
+// DefaultReplacements defines the max. number of instances of a cluster replaced within a period.
+const DefaultReplacements int = 1
+
+// DefaultPeriod defines the period limiting the replacements of a cluster.
+const DefaultPeriod time.Duration = 10 * time.Minute
+
+//------------------------------------------------------------------------------
+
+// Health describes how often the active instances of a component are probed
+// via the status operation of their controller and when failing instances
+// are replaced. Health checks are disabled if no interval is defined.
+type Health struct {
+	Interval     string `yaml:"Interval,omitempty"`     // time between two probes of an active instance, e.g. "30s"
+	Threshold    int    `yaml:"Threshold,omitempty"`    // number of consecutive failed probes before an instance is replaced (default 3)
+	Replacements int    `yaml:"Replacements,omitempty"` // max. number of instances of a cluster replaced within the period (default 1)
+	Period       string `yaml:"Period,omitempty"`       // period limiting the replacements, e.g. "1h" (default 10m)
+}
+
+//------------------------------------------------------------------------------
+
+// Validate checks the settings of a health check.
+func (health Health) Validate() error {
+	if health.Interval != "" {
+		if interval, err := time.ParseDuration(health.Interval); err != nil || interval <= 0 {
+			return errors.New("invalid health check interval: " + health.Interval)
+		}
+	}
+	if health.Threshold < 0 {
+		return errors.New("invalid health check threshold: " + strconv.Itoa(health.Threshold))
+	}
+	if health.Replacements < 0 {
+		return errors.New("invalid number of replacements: " + strconv.Itoa(health.Replacements))
+	}
+	if health.Period != "" {
+		if period, err := time.ParseDuration(health.Period); err != nil || period <= 0 {
+			return errors.New("invalid replacement period: " + health.Period)
+		}
+	}
+
+	// success
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// GetInterval delivers the time between two probes of an active instance
+// (0 if health checks are disabled).
+func (health Health) GetInterval() time.Duration {
+	interval, err := time.ParseDuration(health.Interval)
+	if err != nil || interval <= 0 {
+		return 0
+	}
+
+	return interval
+}
+
+//------------------------------------------------------------------------------
+
+// GetThreshold delivers the number of consecutive failed probes before an
+// instance is replaced.
+func (health Health) GetThreshold() int {
+	if health.Threshold <= 0 {
+		return DefaultThreshold
+	}
+
+	return health.Threshold
+}
+
+//------------------------------------------------------------------------------
+
+// GetReplacements delivers the max. number of instances of a cluster replaced
+// within the period.
+func (health Health) GetReplacements() int {
+	if health.Replacements <= 0 {
+		return DefaultReplacements
+	}
+
+	return health.Replacements
+}
+
+//------------------------------------------------------------------------------
+
+// GetPeriod delivers the period limiting the replacements of a cluster.
+func (health Health) GetPeriod() time.Duration {
+	period, err := time.ParseDuration(health.Period)
+	if err != nil || period <= 0 {
+		return DefaultPeriod
+	}
+
+	return period
+}
+
+//------------------------------------------------------------------------------
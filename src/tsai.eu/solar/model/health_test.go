@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+//------------------------------------------------------------------------------
+
+// TestHealth01 tests the basic functions of a health check.
+func TestHealth01(t *testing.T) {
+	health := Health{Interval: "30s", Threshold: 2, Replacements: 3, Period: "1h"}
+
+	if err := health.Validate(); err != nil {
+		t.Errorf("<health>.Validate should have accepted a valid health check:\n%s", err)
+	}
+
+	if err := (Health{Interval: "often"}).Validate(); err == nil {
+		t.Errorf("<health>.Validate should have complained about an invalid interval")
+	}
+
+	if err := (Health{Threshold: -1}).Validate(); err == nil {
+		t.Errorf("<health>.Validate should have complained about an invalid threshold")
+	}
+
+	if err := (Health{Period: "0s"}).Validate(); err == nil {
+		t.Errorf("<health>.Validate should have complained about an invalid period")
+	}
+
+	if health.GetInterval() != 30 * time.Second || health.GetThreshold() != 2 || health.GetReplacements() != 3 || health.GetPeriod() != time.Hour {
+		t.Errorf("<health> should have delivered the configured settings")
+	}
+
+	if (Health{}).GetInterval() != 0 || (Health{}).GetThreshold() != DefaultThreshold || (Health{}).GetReplacements() != DefaultReplacements || (Health{}).GetPeriod() != DefaultPeriod {
+		t.Errorf("<health> should have delivered the default settings")
+	}
+
+	component, _ := NewComponent("server", "V1.0.0", "", "")
+	component.Health = Health{Interval: "-1s"}
+	if err := component.Validate(); err == nil {
+		t.Errorf("<component>.Validate should have complained about an invalid health check")
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"tsai.eu/solar/util"
 )
 
@@ -14,6 +16,9 @@ import (
 //   - State
 //   - Configuration
 //   - Endpoint
+//   - Drift
+//   - Drifted
+//   - Bindings
 //
 // Functions:
 //   - NewInstance
@@ -23,16 +28,23 @@ import (
 //   - instance.Save
 //   - instance.Reset
 //   - instance.OK
+//   - instance.Outdated
+//   - instance.SetDrift
+//   - instance.IsBound
+//   - instance.SetBinding
 //   - instance.SetState
 //------------------------------------------------------------------------------
 
 // Instance describes the runtime configuration of an solution element cluster instance within a domain.
 type Instance struct {
-	UUID          string  `yaml:"UUID"`          // uuid of the instance
-	Target        string  `yaml:"Target"`        // target state of the instance
-	State         string  `yaml:"State"`         // state of the instance
-	Configuration string  `yaml:"Configuration"` // runtime configuration of the instance
-	Endpoint      string  `yaml:"Endpoint"`      // endpoint of the instance
+	UUID          string   `yaml:"UUID"`               // uuid of the instance
+	Target        string   `yaml:"Target"`             // target state of the instance
+	State         string   `yaml:"State"`              // state of the instance
+	Configuration string   `yaml:"Configuration"`      // runtime configuration of the instance
+	Endpoint      string   `yaml:"Endpoint"`           // endpoint of the instance
+	Drift         string   `yaml:"Drift,omitempty"`    // diff between the desired configuration and the configuration reported by the controller
+	Drifted       string   `yaml:"Drifted,omitempty"`  // time when the drift has been detected
+	Bindings      []string `yaml:"Bindings,omitempty"` // relationships the instance has been bound to
 }
 
 //------------------------------------------------------------------------------
@@ -92,10 +104,91 @@ func (instance *Instance) OK() bool {
 
 //------------------------------------------------------------------------------
 
+// Outdated checks if the configuration last reported by the controller of an
+// inactive or active instance differs from the desired configuration. The
+// configuration of instances which have not reported a configuration is
+// regarded to be unknown.
+func (instance *Instance) Outdated(configuration string) bool {
+	if instance.State != InactiveState && instance.State != ActiveState {
+		return false
+	}
+
+	if instance.Configuration == "" {
+		return false
+	}
+
+	return instance.Configuration != RedactReferences(configuration)
+}
+
+//------------------------------------------------------------------------------
+
+// SetDrift records the difference between the desired configuration and the
+// configuration reported by the controller (empty if there is no drift). It
+// reports if the drift has changed.
+func (instance *Instance) SetDrift(diff string) bool {
+	if instance.Drift == diff {
+		return false
+	}
+
+	instance.Drift   = diff
+	instance.Drifted = ""
+	if diff != "" {
+		instance.Drifted = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	// persist modification
+	Persist()
+
+	return true
+}
+
+//------------------------------------------------------------------------------
+
+// IsBound checks if the instance has been bound to the cluster referenced by a relationship.
+func (instance *Instance) IsBound(relationship string) bool {
+	for _, binding := range instance.Bindings {
+		if binding == relationship {
+			return true
+		}
+	}
+
+	return false
+}
+
+//------------------------------------------------------------------------------
+
+// SetBinding records that the instance has been bound to the cluster
+// referenced by a relationship or that the binding has been released.
+func (instance *Instance) SetBinding(relationship string, bound bool) {
+	bindings := []string{}
+	for _, binding := range instance.Bindings {
+		if binding != relationship {
+			bindings = append(bindings, binding)
+		}
+	}
+
+	if bound {
+		bindings = append(bindings, relationship)
+	}
+
+	instance.Bindings = bindings
+	if len(bindings) == 0 {
+		instance.Bindings = nil
+	}
+
+	// persist modification
+	Persist()
+}
+
+//------------------------------------------------------------------------------
+
 // SetState updates the current state of the instance
 func (instance *Instance) SetState(newState string)  {
 	if newState == InitialState || newState == InactiveState || newState == ActiveState || newState == FailureState {
 		instance.State = newState
+
+		// persist modification
+		Persist()
 	}
 }
 
@@ -83,6 +83,9 @@ func (model *Model) Reset() error {
 	model.Domains  = map[string]*Domain{}
 	model.DomainsX = sync.RWMutex{}
 
+	// persist modification
+	Persist()
+
 	// success
 	return nil
 }
@@ -105,14 +108,24 @@ func (model *Model) Save(filename string) error {
 
 // Load reads the model from a file
 func (model *Model) Load(filename string) error {
-	return util.LoadYAML(filename, model)
+	err := util.LoadYAML(filename, model)
+
+	// persist modification
+	Persist()
+
+	return err
 }
 
 //------------------------------------------------------------------------------
 
 // Load2 imports a yaml model
 func (model *Model) Load2(yaml string) error {
-	return util.ConvertFromYAML(yaml, model)
+	err := util.ConvertFromYAML(yaml, model)
+
+	// persist modification
+	Persist()
+
+	return err
 }
 
 //------------------------------------------------------------------------------
@@ -165,6 +178,9 @@ func (model *Model) AddDomain(domain *Domain) error {
 	model.Domains[domain.Name] = domain
 	model.DomainsX.Unlock()
 
+	// persist modification
+	Persist()
+
 	// success
 	return nil
 }
@@ -187,6 +203,9 @@ func (model *Model) DeleteDomain(name string) error {
 	delete(model.Domains, name)
 	model.DomainsX.Unlock()
 
+	// persist modification
+	Persist()
+
 	// success
 	return nil
 }
@@ -0,0 +1,177 @@
+package model
+
+import (
+	"sync"
+	"time"
+	"strings"
+)
+
+//------------------------------------------------------------------------------
+// Notification
+// ============
+//
+// Notifications inform subscribers about the events processed by the
+// dispatcher and about the state changes of elements, clusters and instances.
+// Subscribers which do not keep up with the notifications miss them, the
+// publisher is never blocked.
+//
+// Attributes:
+//   - Type
+//   - Domain
+//   - Solution
+//   - Element
+//   - Cluster
+//   - Instance
+//   - State
+//   - Event
+//   - Time
+//
+// Functions:
+//   - Subscribe
+//   - Unsubscribe
+//   - Publish
+//   - PublishEvent
+//   - PublishState
+//------------------------------------------------------------------------------
+
+// Notification describes an event or a state change.
+type Notification struct {
+	Type     string `yaml:"Type"               json:"Type"`               // Event, Element, Cluster or Instance
+	Domain   string `yaml:"Domain"             json:"Domain"`             // name of the domain
+	Solution string `yaml:"Solution,omitempty" json:"Solution,omitempty"` // name of the solution
+	Element  string `yaml:"Element,omitempty"  json:"Element,omitempty"`  // name of the element
+	Cluster  string `yaml:"Cluster,omitempty"  json:"Cluster,omitempty"`  // name of the cluster
+	Instance string `yaml:"Instance,omitempty" json:"Instance,omitempty"` // name of the instance
+	State    string `yaml:"State,omitempty"    json:"State,omitempty"`    // new state of the entity
+	Event    *Event `yaml:"Event,omitempty"    json:"Event,omitempty"`    // processed event
+	Time     int64  `yaml:"Time"               json:"Time"`               // time since 1.1.1970 in nsecs
+}
+
+//------------------------------------------------------------------------------
+
+// Subscription receives the notifications concerning a domain, optionally
+// restricted to a solution and an element of the solution.
+type Subscription struct {
+	Channel  chan *Notification // channel receiving the notifications
+	Domain   string             // name of the domain
+	Solution string             // name of the solution (empty for all solutions)
+	Element  string             // name of the element (empty for all elements)
+}
+
+//------------------------------------------------------------------------------
+
+// subscriptionBuffer is the number of notifications a subscriber may lag behind.
+const subscriptionBuffer int = 256
+
+var subscriptions  = map[*Subscription]bool{} // active subscriptions
+var subscriptionsX sync.RWMutex               // mutex for the subscriptions
+
+//------------------------------------------------------------------------------
+
+// Subscribe registers a new subscription.
+func Subscribe(domain string, solution string, element string) *Subscription {
+	subscription := Subscription{
+		Channel:  make(chan *Notification, subscriptionBuffer),
+		Domain:   domain,
+		Solution: solution,
+		Element:  element,
+	}
+
+	subscriptionsX.Lock()
+	subscriptions[&subscription] = true
+	subscriptionsX.Unlock()
+
+	// success
+	return &subscription
+}
+
+//------------------------------------------------------------------------------
+
+// Unsubscribe removes a subscription and closes its channel.
+func Unsubscribe(subscription *Subscription) {
+	subscriptionsX.Lock()
+	defer subscriptionsX.Unlock()
+
+	if subscriptions[subscription] {
+		delete(subscriptions, subscription)
+		close(subscription.Channel)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// Matches checks if a notification concerns a subscription.
+func (subscription *Subscription) Matches(notification *Notification) bool {
+	return notification.Domain == subscription.Domain &&
+		(subscription.Solution == "" || notification.Solution == subscription.Solution) &&
+		(subscription.Element  == "" || notification.Element  == subscription.Element)
+}
+
+//------------------------------------------------------------------------------
+
+// Publish hands a notification over to all matching subscriptions.
+func Publish(notification *Notification) {
+	subscriptionsX.RLock()
+	defer subscriptionsX.RUnlock()
+
+	for subscription := range subscriptions {
+		if !subscription.Matches(notification) {
+			continue
+		}
+
+		// never block the publisher
+		select {
+		case subscription.Channel <- notification:
+		default:
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// PublishEvent notifies about an event processed for a task. The task may be
+// nil if it is unknown.
+func PublishEvent(event *Event, task *Task) {
+	notification := Notification{
+		Type:   NotificationEvent,
+		Domain: event.Domain,
+		Event:  event,
+		Time:   time.Now().UnixNano(),
+	}
+
+	if task != nil {
+		notification.Solution = task.Solution
+		notification.Element  = task.Element
+		notification.Cluster  = task.Cluster
+		notification.Instance = task.Instance
+	}
+
+	Publish(&notification)
+}
+
+//------------------------------------------------------------------------------
+
+// PublishState notifies about the new state of an element, cluster or
+// instance identified by its path <domain>/<solution>/<element>[/<cluster>[/<instance>]].
+func PublishState(entity string, path string, state string) {
+	names := strings.Split(path, "/")
+	name  := func(index int) string {
+		if index < len(names) {
+			return names[index]
+		}
+		return ""
+	}
+
+	Publish(&Notification{
+		Type:     entity,
+		Domain:   name(0),
+		Solution: name(1),
+		Element:  name(2),
+		Cluster:  name(3),
+		Instance: name(4),
+		State:    state,
+		Time:     time.Now().UnixNano(),
+	})
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+// TestNotification01 tests the delivery of notifications to subscribers.
+func TestNotification01(t *testing.T) {
+	all      := Subscribe("demo", "", "")
+	filtered := Subscribe("demo", "app", "web")
+
+	event := NewEvent("demo", "1234", EventTypeTaskExecution, "", "")
+
+	PublishEvent(&event, &Task{Solution: "app", Element: "web", Cluster: "V1"})
+	PublishState(NotificationCluster, "demo/app/db/V1", ActiveState)
+	PublishState(NotificationInstance, "other/app/web/V1/0", ActiveState)
+
+	// all notifications of the domain
+	if notification := <-all.Channel; notification.Type != NotificationEvent || notification.Event != &event || notification.Cluster != "V1" {
+		t.Errorf("subscriber should have received the event:\n%v", notification)
+	}
+
+	if notification := <-all.Channel; notification.Type != NotificationCluster || notification.Element != "db" || notification.State != ActiveState {
+		t.Errorf("subscriber should have received the state change of the cluster:\n%v", notification)
+	}
+
+	// notifications of the element
+	if notification := <-filtered.Channel; notification.Type != NotificationEvent {
+		t.Errorf("subscriber should have received the event of the element:\n%v", notification)
+	}
+
+	Unsubscribe(all)
+	Unsubscribe(filtered)
+
+	if _, ok := <-filtered.Channel; ok {
+		t.Errorf("subscriber should not have received notifications of other elements or domains")
+	}
+
+	// publishing without subscribers
+	PublishState(NotificationElement, "demo/app/web", ActiveState)
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,176 @@
+package model
+
+import (
+	"sort"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+//------------------------------------------------------------------------------
+// Parameter
+// =========
+//
+// Attributes:
+//   - Name
+//   - Type
+//   - Default
+//   - Required
+//   - Description
+//   - Values
+//
+// Functions:
+//   - parameter.Validate
+//   - parameter.Check
+//
+//   - ValidateParameters
+//   - ResolveParameters
+//------------------------------------------------------------------------------
+
+// Parameter describes a parameter of the configuration template of a
+// component or a dependency.
+type Parameter struct {
+	Name        string   `yaml:"Name"`                  // name of the parameter
+	Type        string   `yaml:"Type,omitempty"`        // type of the parameter: string (default), integer, number or boolean
+	Default     string   `yaml:"Default,omitempty"`     // value used if the parameter has not been defined
+	Required    bool     `yaml:"Required,omitempty"`    // parameter needs to be defined by the architecture
+	Description string   `yaml:"Description,omitempty"` // description of the parameter
+	Values      []string `yaml:"Values,omitempty"`      // allowed values of the parameter
+}
+
+//------------------------------------------------------------------------------
+
+// Validate checks the definition of a parameter.
+func (parameter *Parameter) Validate() error {
+	if parameter.Name == "" {
+		return errors.New("name of parameter is undefined")
+	}
+
+	switch parameter.Type {
+	case "", ParameterString, ParameterInteger, ParameterNumber, ParameterBoolean:
+	default:
+		return errors.New("invalid type of parameter '" + parameter.Name + "': " + parameter.Type)
+	}
+
+	for _, value := range parameter.Values {
+		if err := parameter.checkType(value); err != nil {
+			return err
+		}
+	}
+
+	if parameter.Default != "" {
+		if err := parameter.Check(parameter.Default); err != nil {
+			return errors.New("invalid default of parameter '" + parameter.Name + "':\n" + err.Error())
+		}
+	}
+
+	// success
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// Check verifies that a value conforms to the type and the allowed values of the parameter.
+func (parameter *Parameter) Check(value string) error {
+	if err := parameter.checkType(value); err != nil {
+		return err
+	}
+
+	if len(parameter.Values) == 0 {
+		return nil
+	}
+
+	for _, allowed := range parameter.Values {
+		if allowed == value {
+			return nil
+		}
+	}
+
+	return errors.New("value of parameter '" + parameter.Name + "' is not allowed: " + value)
+}
+
+//------------------------------------------------------------------------------
+
+// checkType verifies that a value conforms to the type of the parameter.
+func (parameter *Parameter) checkType(value string) error {
+	var err error
+
+	switch parameter.Type {
+	case ParameterInteger:
+		_, err = strconv.Atoi(value)
+	case ParameterNumber:
+		_, err = strconv.ParseFloat(value, 64)
+	case ParameterBoolean:
+		_, err = strconv.ParseBool(value)
+	}
+
+	if err != nil {
+		return errors.New("value of parameter '" + parameter.Name + "' is not of type " + parameter.Type + ": " + value)
+	}
+
+	// success
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// ValidateParameters checks the definitions of a list of parameters.
+func ValidateParameters(parameters []*Parameter) error {
+	names := map[string]bool{}
+
+	for _, parameter := range parameters {
+		if err := parameter.Validate(); err != nil {
+			return err
+		}
+
+		if names[parameter.Name] {
+			return errors.New("parameter has been defined twice: " + parameter.Name)
+		}
+		names[parameter.Name] = true
+	}
+
+	// success
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// ResolveParameters applies the defaults of the parameter schema to the values
+// and checks that all required parameters have been defined with valid values.
+func ResolveParameters(schema []*Parameter, values map[string]string) (map[string]string, error) {
+	result   := map[string]string{}
+	messages := []string{}
+
+	for name, value := range values {
+		result[name] = value
+	}
+
+	for _, parameter := range schema {
+		value, ok := result[parameter.Name]
+		if !ok {
+			if parameter.Required {
+				messages = append(messages, "required parameter is undefined: " + parameter.Name)
+				continue
+			}
+			if parameter.Default == "" {
+				continue
+			}
+			value = parameter.Default
+			result[parameter.Name] = value
+		}
+
+		if err := parameter.Check(value); err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+
+	if len(messages) > 0 {
+		sort.Strings(messages)
+		return nil, errors.New(strings.Join(messages, "\n"))
+	}
+
+	// success
+	return result, nil
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+// TestParameter01 tests the basic functions of a parameter.
+func TestParameter01(t *testing.T) {
+	if err := (&Parameter{Name: "mtu", Type: ParameterInteger, Default: "1500"}).Validate(); err != nil {
+		t.Errorf("<parameter>.Validate should have accepted a valid parameter:\n%s", err)
+	}
+
+	if err := (&Parameter{Type: ParameterString}).Validate(); err == nil {
+		t.Errorf("<parameter>.Validate should have complained about an undefined name")
+	}
+
+	if err := (&Parameter{Name: "mtu", Type: "unknown"}).Validate(); err == nil {
+		t.Errorf("<parameter>.Validate should have complained about an invalid type")
+	}
+
+	if err := (&Parameter{Name: "mtu", Type: ParameterInteger, Default: "large"}).Validate(); err == nil {
+		t.Errorf("<parameter>.Validate should have complained about an invalid default")
+	}
+
+	if err := (&Parameter{Name: "flavor", Values: []string{"small", "large"}, Default: "medium"}).Validate(); err == nil {
+		t.Errorf("<parameter>.Validate should have complained about a default which is not allowed")
+	}
+
+	if err := ValidateParameters([]*Parameter{{Name: "mtu"}, {Name: "mtu"}}); err == nil {
+		t.Errorf("ValidateParameters should have complained about a duplicate parameter")
+	}
+
+	parameter := Parameter{Name: "enabled", Type: ParameterBoolean}
+	if parameter.Check("true") != nil || parameter.Check("yes") == nil {
+		t.Errorf("<parameter>.Check should have checked the type of the value")
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// TestParameter02 tests the rendering of a template.
+func TestParameter02(t *testing.T) {
+	schema := []*Parameter{
+		{Name: "cidr", Required: true},
+		{Name: "mtu",  Type: ParameterInteger, Default: "1500"},
+	}
+
+	result, err := RenderTemplate("cidr: {{ cidr }}, mtu: {{mtu}}, other: {{other}}", schema, map[string]string{"cidr": "10.0.0.0/24"}, nil)
+	if err != nil {
+		t.Fatalf("RenderTemplate should have rendered the template:\n%s", err)
+	}
+
+	if result != "cidr: 10.0.0.0/24, mtu: 1500, other: {{other}}" {
+		t.Errorf("RenderTemplate delivered an unexpected result: %s", result)
+	}
+
+	if _, err = RenderTemplate("cidr: {{cidr}}", schema, map[string]string{}, nil); err == nil {
+		t.Errorf("RenderTemplate should have complained about a missing required parameter")
+	}
+
+	if _, err = RenderTemplate("mtu: {{mtu}}", schema, map[string]string{"cidr": "10.0.0.0/24", "mtu": "large"}, nil); err == nil {
+		t.Errorf("RenderTemplate should have complained about an invalid value")
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// TestParameter03 tests the rendering of the configuration of a cluster.
+func TestParameter03(t *testing.T) {
+	model := GetModel()
+
+	model.Load("testdata/testdata1.yaml")
+
+	component, _ := GetComponent("demo", "network", "V1.0.0")
+	component.Configuration = component.Configuration + "mtu: {{mtu}}\n"
+	component.Parameters    = []*Parameter{
+		{Name: "cidr", Required: true},
+		{Name: "mtu",  Type: ParameterInteger, Default: "1500"},
+	}
+
+	element, _       := GetElement("demo", "app", "ext")
+	architecture, _  := GetArchitecture("demo", "app", "V0.0.0")
+	configuration, _ := architecture.GetElement("ext")
+
+	if err := element.Update("demo", "app", "V0.0.0", configuration); err != nil {
+		t.Fatalf("<element>.Update should have rendered the configuration:\n%s", err)
+	}
+
+	cluster, _ := element.GetCluster("V1.0.0")
+	if !strings.Contains(cluster.Configuration, "cidr: '10.0.1.0/24'") || !strings.Contains(cluster.Configuration, "mtu: 1500") {
+		t.Errorf("<element>.Update should have applied the parameters and defaults:\n%s", cluster.Configuration)
+	}
+
+	// remove the required parameter
+	clusterConfiguration, _ := configuration.GetCluster("V1.0.0")
+	clusterConfiguration.Configuration = "'gateway': '10.0.1.1'"
+
+	if err := element.Update("demo", "app", "V0.0.0", configuration); err == nil {
+		t.Errorf("<element>.Update should have complained about a missing required parameter")
+	}
+
+	domain, _ := GetDomain("demo")
+	if validation := ValidateArchitecture(domain, architecture); validation.OK() {
+		t.Errorf("ValidateArchitecture should have complained about a missing required parameter")
+	}
+}
+
+//------------------------------------------------------------------------------
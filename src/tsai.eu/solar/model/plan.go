@@ -0,0 +1,339 @@
+package model
+
+import (
+	"sort"
+	"errors"
+	"strconv"
+
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+// Plan
+// ====
+//
+// Attributes:
+//   - Domain
+//   - Solution
+//   - Version
+//   - Architecture
+//   - Changes
+//   - Transitions
+//
+// Functions:
+//   - NewPlan
+//
+//   - plan.Show
+//------------------------------------------------------------------------------
+
+// PlanChange describes a change of an entity of a solution.
+type PlanChange struct {
+	Entity string `yaml:"Entity"`         // type of entity: solution, element, cluster, relationship
+	Name   string `yaml:"Name"`           // path of the entity within the solution
+	Change string `yaml:"Change"`         // type of change: create, reset, update, resize, reconfigure
+	From   string `yaml:"From,omitempty"` // previous value
+	To     string `yaml:"To,omitempty"`   // new value
+}
+
+//------------------------------------------------------------------------------
+
+// PlanTransition describes a transition of an instance issued by the engine.
+type PlanTransition struct {
+	Element    string `yaml:"Element"`    // name of the element
+	Cluster    string `yaml:"Cluster"`    // version of the cluster
+	Instance   string `yaml:"Instance"`   // uuid of the instance (new instances are listed as "new-<n>")
+	From       string `yaml:"From"`       // current state of the instance
+	To         string `yaml:"To"`         // target state of the instance
+	Transition string `yaml:"Transition"` // transition: create, start, stop, destroy, reset
+}
+
+//------------------------------------------------------------------------------
+
+// Plan describes the changes a deployment of an architecture would apply to a solution.
+type Plan struct {
+	Domain       string            `yaml:"Domain"`       // name of the domain
+	Solution     string            `yaml:"Solution"`     // name of the solution
+	Version      string            `yaml:"Version"`      // current version of the solution
+	Architecture string            `yaml:"Architecture"` // version of the architecture to be deployed
+	Changes      []*PlanChange     `yaml:"Changes"`      // changes of the solution
+	Transitions  []*PlanTransition `yaml:"Transitions"`  // sequence of instance transitions
+}
+
+//------------------------------------------------------------------------------
+
+// NewPlan computes the changes a deployment of an architecture would apply to
+// its solution without modifying the model.
+func NewPlan(domainName string, architecture *Architecture) (*Plan, error) {
+	domain, err := GetDomain(domainName)
+	if err != nil {
+		return nil, errors.New("domain not found")
+	}
+
+	plan := Plan{
+		Domain:       domainName,
+		Solution:     architecture.Architecture,
+		Version:      "",
+		Architecture: architecture.Version,
+		Changes:      []*PlanChange{},
+		Transitions:  []*PlanTransition{},
+	}
+
+	// work on a copy of the current solution
+	current, _ := NewSolution(architecture.Architecture, architecture.Version, "")
+	if solution, err := domain.GetSolution(architecture.Architecture); err == nil {
+		current = solution.snapshot()
+		plan.Version = current.Version
+	} else {
+		plan.addChange("solution", plan.Solution, "create", "", architecture.Version)
+	}
+
+	updated := current.snapshot()
+
+	// name new instances after their order of creation
+	names := map[string]string{}
+
+	if err = updated.Update(domainName, architecture); err != nil {
+		return nil, err
+	}
+
+	// determine the changes
+	plan.compareSolutions(current, updated, names)
+
+	// simulate the execution of the engine
+	plan.simulate(updated, names)
+
+	// success
+	return &plan, nil
+}
+
+//------------------------------------------------------------------------------
+
+// Show displays the plan as yaml
+func (plan *Plan) Show() (string, error) {
+	return util.ConvertToYAML(plan)
+}
+
+//------------------------------------------------------------------------------
+
+// addChange records a change of an entity.
+func (plan *Plan) addChange(entity string, name string, change string, from string, to string) {
+	plan.Changes = append(plan.Changes, &PlanChange{
+		Entity: entity,
+		Name:   name,
+		Change: change,
+		From:   from,
+		To:     to,
+	})
+}
+
+//------------------------------------------------------------------------------
+
+// compareSolutions records the differences between the current and the updated solution.
+func (plan *Plan) compareSolutions(current *Solution, updated *Solution, names map[string]string) {
+	if current.Version != updated.Version && plan.Version != "" {
+		plan.addChange("solution", plan.Solution, "update", current.Version, updated.Version)
+	}
+
+	elementNames, _ := updated.ListElements()
+	sort.Strings(elementNames)
+
+	for _, elementName := range elementNames {
+		element, _  := updated.GetElement(elementName)
+		previous, _ := current.GetElement(elementName)
+
+		// element is new
+		if previous == nil {
+			plan.addChange("element", elementName, "create", "", element.Target)
+			previous, _ = NewElement(elementName, element.Component, "")
+		} else if previous.Target != element.Target {
+			plan.addChange("element", elementName, targetChange(element.Target), previous.Target, element.Target)
+		}
+
+		// clusters are replaced step by step
+		if element.upgrading() && (previous.Upgrade == nil || *previous.Upgrade != *element.Upgrade) {
+			plan.addChange("element", elementName, "upgrade", element.Upgrade.From, element.Upgrade.To)
+		}
+
+		plan.compareElements(elementName, previous, element, names)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// compareElements records the differences between the clusters of an element.
+func (plan *Plan) compareElements(path string, current *Element, updated *Element, names map[string]string) {
+	clusterNames, _ := updated.ListClusters()
+	sort.Strings(clusterNames)
+
+	for _, clusterName := range clusterNames {
+		cluster, _  := updated.GetCluster(clusterName)
+		previous, _ := current.GetCluster(clusterName)
+		name        := path + "/" + clusterName
+
+		// cluster is new
+		if previous == nil {
+			plan.addChange("cluster", name, "create", "", cluster.Target)
+			previous, _ = NewCluster(clusterName, InitialState, 0, 0, 0, "")
+		} else {
+			if previous.Target != cluster.Target {
+				plan.addChange("cluster", name, targetChange(cluster.Target), previous.Target, cluster.Target)
+			}
+			if previous.Configuration != cluster.Configuration {
+				plan.addChange("cluster", name, "reconfigure", previous.Configuration, cluster.Configuration)
+			}
+		}
+
+		// sizes have changed
+		if previous.Min != cluster.Min || previous.Max != cluster.Max || previous.Size != cluster.Size {
+			plan.addChange("cluster", name, "resize", sizes(previous), sizes(cluster))
+		}
+
+		// relationships have changed
+		relationshipNames, _ := cluster.ListRelationships()
+		sort.Strings(relationshipNames)
+
+		for _, relationshipName := range relationshipNames {
+			relationship, _ := cluster.GetRelationship(relationshipName)
+			before, _       := previous.GetRelationship(relationshipName)
+
+			if before == nil {
+				plan.addChange("relationship", name + "/" + relationshipName, "create", "", relationship.Element + " - " + relationship.Version)
+			} else if before.Configuration != relationship.Configuration {
+				plan.addChange("relationship", name + "/" + relationshipName, "reconfigure", before.Configuration, relationship.Configuration)
+			}
+		}
+
+		// name the new instances
+		instanceNames, _ := cluster.ListInstances()
+		sort.Strings(instanceNames)
+
+		for _, instanceName := range instanceNames {
+			if instance, _ := previous.GetInstance(instanceName); instance == nil {
+				names[instanceName] = "new-" + strconv.Itoa(len(names) + 1)
+			}
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// simulate determines the sequence of instance transitions which the engine
+// would issue to converge the updated solution to its target state.
+func (plan *Plan) simulate(solution *Solution, names map[string]string) {
+	visited := map[string]bool{}
+
+	elementNames, _ := solution.ListElements()
+	sort.Strings(elementNames)
+
+	for _, elementName := range elementNames {
+		element, _ := solution.GetElement(elementName)
+
+		// an upgrade ends with the sizes of the configuration
+		if element.upgrading() {
+			from, _ := element.GetCluster(element.Upgrade.From)
+			to, _   := element.GetCluster(element.Upgrade.To)
+
+			if from != nil && to != nil {
+				to.Resize(element.Upgrade.Min, element.Upgrade.Max, element.Upgrade.Size)
+				from.Reset()
+			}
+		}
+
+		clusterNames, _ := element.ListClusters()
+		sort.Strings(clusterNames)
+
+		for _, clusterName := range clusterNames {
+			plan.simulateCluster(solution, elementName, clusterName, names, visited)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// simulateCluster determines the transitions of the instances of a cluster
+// after the clusters it depends on have been simulated.
+func (plan *Plan) simulateCluster(solution *Solution, elementName string, clusterName string, names map[string]string, visited map[string]bool) {
+	key := elementName + "/" + clusterName
+	if visited[key] {
+		return
+	}
+	visited[key] = true
+
+	element, _ := solution.GetElement(elementName)
+	if element == nil {
+		return
+	}
+
+	cluster, _ := element.GetCluster(clusterName)
+	if cluster == nil {
+		return
+	}
+
+	// dependencies within the solution need to be established first
+	if cluster.Target == InactiveState || cluster.Target == ActiveState {
+		relationshipNames, _ := cluster.ListRelationships()
+		sort.Strings(relationshipNames)
+
+		for _, relationshipName := range relationshipNames {
+			relationship, _ := cluster.GetRelationship(relationshipName)
+
+			if relationship.Type == ContextRelationship || (relationship.Type == ServiceRelationship && cluster.Target == ActiveState) {
+				plan.simulateCluster(solution, relationship.Element, relationship.Version, names, visited)
+			}
+		}
+	}
+
+	// adjust the instances one by one
+	for steps := 0; steps < 1000; steps++ {
+		instanceName, state, found := cluster.Step()
+		if !found {
+			break
+		}
+
+		instance, _ := cluster.GetInstance(instanceName)
+
+		transition, err := GetTransition(instance.State, state)
+		if err != nil {
+			transition = "invalid"
+		}
+
+		name, ok := names[instanceName]
+		if !ok {
+			name = instanceName
+		}
+
+		plan.Transitions = append(plan.Transitions, &PlanTransition{
+			Element:    elementName,
+			Cluster:    clusterName,
+			Instance:   name,
+			From:       instance.State,
+			To:         state,
+			Transition: transition,
+		})
+
+		// assume that the transition succeeds
+		instance.State = state
+	}
+
+	cluster.State = cluster.Target
+}
+
+//------------------------------------------------------------------------------
+
+// targetChange classifies the change of a target state.
+func targetChange(target string) string {
+	if target == InitialState {
+		return "reset"
+	}
+
+	return "update"
+}
+
+//------------------------------------------------------------------------------
+
+// sizes formats the sizes of a cluster.
+func sizes(cluster *Cluster) string {
+	return "min: " + strconv.Itoa(cluster.Min) + ", max: " + strconv.Itoa(cluster.Max) + ", size: " + strconv.Itoa(cluster.Size)
+}
+
+//------------------------------------------------------------------------------
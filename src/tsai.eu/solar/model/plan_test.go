@@ -0,0 +1,90 @@
+package model
+
+import (
+	"testing"
+	"reflect"
+)
+
+//------------------------------------------------------------------------------
+
+// TestPlan01 tests the plan of a deployment of an existing solution.
+func TestPlan01(t *testing.T) {
+	model := GetModel()
+
+	model.Load("testdata/testdata1.yaml")
+
+	domain, _       := GetDomain("demo")
+	architecture, _ := GetArchitecture("demo", "app", "V0.0.0")
+
+	if _, err := NewPlan("unknown", architecture); err == nil {
+		t.Errorf("NewPlan should have complained about an unknown domain")
+	}
+
+	solution, _ := domain.GetSolution("app")
+	before      := solution.snapshot()
+
+	plan, err := NewPlan("demo", architecture)
+	if err != nil {
+		t.Fatalf("NewPlan should have determined a plan:\n%s", err)
+	}
+
+	if plan.Solution != "app" || plan.Version != solution.Version || plan.Architecture != "V0.0.0" {
+		t.Errorf("<plan> should have referred to the solution and the architecture")
+	}
+
+	if !reflect.DeepEqual(before, solution.snapshot()) {
+		t.Errorf("NewPlan should not have modified the solution")
+	}
+
+	if _, err = plan.Show(); err != nil {
+		t.Errorf("<plan>.Show should have displayed the plan:\n%s", err)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// TestPlan02 tests the plan of a deployment of a new solution.
+func TestPlan02(t *testing.T) {
+	model := GetModel()
+
+	model.Load("testdata/testdata1.yaml")
+
+	domain, _       := GetDomain("demo")
+	architecture, _ := GetArchitecture("demo", "app", "V0.0.0")
+
+	domain.DeleteSolution("app")
+
+	plan, err := NewPlan("demo", architecture)
+	if err != nil {
+		t.Fatalf("NewPlan should have determined a plan:\n%s", err)
+	}
+
+	if _, err = domain.GetSolution("app"); err == nil {
+		t.Errorf("NewPlan should not have created the solution")
+	}
+
+	if len(plan.Changes) == 0 || plan.Changes[0].Entity != "solution" || plan.Changes[0].Change != "create" {
+		t.Errorf("<plan> should have started with the creation of the solution")
+	}
+
+	// all instances need to be created before they can be started
+	states := map[string]string{}
+	for _, transition := range plan.Transitions {
+		if states[transition.Instance] != transition.From && !(states[transition.Instance] == "" && transition.From == InitialState) {
+			t.Errorf("<plan> contains an inconsistent transition of instance: %s", transition.Instance)
+		}
+		states[transition.Instance] = transition.To
+	}
+
+	for instance, state := range states {
+		if state != ActiveState {
+			t.Errorf("<plan> should have activated instance: %s", instance)
+		}
+	}
+
+	if len(states) == 0 {
+		t.Errorf("<plan> should have contained transitions of new instances")
+	}
+}
+
+//------------------------------------------------------------------------------
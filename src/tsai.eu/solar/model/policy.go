@@ -0,0 +1,152 @@
+package model
+
+import (
+	"time"
+	"errors"
+)
+
+//------------------------------------------------------------------------------
+// Policy
+// ======
+//
+// Attributes:
+//   - Timeout
+//   - Retries
+//   - Backoff
+//
+// Functions:
+//   - GetPolicy
+//
+//   - policy.Merge
+//   - policy.Validate
+//   - policy.GetTimeout
+//   - policy.GetRetries
+//   - policy.GetBackoff
+//   - policy.GetDelay
+//------------------------------------------------------------------------------
+
+// DefaultTimeout defines the time a controller may take to perform an operation.
+const DefaultTimeout time.Duration = 10 * time.Second
+
+// DefaultBackoff defines the delay before the first retry of an operation.
+const DefaultBackoff time.Duration = 1 * time.Second
+
+//------------------------------------------------------------------------------
+
+// Policy describes how long the operations of a controller may take and how
+// often they are retried. Undefined settings are inherited from the component.
+// The number of retries is a pointer to distinguish an explicit 0 from an
+// undefined setting.
+type Policy struct {
+	Timeout string `yaml:"Timeout,omitempty"` // max. duration of a controller operation including its retries, e.g. "5m"
+	Retries *int   `yaml:"Retries,omitempty"` // max. number of retries of a failed operation
+	Backoff string `yaml:"Backoff,omitempty"` // delay before the first retry, doubled for every further retry, e.g. "2s"
+}
+
+//------------------------------------------------------------------------------
+
+// GetPolicy determines the policy of a cluster by merging the policy of its
+// component with the settings of the cluster.
+func GetPolicy(domainName string, solutionName string, elementName string, clusterName string) (Policy, error) {
+	cluster, err := GetCluster(domainName, solutionName, elementName, clusterName)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	component, err := GetComponent2(domainName, solutionName, elementName, clusterName)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	// success
+	return component.Policy.Merge(cluster.Policy), nil
+}
+
+//------------------------------------------------------------------------------
+
+// Merge overrides the settings of a policy with the defined settings of another policy.
+func (policy Policy) Merge(override Policy) Policy {
+	if override.Timeout != "" {
+		policy.Timeout = override.Timeout
+	}
+	if override.Retries != nil {
+		policy.Retries = override.Retries
+	}
+	if override.Backoff != "" {
+		policy.Backoff = override.Backoff
+	}
+
+	return policy
+}
+
+//------------------------------------------------------------------------------
+
+// Validate checks the settings of a policy.
+func (policy Policy) Validate() error {
+	if policy.Timeout != "" {
+		if timeout, err := time.ParseDuration(policy.Timeout); err != nil || timeout <= 0 {
+			return errors.New("invalid timeout: " + policy.Timeout)
+		}
+	}
+	if policy.Retries != nil && *policy.Retries < 0 {
+		return errors.New("invalid number of retries")
+	}
+	if policy.Backoff != "" {
+		if backoff, err := time.ParseDuration(policy.Backoff); err != nil || backoff < 0 {
+			return errors.New("invalid backoff: " + policy.Backoff)
+		}
+	}
+
+	// success
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// GetTimeout delivers the max. duration of a controller operation.
+func (policy Policy) GetTimeout() time.Duration {
+	timeout, err := time.ParseDuration(policy.Timeout)
+	if err != nil || timeout <= 0 {
+		return DefaultTimeout
+	}
+
+	return timeout
+}
+
+//------------------------------------------------------------------------------
+
+// GetRetries delivers the max. number of retries of a failed operation.
+func (policy Policy) GetRetries() int {
+	if policy.Retries == nil || *policy.Retries < 0 {
+		return 0
+	}
+
+	return *policy.Retries
+}
+
+//------------------------------------------------------------------------------
+
+// GetBackoff delivers the delay before the first retry.
+func (policy Policy) GetBackoff() time.Duration {
+	backoff, err := time.ParseDuration(policy.Backoff)
+	if err != nil || backoff < 0 {
+		return DefaultBackoff
+	}
+
+	return backoff
+}
+
+//------------------------------------------------------------------------------
+
+// GetDelay delivers the delay before a retry (the first retry has the number 0).
+func (policy Policy) GetDelay(retry int) time.Duration {
+	delay := policy.GetBackoff()
+
+	for i := 0; i < retry && delay < time.Hour; i++ {
+		delay = delay * 2
+	}
+
+	return delay
+}
+
+//------------------------------------------------------------------------------
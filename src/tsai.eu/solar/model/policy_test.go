@@ -0,0 +1,86 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+//------------------------------------------------------------------------------
+
+// TestPolicy01 tests the basic functions of a policy.
+func TestPolicy01(t *testing.T) {
+	retries := 3
+	policy  := Policy{Timeout: "5m", Retries: &retries, Backoff: "2s"}
+
+	if err := policy.Validate(); err != nil {
+		t.Errorf("<policy>.Validate should have accepted a valid policy:\n%s", err)
+	}
+
+	if err := (Policy{Timeout: "soon"}).Validate(); err == nil {
+		t.Errorf("<policy>.Validate should have complained about an invalid timeout")
+	}
+
+	invalid := -1
+	if err := (Policy{Retries: &invalid}).Validate(); err == nil {
+		t.Errorf("<policy>.Validate should have complained about an invalid number of retries")
+	}
+
+	component, _ := NewComponent("server", "V1.0.0", "", "Internal:V1.0.0")
+	component.Policy = Policy{Backoff: "-1s"}
+
+	if err := component.Validate(); err == nil {
+		t.Errorf("<component>.Validate should have complained about an invalid policy")
+	}
+
+	if policy.GetTimeout() != 5 * time.Minute || policy.GetDelay(0) != 2 * time.Second || policy.GetDelay(2) != 8 * time.Second {
+		t.Errorf("<policy> should have delivered the configured durations")
+	}
+
+	if (Policy{}).GetTimeout() != DefaultTimeout || (Policy{}).GetBackoff() != DefaultBackoff {
+		t.Errorf("<policy> should have delivered the default durations")
+	}
+
+	merged := policy.Merge(Policy{Timeout: "1h"})
+	if merged.Timeout != "1h" || merged.GetRetries() != 3 || merged.Backoff != "2s" {
+		t.Errorf("<policy>.Merge should only have overridden the defined settings")
+	}
+
+	none   := 0
+	merged  = policy.Merge(Policy{Retries: &none})
+	if merged.GetRetries() != 0 || (Policy{}).GetRetries() != 0 {
+		t.Errorf("<policy>.Merge should have accepted an explicit number of 0 retries")
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// TestPolicy02 tests the determination of the policy of a cluster.
+func TestPolicy02(t *testing.T) {
+	model := GetModel()
+
+	model.Load("testdata/testdata1.yaml")
+
+	_, err := GetPolicy("demo", "app", "app", "V2.0.0")
+	if err == nil {
+		t.Errorf("GetPolicy should have complained about a non existing cluster")
+	}
+
+	component, _ := GetComponent2("demo", "app", "app", "V1.0.0")
+	cluster, _   := GetCluster("demo", "app", "app", "V1.0.0")
+
+	componentRetries, clusterRetries := 3, 5
+
+	component.Policy = Policy{Timeout: "5m", Retries: &componentRetries}
+	cluster.Policy   = Policy{Retries: &clusterRetries}
+
+	policy, err := GetPolicy("demo", "app", "app", "V1.0.0")
+	if err != nil {
+		t.Errorf("GetPolicy should have determined the policy of the cluster:\n%s", err)
+	}
+
+	if policy.Timeout != "5m" || policy.GetRetries() != 5 {
+		t.Errorf("GetPolicy should have merged the policies of the component and the cluster")
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -2,9 +2,7 @@ package model
 
 import (
 	"errors"
-	"strings"
 	"strconv"
-	"regexp"
 
 	"tsai.eu/solar/util"
 )
@@ -31,6 +29,8 @@ import (
 //   - relationship.Load
 //   - relationship.Save
 //   - relationship.Reset
+//   - relationship.OK
+//   - relationship.SetState
 //------------------------------------------------------------------------------
 
 // Relationship describes the runtime configuration of a relationship between clusters within a domain.
@@ -42,10 +42,10 @@ type Relationship struct {
 	Solution      string  `yaml:"Solution"`      // solution to which this relationship refers to
 	Element       string  `yaml:"Element"`       // element to which this relationship refers to
 	Version       string  `yaml:"Version"`       // version of the element to which this relationship refers to
-	Target        string  `yaml:"Target"`        // target state of relationship
-	State         string  `yaml:"State"`         // current state of relationship
+	Target        string  `yaml:"Target"`        // target state of relationship (active if bound)
+	State         string  `yaml:"State"`         // current state of relationship (active if bound)
 	Configuration string  `yaml:"Configuration"` // runtime configuration of the relationship
-	Endpoint      string  `yaml:"Endpoint"`      // endpoint of the relationship
+	Endpoint      string  `yaml:"Endpoint"`      // endpoint of the related cluster the relationship has been bound to
 }
 
 //------------------------------------------------------------------------------
@@ -73,19 +73,19 @@ func NewRelationship(name string, dependency string, dependencyType string, doma
 //------------------------------------------------------------------------------
 
 // renderConfiguration calculates the configuration from the component template and the parameters defined in the relationshipConfiguration.
-func (relationship *Relationship) renderConfiguration(domainName string, solutionName string, version string, element *Element, cluster *Cluster, relationshipConfiguration *RelationshipConfiguration) {
+func (relationship *Relationship) renderConfiguration(domainName string, solutionName string, version string, element *Element, cluster *Cluster, relationshipConfiguration *RelationshipConfiguration) error {
 	// determine component
 	component, err := GetComponent(domainName, element.Component, cluster.Version)
 	if err != nil {
 		util.LogError("relationship", "MODEL", "unknown component '" + element.Component + " - " + cluster.Version + "' within domain: '" + domainName + "'")
-		return
+		return nil
 	}
 
 	// determine dependency
 	dependency, err := component.GetDependency(relationshipConfiguration.Dependency)
 	if err != nil {
 		util.LogError("relationship", "MODEL", "unknown dependency '" + element.Component + " - " + cluster.Version + " / " + relationshipConfiguration.Relationship + "' within domain: '" + domainName + "'")
-		return
+		return nil
 	}
 
 	// get parameters
@@ -110,24 +110,22 @@ func (relationship *Relationship) renderConfiguration(domainName string, solutio
 	parameters["size"]         = strconv.Itoa(cluster.Size)
 	parameters["relationship"] = relationshipConfiguration.Relationship
 
-	// determine all required parameters
-	configuration := dependency.Configuration
-	r := regexp.MustCompile(`{{([^}]*)}}`)
-	matches := r.FindAllStringSubmatch(configuration, -1)
-	if matches != nil {
-		for _, match := range matches {
-			name := match[1]
-			key  := strings.TrimSpace(name)
-
-			value, ok := parameters[key]
-			if ok {
-				configuration = strings.Replace(configuration, "{{" + name + "}}", value, -1)
-			}
-		}
+	// render the template of the dependency
+	context := newTemplateContext(domainName, solutionName, version, cluster)
+	if target, err := GetCluster(domainName, solutionName, relationshipConfiguration.Element, relationshipConfiguration.Version); err == nil {
+		context["Target"] = clusterContext(relationshipConfiguration.Element, target)
+	}
+
+	configuration, err := RenderTemplate(dependency.Configuration, dependency.Parameters, parameters, context)
+	if err != nil {
+		return errors.New("invalid parameters of the relationship: '" + element.Element + " - " + cluster.Version + " / " + relationshipConfiguration.Relationship + "'\n" + err.Error())
 	}
 
 	// set conifguration of relationship
 	relationship.Configuration = configuration
+
+	// success
+	return nil
 }
 
 //------------------------------------------------------------------------------
@@ -140,10 +138,7 @@ func (relationship *Relationship) Update(domainName string, solutionName string,
 	}
 
 	// update configuration
-	relationship.renderConfiguration(domainName, solutionName, version, element, cluster, relationshipConfiguration)
-
-	// success
-	return nil
+	return relationship.renderConfiguration(domainName, solutionName, version, element, cluster, relationshipConfiguration)
 }
 
 //------------------------------------------------------------------------------
@@ -175,3 +170,24 @@ func (relationship *Relationship) Reset() {
 }
 
 //------------------------------------------------------------------------------
+
+// OK checks if the relationship has reached its target state
+func (relationship *Relationship) OK() bool {
+	return relationship.Target == relationship.State
+}
+
+//------------------------------------------------------------------------------
+
+// SetState updates the current state of the relationship and the endpoint of
+// the related cluster it has been bound to.
+func (relationship *Relationship) SetState(newState string, endpoint string) {
+	if newState == InitialState || newState == ActiveState {
+		relationship.State    = newState
+		relationship.Endpoint = endpoint
+
+		// persist modification
+		Persist()
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -30,6 +30,25 @@ func TestRelationship02(t *testing.T) {
 	relationship, _ := NewRelationship("Tenant", "tenant", "context", "demo", "app", "Tenant", "V1.0.0", "")
 
 	relationship.Reset()
+
+	if !relationship.OK() {
+		t.Errorf("<relationship>.OK should have confirmed the initial state")
+	}
+
+	relationship.Target = ActiveState
+	if relationship.OK() {
+		t.Errorf("<relationship>.OK should have detected the unbound relationship")
+	}
+
+	relationship.SetState(ActiveState, "Host: db")
+	if !relationship.OK() || relationship.Endpoint != "Host: db" {
+		t.Errorf("<relationship>.SetState should have bound the relationship")
+	}
+
+	relationship.SetState(FailureState, "")
+	if relationship.State != ActiveState {
+		t.Errorf("<relationship>.SetState should have ignored an invalid state")
+	}
 }
 
 //------------------------------------------------------------------------------
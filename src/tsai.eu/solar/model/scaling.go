@@ -0,0 +1,161 @@
+package model
+
+import (
+	"math"
+	"time"
+	"errors"
+	"strconv"
+)
+
+//------------------------------------------------------------------------------
+// Scaling
+// =======
+//
+// Attributes:
+//   - Metric
+//   - Target
+//   - Upper
+//   - Lower
+//   - Step
+//   - Cooldown
+//
+// Functions:
+//   - scaling.Validate
+//   - scaling.GetStep
+//   - scaling.GetCooldown
+//   - scaling.Evaluate
+//------------------------------------------------------------------------------
+
+// DefaultCooldown defines the min. time between two scaling decisions of a cluster.
+const DefaultCooldown time.Duration = 1 * time.Minute
+
+// MaxMetricAge defines the time after which a reported metric is ignored.
+const MaxMetricAge time.Duration = 5 * time.Minute
+
+//------------------------------------------------------------------------------
+
+// Scaling describes how the size of a cluster follows a metric reported for
+// its instances. The cluster is scaled out if the metric exceeds the upper
+// threshold (or the target value if no threshold is defined) and scaled in if
+// it falls below the lower threshold (or the target value). With a target value
+// the size is adjusted proportionally, otherwise by the step size.
+type Scaling struct {
+	Metric   string  `yaml:"Metric"`             // name of the metric, e.g. "cpu"
+	Target   float64 `yaml:"Target,omitempty"`   // desired value of the metric (average per instance)
+	Upper    float64 `yaml:"Upper,omitempty"`    // threshold above which the cluster is scaled out
+	Lower    float64 `yaml:"Lower,omitempty"`    // threshold below which the cluster is scaled in
+	Step     int     `yaml:"Step,omitempty"`     // max. number of instances added or removed at once (default 1)
+	Cooldown string  `yaml:"Cooldown,omitempty"` // min. time between two scaling decisions, e.g. "5m" (default 1m)
+}
+
+//------------------------------------------------------------------------------
+
+// Measurement describes the latest reported value of a metric.
+type Measurement struct {
+	Value    float64 `yaml:"Value"`    // reported value
+	Reported int64   `yaml:"Reported"` // time of the report since 1.1.1970 in nsecs
+}
+
+//------------------------------------------------------------------------------
+
+// Fresh checks if a measurement has been reported after a point in time
+// (e.g. the latest scaling decision) and is not older than the max. age.
+func (measurement Measurement) Fresh(since int64) bool {
+	return measurement.Reported > since && time.Since(time.Unix(0, measurement.Reported)) <= MaxMetricAge
+}
+
+//------------------------------------------------------------------------------
+
+// Validate checks the settings of a scaling policy.
+func (scaling *Scaling) Validate() error {
+	if scaling.Metric == "" {
+		return errors.New("scaling metric is undefined")
+	}
+	if scaling.Target < 0 || scaling.Upper < 0 || scaling.Lower < 0 {
+		return errors.New("scaling target and thresholds must not be negative")
+	}
+	if scaling.Target == 0 && (scaling.Upper == 0 || scaling.Lower == 0) {
+		return errors.New("scaling requires a target value or an upper and a lower threshold")
+	}
+	if scaling.Upper != 0 && scaling.Lower != 0 && scaling.Lower >= scaling.Upper {
+		return errors.New("lower scaling threshold must be below the upper threshold")
+	}
+	if scaling.Step < 0 {
+		return errors.New("invalid scaling step: " + strconv.Itoa(scaling.Step))
+	}
+	if scaling.Cooldown != "" {
+		if cooldown, err := time.ParseDuration(scaling.Cooldown); err != nil || cooldown < 0 {
+			return errors.New("invalid scaling cooldown: " + scaling.Cooldown)
+		}
+	}
+
+	// success
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// GetStep delivers the max. number of instances added or removed at once.
+func (scaling *Scaling) GetStep() int {
+	if scaling.Step <= 0 {
+		return 1
+	}
+
+	return scaling.Step
+}
+
+//------------------------------------------------------------------------------
+
+// GetCooldown delivers the min. time between two scaling decisions.
+func (scaling *Scaling) GetCooldown() time.Duration {
+	cooldown, err := time.ParseDuration(scaling.Cooldown)
+	if err != nil || cooldown < 0 {
+		return DefaultCooldown
+	}
+
+	return cooldown
+}
+
+//------------------------------------------------------------------------------
+
+// Evaluate determines the desired size of a cluster for the current value of
+// the metric. The size is limited by the step size and the range min..max.
+func (scaling *Scaling) Evaluate(value float64, size int, min int, max int) int {
+	upper := scaling.Upper
+	if upper == 0 {
+		upper = scaling.Target
+	}
+
+	lower := scaling.Lower
+	if lower == 0 {
+		lower = scaling.Target
+	}
+
+	step    := scaling.GetStep()
+	desired := size
+
+	switch {
+	case value > upper:
+		desired = size + step
+		if scaling.Target > 0 && size > 0 {
+			desired = int(math.Min(float64(desired), math.Ceil(float64(size) * value / scaling.Target)))
+		}
+	case value < lower:
+		desired = size - step
+		if scaling.Target > 0 {
+			desired = int(math.Max(float64(desired), math.Ceil(float64(size) * value / scaling.Target)))
+		}
+	}
+
+	// keep the size within the limits of the cluster
+	if desired > max {
+		desired = max
+	}
+	if desired < min {
+		desired = min
+	}
+
+	return desired
+}
+
+//------------------------------------------------------------------------------
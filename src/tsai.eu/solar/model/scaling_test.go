@@ -0,0 +1,81 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+//------------------------------------------------------------------------------
+
+// TestScaling01 tests the validation of a scaling policy.
+func TestScaling01(t *testing.T) {
+	scaling := Scaling{Metric: "cpu", Upper: 80, Lower: 30, Step: 2, Cooldown: "5m"}
+
+	if err := scaling.Validate(); err != nil {
+		t.Errorf("<scaling>.Validate should have accepted a valid policy:\n%s", err)
+	}
+
+	if err := (&Scaling{Target: 60}).Validate(); err == nil {
+		t.Errorf("<scaling>.Validate should have complained about an undefined metric")
+	}
+
+	if err := (&Scaling{Metric: "cpu", Upper: 80}).Validate(); err == nil {
+		t.Errorf("<scaling>.Validate should have complained about a missing lower threshold")
+	}
+
+	if err := (&Scaling{Metric: "cpu", Upper: 30, Lower: 80}).Validate(); err == nil {
+		t.Errorf("<scaling>.Validate should have complained about inverted thresholds")
+	}
+
+	if err := (&Scaling{Metric: "cpu", Target: 60, Cooldown: "soon"}).Validate(); err == nil {
+		t.Errorf("<scaling>.Validate should have complained about an invalid cooldown")
+	}
+
+	if scaling.GetStep() != 2 || scaling.GetCooldown() != 5 * time.Minute {
+		t.Errorf("<scaling> should have delivered the configured step and cooldown")
+	}
+
+	if (&Scaling{}).GetStep() != 1 || (&Scaling{}).GetCooldown() != DefaultCooldown {
+		t.Errorf("<scaling> should have delivered the default step and cooldown")
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// TestScaling02 tests the evaluation of a scaling policy.
+func TestScaling02(t *testing.T) {
+	thresholds := Scaling{Metric: "cpu", Upper: 80, Lower: 30}
+
+	if size := thresholds.Evaluate(90, 2, 1, 5); size != 3 {
+		t.Errorf("<scaling>.Evaluate should have scaled out by one instance: %d", size)
+	}
+
+	if size := thresholds.Evaluate(10, 2, 1, 5); size != 1 {
+		t.Errorf("<scaling>.Evaluate should have scaled in by one instance: %d", size)
+	}
+
+	if size := thresholds.Evaluate(50, 2, 1, 5); size != 2 {
+		t.Errorf("<scaling>.Evaluate should have kept the size: %d", size)
+	}
+
+	if size := thresholds.Evaluate(90, 5, 1, 5); size != 5 {
+		t.Errorf("<scaling>.Evaluate should have respected the max. size: %d", size)
+	}
+
+	target := Scaling{Metric: "cpu", Target: 50, Step: 10}
+
+	if size := target.Evaluate(100, 2, 1, 10); size != 4 {
+		t.Errorf("<scaling>.Evaluate should have scaled out proportionally: %d", size)
+	}
+
+	if size := target.Evaluate(10, 4, 2, 10); size != 2 {
+		t.Errorf("<scaling>.Evaluate should have respected the min. size: %d", size)
+	}
+
+	target.Step = 1
+	if size := target.Evaluate(100, 2, 1, 10); size != 3 {
+		t.Errorf("<scaling>.Evaluate should have respected the step size: %d", size)
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,154 @@
+package model
+
+import (
+	"sort"
+	"errors"
+	"regexp"
+	"strings"
+	"strconv"
+
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+// Secret
+// ======
+//
+// Secrets are stored encrypted within a domain and are referenced by
+// configurations with the syntax {{secret:<name>}}. The references are only
+// resolved when the target state of an instance is passed to a controller.
+//
+// Functions:
+//   - domain.ListSecrets
+//   - domain.SetSecret
+//   - domain.DeleteSecret
+//   - domain.ResolveSecrets
+//   - RedactReferences
+//------------------------------------------------------------------------------
+
+// secretReference matches the references to secrets within a configuration.
+var secretReference = regexp.MustCompile(`{{\s*secret:([^}\s]+)\s*}}`)
+
+//------------------------------------------------------------------------------
+
+// ListSecrets lists the names of all secrets of a domain
+func (domain *Domain) ListSecrets() ([]string, error) {
+	// collect names
+	secrets := []string{}
+
+	domain.SecretsX.RLock()
+	for secret := range domain.Secrets {
+		secrets = append(secrets, secret)
+	}
+	domain.SecretsX.RUnlock()
+
+	sort.Strings(secrets)
+
+	// success
+	return secrets, nil
+}
+
+//------------------------------------------------------------------------------
+
+// SetSecret encrypts and stores the value of a secret
+func (domain *Domain) SetSecret(name string, value string) error {
+	if name == "" || strings.ContainsAny(name, "{} \t\n") {
+		return errors.New("invalid name of secret")
+	}
+
+	if len(value) < util.MinSecretLength {
+		return errors.New("the value of a secret requires at least " + strconv.Itoa(util.MinSecretLength) + " characters")
+	}
+
+	encrypted, err := util.Encrypt(value)
+	if err != nil {
+		return errors.New("unable to encrypt secret:\n" + err.Error())
+	}
+
+	domain.SecretsX.Lock()
+	if domain.Secrets == nil {
+		domain.Secrets = map[string]string{}
+	}
+	domain.Secrets[name] = encrypted
+	domain.SecretsX.Unlock()
+
+	// persist modification
+	Persist()
+
+	// success
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// DeleteSecret deletes a secret
+func (domain *Domain) DeleteSecret(name string) error {
+	// determine secret
+	domain.SecretsX.RLock()
+	_, ok := domain.Secrets[name]
+	domain.SecretsX.RUnlock()
+
+	if !ok {
+		return errors.New("secret not found")
+	}
+
+	// remove secret
+	domain.SecretsX.Lock()
+	delete(domain.Secrets, name)
+	domain.SecretsX.Unlock()
+
+	// persist modification
+	Persist()
+
+	// success
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// ResolveSecrets replaces the references to secrets within a configuration by
+// their values. The values are registered for redaction from any output.
+func (domain *Domain) ResolveSecrets(configuration string) (string, error) {
+	var err error
+
+	result := secretReference.ReplaceAllStringFunc(configuration, func(match string) string {
+		name := secretReference.FindStringSubmatch(match)[1]
+
+		domain.SecretsX.RLock()
+		encrypted, ok := domain.Secrets[name]
+		domain.SecretsX.RUnlock()
+
+		if !ok {
+			err = errors.New("unknown secret: " + name)
+			return match
+		}
+
+		value, decryptErr := util.Decrypt(encrypted)
+		if decryptErr != nil {
+			err = errors.New("unable to decrypt secret: " + name)
+			return match
+		}
+
+		util.AddRedaction(value)
+
+		return value
+	})
+
+	if err != nil {
+		return configuration, err
+	}
+
+	// success
+	return result, nil
+}
+
+//------------------------------------------------------------------------------
+
+// RedactReferences replaces the references to secrets within a configuration
+// in the same way as the values of resolved secrets are redacted. This allows
+// to compare a configuration with the configuration reported by a controller.
+func RedactReferences(configuration string) string {
+	return secretReference.ReplaceAllString(configuration, util.Redacted)
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,105 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// TestSecret01 tests the basic functions of the secret store.
+func TestSecret01(t *testing.T) {
+	domain, _ := NewDomain("secrets")
+
+	if err := domain.SetSecret("{token}", "value"); err == nil {
+		t.Errorf("<domain>.SetSecret should have complained about an invalid name")
+	}
+
+	if err := domain.SetSecret("token", "short"); err == nil {
+		t.Errorf("<domain>.SetSecret should have complained about a value shorter than the min. length")
+	}
+
+	if err := domain.SetSecret("token", "TestSecret01-value"); err != nil {
+		t.Fatalf("<domain>.SetSecret should have stored the secret:\n%s", err)
+	}
+
+	if secrets, _ := domain.ListSecrets(); len(secrets) != 1 || secrets[0] != "token" {
+		t.Errorf("<domain>.ListSecrets should have listed the secret")
+	}
+
+	if strings.Contains(domain.Secrets["token"], "TestSecret01-value") {
+		t.Errorf("<domain>.SetSecret should have encrypted the secret")
+	}
+
+	result, err := domain.ResolveSecrets("token: {{ secret:token }}")
+	if err != nil || result != "token: TestSecret01-value" {
+		t.Errorf("<domain>.ResolveSecrets should have resolved the reference:\n%s", result)
+	}
+
+	if util.Redact(result) != "token: " + util.Redacted {
+		t.Errorf("<domain>.ResolveSecrets should have registered the value for redaction")
+	}
+
+	if _, err = domain.ResolveSecrets("{{secret:unknown}}"); err == nil {
+		t.Errorf("<domain>.ResolveSecrets should have complained about an unknown secret")
+	}
+
+	if err = domain.DeleteSecret("token"); err != nil {
+		t.Errorf("<domain>.DeleteSecret should have deleted the secret")
+	}
+
+	if err = domain.DeleteSecret("token"); err == nil {
+		t.Errorf("<domain>.DeleteSecret should have complained about an unknown secret")
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// TestSecret02 tests the resolution of secrets in the target state.
+func TestSecret02(t *testing.T) {
+	model := GetModel()
+
+	model.Load("testdata/testdata1.yaml")
+
+	domain, _       := GetDomain("demo")
+	architecture, _ := GetArchitecture("demo", "app", "V0.0.0")
+	element, _      := architecture.GetElement("ext")
+	cluster, _      := element.GetCluster("V1.0.0")
+
+	cluster.Configuration = "password: {{secret:password}}"
+
+	solutionCluster, _ := GetCluster("demo", "app", "ext", "V1.0.0")
+	solutionCluster.Configuration = cluster.Configuration
+
+	// templates keep the references
+	rendered, _ := RenderTemplate("password: {{secret:password}}", nil, map[string]string{}, nil)
+	if rendered != "password: {{secret:password}}" {
+		t.Errorf("RenderTemplate should have kept the reference to the secret: %s", rendered)
+	}
+
+	instanceNames, _ := solutionCluster.ListInstances()
+
+	if _, err := GetTargetState("demo", "app", "V0.0.0", "ext", "V1.0.0", instanceNames[0]); err != ErrSecret {
+		t.Errorf("GetTargetState should have complained about an unknown secret")
+	}
+
+	domain.SetSecret("password", "TestSecret02-value")
+
+	targetState, err := GetTargetState("demo", "app", "V0.0.0", "ext", "V1.0.0", instanceNames[0])
+	if err != nil || targetState.Configuration != "password: TestSecret02-value" {
+		t.Errorf("GetTargetState should have resolved the secret:\n%s", targetState.Configuration)
+	}
+
+	instance, _ := solutionCluster.GetInstance(instanceNames[0])
+	if strings.Contains(instance.Configuration, "TestSecret02-value") {
+		t.Errorf("GetTargetState should not have stored the value of the secret")
+	}
+
+	if output, _ := domain.Show(); strings.Contains(output, "TestSecret02-value") {
+		t.Errorf("<domain>.Show should not have revealed the value of the secret")
+	}
+}
+
+//------------------------------------------------------------------------------
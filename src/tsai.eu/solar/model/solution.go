@@ -84,6 +84,28 @@ func GetTransition(currentState string, targetState string) (string, error) {
 	return transition, nil
 }
 
+// GetReconfiguration determines the transition required to apply a changed
+// configuration to an instance. Active instances are reconfigured and inactive
+// instances are configured unless the component requires the replacement of
+// its instances.
+func GetReconfiguration(currentState string, replace bool) (string, error) {
+	// check parameters
+	if currentState != InactiveState && currentState != ActiveState {
+		return "", errors.New("invalid state")
+	}
+
+	// determine transition
+	switch {
+	case replace:
+		return "replace", nil
+	case currentState == ActiveState:
+		return "reconfigure", nil
+	}
+
+	//success
+	return "configure", nil
+}
+
 //------------------------------------------------------------------------------
 // Solution
 // ========
@@ -95,6 +117,7 @@ func GetTransition(currentState string, targetState string) (string, error) {
 //   - State
 //   - Configuration
 //   - Elements
+//   - History
 //
 // Functions:
 //   - NewSolution
@@ -121,6 +144,8 @@ type Solution struct {
 	Configuration  string              `yaml:"Configuration"`       // configuration of solution
 	Elements       map[string]*Element `yaml:"Elements"`            // elements of solution
 	ElementsX      sync.RWMutex        `yaml:"ElementsX,omitempty"` // mutex for elements
+	History        []*Deployment       `yaml:"History,omitempty"`   // deployments of architectures
+	HistoryX       sync.RWMutex        `yaml:"HistoryX,omitempty"`  // mutex for history
 }
 
 //------------------------------------------------------------------------------
@@ -136,6 +161,8 @@ func NewSolution(name string, version string, configuration string) (*Solution,
 	solution.Configuration = configuration
 	solution.Elements      = map[string]*Element{}
 	solution.ElementsX     = sync.RWMutex{}
+	solution.History       = []*Deployment{}
+	solution.HistoryX      = sync.RWMutex{}
 
 	// success
 	return &solution, nil
@@ -291,6 +318,9 @@ func (solution *Solution) Update(domainName string, architecture *Architecture)
 		}
 	}
 
+	// persist modification
+	Persist()
+
 	// success
 	return nil
 }
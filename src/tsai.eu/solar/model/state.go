@@ -1,13 +1,25 @@
 package model
 
+import (
+  "errors"
+
+  "tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// ErrSecret indicates that the references to secrets of a target state could not be resolved.
+var ErrSecret = errors.New("unable to resolve the secrets of the configuration")
+
 //------------------------------------------------------------------------------
 
 // RelationshipState describes the current state of a relationship
 type RelationshipState struct {
   Relationship  string  `yaml:"Relationship"`  // name of relationship
   Dependency    string  `yaml:"Dependency"`    // name of dependency
+  State         string  `yaml:"State"`         // state of relationship (active if bound)
   Configuration string  `yaml:"Configuration"` // configuration information
-  Endpoint      string  `yaml:"Endpoint"`      // endpoint information in yaml format
+  Endpoint      string  `yaml:"Endpoint"`      // endpoint information of the related cluster in yaml format
 }
 
 //------------------------------------------------------------------------------
@@ -23,20 +35,23 @@ type InstanceState struct {
 
 // TargetState describes the desired state and configuration for an instance
 type TargetState struct {
-	Domain        string              `yaml:"Domain"`        // name of the domain
-  Solution      string              `yaml:"Solution"`      // name of solution
-	Version       string              `yaml:"Version"`       // version of solution
-  Element       string              `yaml:"Element"`       // name of element
-  Cluster       string              `yaml:"Cluster"`       // name of cluster
-  Instance      string              `yaml:"Instance"`      // name of instance
-  Component     string              `yaml:"Component"`     // name of component
-  State         string              `yaml:"State"`         // state of instance
-  Min           int                 `yaml:"Min"`           // min. size of the solution element cluster
-	Max           int                 `yaml:"Max"`           // max. size of the solution element cluster
-	Size          int                 `yaml:"Size"`          // size of the solution element cluster
-	Configuration string              `yaml:"Configuration"` // configuration of instance
-  Relationships []RelationshipState `yaml:"Relationships"` // current state of all relationships
-  Instances     []InstanceState     `yaml:"Instances"`     // current state of all instances
+	Domain                string              `yaml:"Domain"`                  // name of the domain
+  Solution              string              `yaml:"Solution"`                // name of solution
+	Version               string              `yaml:"Version"`                 // version of solution
+  Element               string              `yaml:"Element"`                 // name of element
+  Cluster               string              `yaml:"Cluster"`                 // name of cluster
+  Instance              string              `yaml:"Instance"`                // name of instance
+  Relationship          string              `yaml:"Relationship"`            // name of the relationship to be bound or unbound
+  Component             string              `yaml:"Component"`               // name of component
+  State                 string              `yaml:"State"`                   // state of instance
+  Min                   int                 `yaml:"Min"`                     // min. size of the solution element cluster
+	Max                   int                 `yaml:"Max"`                     // max. size of the solution element cluster
+	Size                  int                 `yaml:"Size"`                    // size of the solution element cluster
+	Configuration         string              `yaml:"Configuration"`           // configuration of instance
+  ElementConfiguration  string              `yaml:"ElementConfiguration"`    // configuration of the solution element
+  SolutionConfiguration string              `yaml:"SolutionConfiguration"`   // configuration of the solution
+  Relationships         []RelationshipState `yaml:"Relationships"`           // current state of all relationships
+  Instances             []InstanceState     `yaml:"Instances"`               // current state of all instances
 }
 
 //------------------------------------------------------------------------------
@@ -53,6 +68,8 @@ type CurrentState struct {
   State         string `yaml:"State"`                 // state of instance
 	Configuration string `yaml:"Configuration"`         // configuration of instance
 	Endpoint      string `yaml:"Endpoint"`              // endpoint of instance
+  Operation     string `yaml:"Operation,omitempty"`   // id of a pending asynchronous operation of the controller
+  Timeout       string `yaml:"Timeout,omitempty"`     // max. duration of the pending operation, e.g. "10m"
 }
 
 //------------------------------------------------------------------------------
@@ -60,20 +77,22 @@ type CurrentState struct {
 // GetTargetState determines the desired state of an element, cluster and instance
 func GetTargetState(domainName string, solutionName string,  solutionVersion string, elementName string,  clusterName string, instanceName string) (*TargetState, error) {
 	targetState := &TargetState{
-    Domain:        domainName,
-    Solution:      solutionName,
-    Version:       solutionVersion,
-    Element:       elementName,
-    Cluster:       clusterName,
-    Instance:      instanceName,
-    Component:     "",
-    State:         "initial",
-    Min:           0,
-    Max:           0,
-    Size:          0,
-    Configuration: "",
-    Relationships: []RelationshipState{},
-    Instances:     []InstanceState{},
+    Domain:                domainName,
+    Solution:              solutionName,
+    Version:               solutionVersion,
+    Element:               elementName,
+    Cluster:               clusterName,
+    Instance:              instanceName,
+    Component:             "",
+    State:                 "initial",
+    Min:                   0,
+    Max:                   0,
+    Size:                  0,
+    Configuration:         "",
+    ElementConfiguration:  "",
+    SolutionConfiguration: "",
+    Relationships:         []RelationshipState{},
+    Instances:             []InstanceState{},
   }
 
   // determine domain context
@@ -122,7 +141,7 @@ func GetTargetState(domainName string, solutionName string,  solutionVersion str
   }
 
   // determine architecture cluster context
-  architectureCluster, err := architectureElement.GetCluster(clusterName)
+  _, err = architectureElement.GetCluster(clusterName)
   if err != nil {
     return targetState, err
   }
@@ -136,14 +155,15 @@ func GetTargetState(domainName string, solutionName string,  solutionVersion str
   // update component of target state
   targetState.Component = architectureComponent.Component
 
-  // update instance configuration
-  instance.Configuration = architectureCluster.Configuration
-
-  // update pool dimension and configuration of target state
+  // update pool dimension and rendered configuration of target state
   targetState.Min           = cluster.Min
   targetState.Max           = cluster.Max
   targetState.Size          = cluster.Size
-  targetState.Configuration = instance.Configuration
+  targetState.Configuration = cluster.Configuration
+
+  // update configuration of the element and the solution
+  targetState.ElementConfiguration  = element.Configuration
+  targetState.SolutionConfiguration = solution.Configuration
 
   // update relationship information
   relationshipNames, err := cluster.ListRelationships()
@@ -157,21 +177,27 @@ func GetTargetState(domainName string, solutionName string,  solutionVersion str
       return targetState, err
     }
 
-    // determine current endpoint information of element
-    relationshipElement, err := GetElement(domainName, solutionName, relationship.Element)
-    if err != nil {
-      return targetState, err
+    // determine current endpoint information of the related cluster
+    endpoint := ""
+    if relationshipCluster, err := GetCluster(relationship.Domain, relationship.Solution, relationship.Element, relationship.Version); err == nil {
+      endpoint = relationshipCluster.GetEndpoint()
     }
 
     // add relationship information
     targetState.Relationships = append(targetState.Relationships, RelationshipState{
       Relationship:  relationship.Relationship,
-      Dependency:    relationship.Endpoint,
+      Dependency:    relationship.Dependency,
+      State:         relationship.State,
       Configuration: relationship.Configuration,
-      Endpoint:      relationshipElement.Endpoint,
+      Endpoint:      endpoint,
     })
   }
 
+  // resolve the references to secrets
+  if err = resolveTargetState(domain, targetState); err != nil {
+    return targetState, err
+  }
+
   // update instance information
   instanceNames, err := cluster.ListInstances()
   if err != nil {
@@ -206,12 +232,62 @@ func SetCurrentState(currentState *CurrentState) (error) {
     return err
   }
 
-  // update state and endpoint of instance
+  // update state and endpoint of instance (without the values of secrets)
 	instance.State         = currentState.State
-  instance.Configuration = currentState.Configuration
-  instance.Endpoint      = currentState.Endpoint
+  instance.Configuration = util.Redact(currentState.Configuration)
+  instance.Endpoint      = util.Redact(currentState.Endpoint)
+
+  // drift is only detected for active instances and only active instances
+  // remain bound to related clusters
+  if instance.State != ActiveState {
+    instance.Drift    = ""
+    instance.Drifted  = ""
+    instance.Bindings = nil
+  }
+
+	// persist modification
+	Persist()
 
 	return nil
 }
 
 //------------------------------------------------------------------------------
+
+// resolveTargetState resolves the references to secrets within the
+// configurations of a target state.
+func resolveTargetState(domain *Domain, targetState *TargetState) error {
+  configuration, err := domain.ResolveSecrets(targetState.Configuration)
+  if err != nil {
+    util.LogError(targetState.Element + " - " + targetState.Cluster, "MODEL", err.Error())
+    return ErrSecret
+  }
+  targetState.Configuration = configuration
+
+  configuration, err = domain.ResolveSecrets(targetState.ElementConfiguration)
+  if err != nil {
+    util.LogError(targetState.Element + " - " + targetState.Cluster, "MODEL", err.Error())
+    return ErrSecret
+  }
+  targetState.ElementConfiguration = configuration
+
+  configuration, err = domain.ResolveSecrets(targetState.SolutionConfiguration)
+  if err != nil {
+    util.LogError(targetState.Element + " - " + targetState.Cluster, "MODEL", err.Error())
+    return ErrSecret
+  }
+  targetState.SolutionConfiguration = configuration
+
+  for index, relationship := range targetState.Relationships {
+    configuration, err = domain.ResolveSecrets(relationship.Configuration)
+    if err != nil {
+      util.LogError(targetState.Element + " - " + targetState.Cluster, "MODEL", err.Error())
+      return ErrSecret
+    }
+    targetState.Relationships[index].Configuration = configuration
+  }
+
+  // success
+  return nil
+}
+
+//------------------------------------------------------------------------------
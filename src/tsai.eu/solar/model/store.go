@@ -0,0 +1,238 @@
+package model
+
+import (
+	"sync"
+)
+
+//------------------------------------------------------------------------------
+// Store
+// =====
+//
+// Functions:
+//   - GetModifications
+//   - Persist
+//
+//   - model.Snapshot
+//------------------------------------------------------------------------------
+
+// Store defines the operations of a persistence layer for the model.
+type Store interface {
+	Load(model *Model) error // restores the model from the store
+	Save(model *Model) error // writes a snapshot of the model to the store
+}
+
+//------------------------------------------------------------------------------
+
+var modifications chan bool // the channel for modification notifications
+var modificationsOnce sync.Once
+
+//------------------------------------------------------------------------------
+
+// GetModifications initialises and returns the channel signalling
+// modifications of the model.
+func GetModifications() chan bool {
+	// initialise singleton once
+	modificationsOnce.Do(func() {
+		modifications = make(chan bool, 1)
+	})
+
+	return modifications
+}
+
+//------------------------------------------------------------------------------
+
+// Persist signals a modification of the model to an attached store.
+// Consecutive signals are merged while a notification is still pending.
+func Persist() {
+	select {
+	case GetModifications() <- true:
+	default:
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// Snapshot creates a copy of the model which can be serialised while the
+// original model is still being modified.
+func (model *Model) Snapshot() *Model {
+	snapshot := Model{
+		Schema:  model.Schema,
+		Name:    model.Name,
+		Domains: map[string]*Domain{},
+	}
+
+	model.DomainsX.RLock()
+	for name, domain := range model.Domains {
+		snapshot.Domains[name] = domain.snapshot()
+	}
+	model.DomainsX.RUnlock()
+
+	// success
+	return &snapshot
+}
+
+//------------------------------------------------------------------------------
+
+// snapshot creates a copy of the domain.
+func (domain *Domain) snapshot() *Domain {
+	snapshot := Domain{
+		Name:          domain.Name,
+		Components:    map[string]*Component{},
+		Architectures: map[string]*Architecture{},
+		Solutions:     map[string]*Solution{},
+		Tasks:         map[string]*Task{},
+		Events:        map[string]*Event{},
+		Controllers:   map[string]*Controller{},
+		Secrets:       map[string]string{},
+	}
+
+	// components and architectures are replaced but not modified in place
+	domain.ComponentsX.RLock()
+	for name, component := range domain.Components {
+		snapshot.Components[name] = component
+	}
+	domain.ComponentsX.RUnlock()
+
+	domain.ArchitecturesX.RLock()
+	for name, architecture := range domain.Architectures {
+		snapshot.Architectures[name] = architecture
+	}
+	domain.ArchitecturesX.RUnlock()
+
+	domain.SolutionsX.RLock()
+	for name, solution := range domain.Solutions {
+		snapshot.Solutions[name] = solution.snapshot()
+	}
+	domain.SolutionsX.RUnlock()
+
+	domain.TasksX.RLock()
+	taskX.RLock()
+	for uuid, task := range domain.Tasks {
+		clone := *task
+		snapshot.Tasks[uuid] = &clone
+	}
+	taskX.RUnlock()
+	domain.TasksX.RUnlock()
+
+	domain.EventsX.RLock()
+	for uuid, event := range domain.Events {
+		snapshot.Events[uuid] = event
+	}
+	domain.EventsX.RUnlock()
+
+	domain.ControllersX.RLock()
+	for name, controller := range domain.Controllers {
+		clone := *controller
+		snapshot.Controllers[name] = &clone
+	}
+	domain.ControllersX.RUnlock()
+
+	domain.SecretsX.RLock()
+	for name, secret := range domain.Secrets {
+		snapshot.Secrets[name] = secret
+	}
+	domain.SecretsX.RUnlock()
+
+	// success
+	return &snapshot
+}
+
+//------------------------------------------------------------------------------
+
+// snapshot creates a copy of the solution.
+func (solution *Solution) snapshot() *Solution {
+	snapshot := Solution{
+		Solution:      solution.Solution,
+		Version:       solution.Version,
+		Target:        solution.Target,
+		State:         solution.State,
+		Configuration: solution.Configuration,
+		Elements:      map[string]*Element{},
+	}
+
+	solution.ElementsX.RLock()
+	for name, element := range solution.Elements {
+		snapshot.Elements[name] = element.snapshot()
+	}
+	solution.ElementsX.RUnlock()
+
+	solution.HistoryX.RLock()
+	for _, deployment := range solution.History {
+		clone := *deployment
+		snapshot.History = append(snapshot.History, &clone)
+	}
+	solution.HistoryX.RUnlock()
+
+	// success
+	return &snapshot
+}
+
+//------------------------------------------------------------------------------
+
+// snapshot creates a copy of the element.
+func (element *Element) snapshot() *Element {
+	snapshot := Element{
+		Element:       element.Element,
+		Component:     element.Component,
+		Target:        element.Target,
+		State:         element.State,
+		Configuration: element.Configuration,
+		Endpoint:      element.Endpoint,
+		Clusters:      map[string]*Cluster{},
+	}
+
+	if element.Upgrade != nil {
+		upgrade := *element.Upgrade
+		snapshot.Upgrade = &upgrade
+	}
+
+	element.ClustersX.RLock()
+	for name, cluster := range element.Clusters {
+		snapshot.Clusters[name] = cluster.snapshot()
+	}
+	element.ClustersX.RUnlock()
+
+	// success
+	return &snapshot
+}
+
+//------------------------------------------------------------------------------
+
+// snapshot creates a copy of the cluster. Reported metrics are runtime
+// information and are not part of the snapshot.
+func (cluster *Cluster) snapshot() *Cluster {
+	snapshot := Cluster{
+		Version:       cluster.Version,
+		Target:        cluster.Target,
+		State:         cluster.State,
+		Min:           cluster.Min,
+		Max:           cluster.Max,
+		Size:          cluster.Size,
+		Configuration: cluster.Configuration,
+		Endpoint:      cluster.Endpoint,
+		Policy:        cluster.Policy,
+		Scaling:       cluster.Scaling,
+		Scaled:        cluster.Scaled,
+		Relationships: map[string]*Relationship{},
+		Instances:     map[string]*Instance{},
+	}
+
+	cluster.RelationshipsX.RLock()
+	for name, relationship := range cluster.Relationships {
+		clone := *relationship
+		snapshot.Relationships[name] = &clone
+	}
+	cluster.RelationshipsX.RUnlock()
+
+	cluster.InstancesX.RLock()
+	for uuid, instance := range cluster.Instances {
+		clone := *instance
+		snapshot.Instances[uuid] = &clone
+	}
+	cluster.InstancesX.RUnlock()
+
+	// success
+	return &snapshot
+}
+
+//------------------------------------------------------------------------------
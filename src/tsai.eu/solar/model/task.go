@@ -3,6 +3,7 @@ package model
 import (
 	"context"
 	"sort"
+	"sync"
 	"errors"
 
 	"tsai.eu/solar/util"
@@ -12,21 +13,23 @@ import (
 
 // TaskInfo specifies the basic behaviour of a task
 type TaskInfo struct {
-	Type         string      `yaml:"Type"`         // type of task
-	Domain       string      `yaml:"Domain"`       // domain of task
-	Solution     string      `yaml:"Solution"`     // architecture of entity
-	Version      string      `yaml:"Version"`      // architecture version of entity
-	Element      string      `yaml:"Element"`      // element of entity
-	Cluster      string      `yaml:"Cluster"`      // cluster of entity
-	Instance     string      `yaml:"Instance"`     // instance of entity
-	State        string      `yaml:"State"`        // desired state of entity
-	Action       string      `yaml:"Action"`       // action to be performed by a controller
-	UUID         string      `yaml:"UUID"`         // uuid of task
-	Parent       string      `yaml:"Parent"`       // uuid of parent task
-	Status       string      `yaml:"Status"`       // status of task: (execution/completion/failure)
-	Phase        int         `yaml:"Phase"`        // phase of task
-	Subtasks     []*TaskInfo `yaml:"Subtasks"`     // list of subtasks
-	Events       []*Event    `yaml:"Events"`       // list of events
+	Type         string      `yaml:"Type"`                   // type of task
+	Domain       string      `yaml:"Domain"`                 // domain of task
+	Solution     string      `yaml:"Solution"`               // architecture of entity
+	Version      string      `yaml:"Version"`                // architecture version of entity
+	Element      string      `yaml:"Element"`                // element of entity
+	Cluster      string      `yaml:"Cluster"`                // cluster of entity
+	Instance     string      `yaml:"Instance"`               // instance of entity
+	Relationship string      `yaml:"Relationship,omitempty"` // relationship of entity
+	State        string      `yaml:"State"`                  // desired state of entity
+	Action       string      `yaml:"Action"`                 // action to be performed by a controller
+	UUID         string      `yaml:"UUID"`                   // uuid of task
+	Parent       string      `yaml:"Parent"`                 // uuid of parent task
+	Status       string      `yaml:"Status"`                 // status of task: (execution/completion/failure)
+	Phase        int         `yaml:"Phase"`                  // phase of task
+	Operation    string      `yaml:"Operation,omitempty"`    // pending asynchronous operation of the controller
+	Subtasks     []*TaskInfo `yaml:"Subtasks"`               // list of subtasks
+	Events       []*Event    `yaml:"Events"`                 // list of events
 }
 
 //------------------------------------------------------------------------------
@@ -34,21 +37,23 @@ type TaskInfo struct {
 // NewTaskInfo derives a taskinfo object from a task.
 func NewTaskInfo(task *Task, level int) (*TaskInfo) {
 	taskinfo := TaskInfo{
-		Type:       task.Type,
-		Domain:     task.Domain,
-		Solution:   task.Solution,
-		Version:    task.Version,
-		Element:    task.Element,
-		Cluster:    task.Cluster,
-		Instance:   task.Instance,
-		State:      task.State,
-		Action:     task.Action,
-		UUID:       task.UUID,
-		Parent:     task.Parent,
-		Status:     task.Status,
-		Phase:      task.Phase,
-		Subtasks:   []*TaskInfo{},
-		Events:     []*Event{},
+		Type:         task.Type,
+		Domain:       task.Domain,
+		Solution:     task.Solution,
+		Version:      task.Version,
+		Element:      task.Element,
+		Cluster:      task.Cluster,
+		Instance:     task.Instance,
+		Relationship: task.Relationship,
+		State:        task.State,
+		Action:       task.Action,
+		UUID:         task.UUID,
+		Parent:       task.Parent,
+		Status:       task.GetStatus(),
+		Phase:        task.Phase,
+		Operation:    task.GetOperation(),
+		Subtasks:     []*TaskInfo{},
+		Events:       []*Event{},
 	}
 
 	// add events
@@ -81,6 +86,12 @@ func NewTaskInfo(task *Task, level int) (*TaskInfo) {
 
 //------------------------------------------------------------------------------
 
+// taskX guards the status and the pending operation of all tasks. Both are
+// modified by the worker of a task and read by other goroutines.
+var taskX sync.RWMutex
+
+//------------------------------------------------------------------------------
+
 // TaskHandler is function capable of processing a task related event.
 type TaskHandler func(task *Task)
 
@@ -88,21 +99,25 @@ type TaskHandler func(task *Task)
 
 // Task specifies the basic behaviour of a task
 type Task struct {
-	Type         string     `yaml:"Type"`         // type of task
-	Domain       string     `yaml:"Domain"`       // domain of task
-	Solution     string     `yaml:"Solution"`     // architecture of entity
-	Version      string     `yaml:"Version"`      // architecture version of entity
-	Element      string     `yaml:"Element"`      // element of entity
-	Cluster      string     `yaml:"Cluster"`      // cluster of entity
-	Instance     string     `yaml:"Instance"`     // instance of entity
-	State        string     `yaml:"State"`        // desired state of entity
-	Action       string     `yaml:"Action"`       // desired action for entity
-	UUID         string     `yaml:"UUID"`         // uuid of task
-	Parent       string     `yaml:"Parent"`       // uuid of parent task
-	Status       string     `yaml:"Status"`       // status of task: (execution/completion/failure)
-	Phase        int        `yaml:"Phase"`        // phase of task
-	Subtasks     []string   `yaml:"Subtasks"`     // list of subtasks
-	Events       []string   `yaml:"Events"`       // list of events
+	Type         string   `yaml:"Type"`                   // type of task
+	Domain       string   `yaml:"Domain"`                 // domain of task
+	Solution     string   `yaml:"Solution"`               // architecture of entity
+	Version      string   `yaml:"Version"`                // architecture version of entity
+	Element      string   `yaml:"Element"`                // element of entity
+	Cluster      string   `yaml:"Cluster"`                // cluster of entity
+	Instance     string   `yaml:"Instance"`               // instance of entity
+	Relationship string   `yaml:"Relationship,omitempty"` // relationship of entity
+	State        string   `yaml:"State"`                  // desired state of entity
+	Action       string   `yaml:"Action"`                 // desired action for entity
+	UUID         string   `yaml:"UUID"`                   // uuid of task
+	Parent       string   `yaml:"Parent"`                 // uuid of parent task
+	Status       string   `yaml:"Status"`                 // status of task: (execution/completion/failure)
+	Phase        int      `yaml:"Phase"`                  // phase of task
+	Subtasks     []string `yaml:"Subtasks"`               // list of subtasks
+	Events       []string `yaml:"Events"`                 // list of events
+	Operation    string   `yaml:"Operation,omitempty"`    // pending asynchronous operation of the controller
+	Deadline     int64    `yaml:"Deadline,omitempty"`     // deadline of the controller task or its pending operation (nsec since 1970)
+	Retries      int      `yaml:"Retries,omitempty"`      // number of retries of the controller task
 	execute      TaskHandler
 	terminate    TaskHandler
 	failed       TaskHandler
@@ -161,6 +176,13 @@ func (task *Task) GetInstance() string {
 
 //------------------------------------------------------------------------------
 
+// GetRelationship delivers the relationship of the entity.
+func (task *Task) GetRelationship() string {
+	return task.Relationship
+}
+
+//------------------------------------------------------------------------------
+
 // GetState delivers the state of the entity.
 func (task *Task) GetState() string {
 	return task.State
@@ -191,11 +213,24 @@ func (task *Task) GetParent() string {
 
 // GetStatus delivers the status of the task.
 func (task *Task) GetStatus() string {
+	taskX.RLock()
+	defer taskX.RUnlock()
+
 	return task.Status
 }
 
 //------------------------------------------------------------------------------
 
+// SetStatus defines the status of the task.
+func (task *Task) SetStatus(status string) {
+	taskX.Lock()
+	defer taskX.Unlock()
+
+	task.Status = status
+}
+
+//------------------------------------------------------------------------------
+
 // GetPhase delivers the internal status of the task.
 func (task *Task) GetPhase() int {
 	return task.Phase
@@ -203,6 +238,38 @@ func (task *Task) GetPhase() int {
 
 //------------------------------------------------------------------------------
 
+// GetOperation delivers the pending asynchronous operation of the controller.
+func (task *Task) GetOperation() string {
+	taskX.RLock()
+	defer taskX.RUnlock()
+
+	return task.Operation
+}
+
+//------------------------------------------------------------------------------
+
+// GetDeadline delivers the deadline of the task or its pending operation.
+func (task *Task) GetDeadline() int64 {
+	taskX.RLock()
+	defer taskX.RUnlock()
+
+	return task.Deadline
+}
+
+//------------------------------------------------------------------------------
+
+// SetOperation defines the pending asynchronous operation of the controller
+// and its deadline.
+func (task *Task) SetOperation(operation string, deadline int64) {
+	taskX.Lock()
+	defer taskX.Unlock()
+
+	task.Operation = operation
+	task.Deadline  = deadline
+}
+
+//------------------------------------------------------------------------------
+
 // GetSubtask provides the subtask with a given uuid.
 func (task *Task) GetSubtask(uuid string) (*Task, error) {
 	// check if uuid is in slice of substasks
@@ -294,6 +361,9 @@ func (task *Task) Execute(ctx context.Context) {
 	// execute task if appropriate handler has been defined
 	if task.execute != nil {
 		task.execute(task)
+
+		// persist modification
+		Persist()
 	}
 }
 
@@ -311,6 +381,9 @@ func (task *Task) Terminate(ctx context.Context) {
 	// execute task if appropriate handler has been defined
 	if task.terminate != nil {
 		task.terminate(task)
+
+		// persist modification
+		Persist()
 	}
 }
 
@@ -328,6 +401,9 @@ func (task *Task) Failed(ctx context.Context) {
 	// execute task if appropriate handler has been defined
 	if task.failed != nil {
 		task.failed(task)
+
+		// persist modification
+		Persist()
 	}
 }
 
@@ -345,6 +421,9 @@ func (task *Task) Timeout(ctx context.Context) {
 	// execute task if appropriate handler has been defined
 	if task.timeout != nil {
 		task.timeout(task)
+
+		// persist modification
+		Persist()
 	}
 }
 
@@ -362,6 +441,9 @@ func (task *Task) Completed(ctx context.Context) {
 	// execute task if appropriate handler has been defined
 	if task.completed != nil {
 		task.completed(task)
+
+		// persist modification
+		Persist()
 	}
 }
 
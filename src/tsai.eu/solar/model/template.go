@@ -0,0 +1,228 @@
+package model
+
+import (
+	"sort"
+	"regexp"
+	"strings"
+
+	"github.com/cbroglie/mustache"
+
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+// Template
+// ========
+//
+// Configuration templates of components and dependencies are rendered with
+// mustache. Besides the parameters the templates have access to:
+//
+//   - Instances:     instances of the cluster
+//   - Relationships: relationships of the cluster incl. the referenced cluster
+//   - Target:        cluster referenced by a relationship (dependency templates only)
+//   - Elements:      elements of the solution by name and their clusters
+//   - Architecture:  parsed configuration of the architecture
+//
+// Functions:
+//   - RenderTemplate
+//
+//   - placeholder
+//   - newTemplateContext
+//   - clusterContext
+//   - instancesContext
+//------------------------------------------------------------------------------
+
+// RenderTemplate renders a mustache template with the parameters and the
+// context information. Parameters are checked against the parameter schema
+// and its defaults are applied. Undefined top level variables are left in
+// place and reported as a warning.
+func RenderTemplate(template string, schema []*Parameter, values map[string]string, context map[string]interface{}) (string, error) {
+	parameters, err := ResolveParameters(schema, values)
+	if err != nil {
+		return "", err
+	}
+
+	data := map[string]interface{}{}
+	for key, value := range context {
+		data[key] = value
+	}
+	for key, value := range parameters {
+		data[key] = value
+	}
+
+	// references to secrets are resolved by the controller request
+	for _, reference := range secretReference.FindAllStringSubmatch(template, -1) {
+		data["secret:" + reference[1]] = reference[0]
+	}
+
+	tmpl, err := mustache.ParseStringRaw(template, true)
+	if err != nil {
+		return "", err
+	}
+
+	// determine undefined top level variables
+	unknown := []string{}
+	for _, tag := range tmpl.Tags() {
+		name := strings.SplitN(tag.Name(), ".", 2)[0]
+		if _, ok := data[name]; !ok && tag.Type() == mustache.Variable {
+			unknown = append(unknown, tag.Name())
+		}
+	}
+
+	// leave undefined variables in place
+	for _, name := range unknown {
+		text := "{{" + name + "}}"
+		if match := regexp.MustCompile(`{{\s*` + regexp.QuoteMeta(name) + `\s*}}`).FindString(template); match != "" {
+			text = match
+		}
+		placeholder(data, strings.Split(name, "."), text)
+	}
+
+	if len(unknown) > 0 {
+		util.LogWarn("template", "MODEL", "undefined parameters: " + strings.Join(unknown, ", "))
+	}
+
+	// success
+	return tmpl.Render(data)
+}
+
+//------------------------------------------------------------------------------
+
+// placeholder defines the value of a (dotted) variable in the template data.
+func placeholder(data map[string]interface{}, path []string, value string) {
+	if len(path) == 1 {
+		data[path[0]] = value
+		return
+	}
+
+	node, ok := data[path[0]].(map[string]interface{})
+	if !ok {
+		node = map[string]interface{}{}
+		data[path[0]] = node
+	}
+	placeholder(node, path[1:], value)
+}
+
+//------------------------------------------------------------------------------
+
+// newTemplateContext collects the information of a solution which is
+// available to the configuration templates of a cluster.
+func newTemplateContext(domainName string, solutionName string, version string, cluster *Cluster) map[string]interface{} {
+	context := map[string]interface{}{
+		"Instances":     instancesContext(cluster),
+		"Relationships": []interface{}{},
+		"Elements":      map[string]interface{}{},
+		"Architecture":  map[string]interface{}{},
+	}
+
+	// configuration of the architecture
+	if architecture, err := GetArchitecture(domainName, solutionName, version); err == nil {
+		configuration := map[string]interface{}{}
+		if util.ConvertFromYAML(architecture.Configuration, &configuration) == nil && configuration != nil {
+			context["Architecture"] = configuration
+		}
+	}
+
+	// elements of the solution
+	solution, err := GetSolution(domainName, solutionName)
+	if err != nil {
+		return context
+	}
+
+	elements := map[string]interface{}{}
+
+	elementNames, _ := solution.ListElements()
+	for _, elementName := range elementNames {
+		element, _ := solution.GetElement(elementName)
+
+		clusters := []interface{}{}
+
+		clusterNames, _ := element.ListClusters()
+		sort.Strings(clusterNames)
+		for _, clusterName := range clusterNames {
+			elementCluster, _ := element.GetCluster(clusterName)
+
+			clusters = append(clusters, clusterContext(elementName, elementCluster))
+		}
+
+		elements[elementName] = map[string]interface{}{
+			"Element":   element.Element,
+			"Component": element.Component,
+			"State":     element.State,
+			"Endpoint":  element.Endpoint,
+			"Clusters":  clusters,
+		}
+	}
+	context["Elements"] = elements
+
+	// relationships of the cluster
+	relationships := []interface{}{}
+
+	relationshipNames, _ := cluster.ListRelationships()
+	sort.Strings(relationshipNames)
+	for _, relationshipName := range relationshipNames {
+		relationship, _ := cluster.GetRelationship(relationshipName)
+
+		target := map[string]interface{}{}
+		if targetCluster, err := GetCluster(domainName, solutionName, relationship.Element, relationship.Version); err == nil {
+			target = clusterContext(relationship.Element, targetCluster)
+		}
+
+		relationships = append(relationships, map[string]interface{}{
+			"Relationship": relationship.Relationship,
+			"Dependency":   relationship.Dependency,
+			"Type":         relationship.Type,
+			"Element":      relationship.Element,
+			"Version":      relationship.Version,
+			"State":        relationship.State,
+			"Endpoint":     relationship.Endpoint,
+			"Target":       target,
+		})
+	}
+	context["Relationships"] = relationships
+
+	// success
+	return context
+}
+
+//------------------------------------------------------------------------------
+
+// clusterContext describes a cluster for the configuration templates.
+func clusterContext(elementName string, cluster *Cluster) map[string]interface{} {
+	return map[string]interface{}{
+		"Element":   elementName,
+		"Version":   cluster.Version,
+		"State":     cluster.State,
+		"Endpoint":  cluster.Endpoint,
+		"Size":      cluster.Size,
+		"Instances": instancesContext(cluster),
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// instancesContext describes the instances of a cluster for the configuration
+// templates. Active instances are flagged so that they can be filtered by a
+// section.
+func instancesContext(cluster *Cluster) []interface{} {
+	instances := []interface{}{}
+
+	instanceNames, _ := cluster.ListInstances()
+	sort.Strings(instanceNames)
+	for _, instanceName := range instanceNames {
+		instance, _ := cluster.GetInstance(instanceName)
+
+		instances = append(instances, map[string]interface{}{
+			"UUID":     instance.UUID,
+			"State":    instance.State,
+			"Target":   instance.Target,
+			"Endpoint": instance.Endpoint,
+			"Active":   instance.State == ActiveState,
+		})
+	}
+
+	// success
+	return instances
+}
+
+//------------------------------------------------------------------------------
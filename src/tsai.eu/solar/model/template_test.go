@@ -0,0 +1,87 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+// TestTemplate01 tests sections and loops of a template.
+func TestTemplate01(t *testing.T) {
+	context := map[string]interface{}{
+		"Instances": []interface{}{
+			map[string]interface{}{"UUID": "a", "Endpoint": "10.0.0.1", "Active": true},
+			map[string]interface{}{"UUID": "b", "Endpoint": "10.0.0.2", "Active": false},
+		},
+		"Architecture": map[interface{}]interface{}{
+			"dns": map[interface{}]interface{}{"zone": "example.com"},
+		},
+	}
+
+	template := "{{#Instances}}{{#Active}}{{Endpoint}};{{/Active}}{{/Instances}}" +
+	            "{{^debug}}production{{/debug}} {{Architecture.dns.zone}} <{{name}}>"
+
+	result, err := RenderTemplate(template, nil, map[string]string{"name": "a&b"}, context)
+	if err != nil {
+		t.Fatalf("RenderTemplate should have rendered the template:\n%s", err)
+	}
+
+	if result != "10.0.0.1;production example.com <a&b>" {
+		t.Errorf("RenderTemplate delivered an unexpected result: %s", result)
+	}
+
+	result, err = RenderTemplate("{{Architecture.dns.zone}} {{Cluster.Endpoint}} {{ .Values.image }}", nil, map[string]string{}, context)
+	if err != nil || result != "example.com {{Cluster.Endpoint}} {{ .Values.image }}" {
+		t.Errorf("RenderTemplate should have left the undefined variables in place: %s", result)
+	}
+
+	if _, err = RenderTemplate("{{#Instances}}", nil, map[string]string{}, context); err == nil {
+		t.Errorf("RenderTemplate should have complained about an unclosed section")
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// TestTemplate02 tests the rendering of an inventory of a related cluster.
+func TestTemplate02(t *testing.T) {
+	model := GetModel()
+
+	model.Load("testdata/testdata1.yaml")
+
+	// activate the instances of the database cluster
+	db, _ := GetCluster("demo", "app", "db", "V1.0.0")
+
+	instanceNames, _ := db.ListInstances()
+	for _, instanceName := range instanceNames {
+		instance, _ := db.GetInstance(instanceName)
+		instance.State    = ActiveState
+		instance.Endpoint = "db-" + instanceName
+	}
+
+	component, _ := GetComponent("demo", "application", "V1.0.0")
+	component.Configuration = "[{{element}}]\n" +
+		"{{#Relationships}}{{#Target}}{{#Instances}}{{#Active}}{{Element}} {{Endpoint}}\n{{/Active}}{{/Instances}}{{/Target}}{{/Relationships}}" +
+		"{{#Elements.db.Clusters}}{{Version}}: {{Size}}{{/Elements.db.Clusters}}"
+
+	element, _       := GetElement("demo", "app", "app")
+	architecture, _  := GetArchitecture("demo", "app", "V0.0.0")
+	configuration, _ := architecture.GetElement("app")
+
+	if err := element.Update("demo", "app", "V0.0.0", configuration); err != nil {
+		t.Fatalf("<element>.Update should have rendered the configuration:\n%s", err)
+	}
+
+	cluster, _ := element.GetCluster("V1.0.0")
+	for _, instanceName := range instanceNames {
+		if !strings.Contains(cluster.Configuration, "db db-" + instanceName + "\n") {
+			t.Errorf("<element>.Update should have listed the active instances of the database:\n%s", cluster.Configuration)
+		}
+	}
+
+	if !strings.HasPrefix(cluster.Configuration, "[app]\n") || !strings.HasSuffix(cluster.Configuration, "V1.0.0: 3") {
+		t.Errorf("<element>.Update should have rendered the parameters:\n%s", cluster.Configuration)
+	}
+}
+
+//------------------------------------------------------------------------------
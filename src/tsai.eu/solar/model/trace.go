@@ -61,6 +61,7 @@ type TraceEvent struct {
 	Index2    int                        `yaml:"Index2"`     // second index of event
 	Layer2    int                        `yaml:"Layer2"`     // second layer of event
 	Layers2   int                        `yaml:"Layers2"`    // second layers of event
+	Comment   string                     `yaml:"Comment"`    // comment of event
 }
 
 // Trace describes the stack trace of a task
@@ -131,6 +132,7 @@ func addTaskToTrace(trace *Trace, task* Task) {
 			Index2:     0,
 			Layer2:     0,
 			Layers2:    1,
+			Comment:    event.Comment,
 		}
 
 		task1, _ := GetTask(task.GetDomain(), event.Source)
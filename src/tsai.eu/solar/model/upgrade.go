@@ -0,0 +1,229 @@
+package model
+
+import (
+	"errors"
+	"strconv"
+)
+
+//------------------------------------------------------------------------------
+// Strategy
+// ========
+//
+// Attributes:
+//   - Type
+//   - Batch
+//   - Percentage
+//   - OnFailure
+//
+// Functions:
+//   - strategy.Validate
+//   - strategy.GetBatch
+//------------------------------------------------------------------------------
+
+// Strategy describes how the instances of an old cluster of an element are
+// replaced by the instances of a new cluster.
+type Strategy struct {
+	Type       string `yaml:"Type,omitempty"`       // type of strategy: rolling, blue-green, canary (undefined: no ordering)
+	Batch      int    `yaml:"Batch,omitempty"`      // number of instances replaced per step (rolling and canary)
+	Percentage int    `yaml:"Percentage,omitempty"` // percentage of the instances replaced in the first step (canary)
+	OnFailure  string `yaml:"OnFailure,omitempty"`  // reaction to a failure: pause (default) or abort
+}
+
+//------------------------------------------------------------------------------
+
+// Validate checks the settings of a strategy.
+func (strategy Strategy) Validate() error {
+	switch strategy.Type {
+	case "", RollingStrategy, BlueGreenStrategy, CanaryStrategy:
+	default:
+		return errors.New("invalid type of strategy: " + strategy.Type)
+	}
+
+	if strategy.Batch < 0 {
+		return errors.New("invalid batch size")
+	}
+	if strategy.Percentage < 0 || strategy.Percentage > 100 {
+		return errors.New("invalid percentage")
+	}
+	if strategy.Type == CanaryStrategy && strategy.Percentage == 0 {
+		return errors.New("percentage of canary is undefined")
+	}
+	if strategy.OnFailure != "" && strategy.OnFailure != "pause" && strategy.OnFailure != "abort" {
+		return errors.New("invalid reaction to failure: " + strategy.OnFailure)
+	}
+
+	// success
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// GetBatch determines the number of instances to be replaced in a step of an
+// upgrade (the first step has the number 0).
+func (strategy Strategy) GetBatch(step int, size int) int {
+	batch := strategy.Batch
+
+	switch strategy.Type {
+	case BlueGreenStrategy:
+		batch = size
+	case CanaryStrategy:
+		// the canary is followed by the remaining instances unless a batch size has been defined
+		if step == 0 {
+			batch = (size * strategy.Percentage + 99) / 100
+		} else if batch == 0 {
+			batch = size
+		}
+	}
+
+	if batch < 1 {
+		batch = 1
+	}
+
+	return batch
+}
+
+//------------------------------------------------------------------------------
+
+// aborts checks if an upgrade should be rolled back after a failure.
+func (strategy Strategy) aborts() bool {
+	return strategy.OnFailure == "abort"
+}
+
+//------------------------------------------------------------------------------
+// Upgrade
+// =======
+//
+// Attributes:
+//   - Strategy
+//   - From
+//   - To
+//   - Min
+//   - Max
+//   - Size
+//   - Restore
+//   - Step
+//   - Drain
+//   - Status
+//
+// Functions:
+//   - upgrade.Advance
+//   - upgrade.Fail
+//------------------------------------------------------------------------------
+
+// Upgrade describes the progress of the replacement of an old cluster of an
+// element by a new cluster.
+type Upgrade struct {
+	Strategy Strategy `yaml:"Strategy"` // strategy of the upgrade
+	From     string   `yaml:"From"`     // version of the old cluster
+	To       string   `yaml:"To"`       // version of the new cluster
+	Min      int      `yaml:"Min"`      // configured min. size of the new cluster
+	Max      int      `yaml:"Max"`      // configured max. size of the new cluster
+	Size     int      `yaml:"Size"`     // configured size of the new cluster
+	Restore  int      `yaml:"Restore"`  // size of the old cluster before the upgrade
+	Step     int      `yaml:"Step"`     // number of completed steps
+	Drain    int      `yaml:"Drain"`    // number of old instances to be drained next (0: scale up the new cluster next)
+	Status   string   `yaml:"Status"`   // status of the upgrade: running, paused, aborted, completed
+}
+
+//------------------------------------------------------------------------------
+
+// newUpgrade prepares the replacement of an old cluster by a new cluster.
+// Both clusters are resized so that the engine can adjust them step by step.
+func newUpgrade(strategy Strategy, from *Cluster, to *Cluster) *Upgrade {
+	upgrade := Upgrade{
+		Strategy: strategy,
+		From:     from.Version,
+		To:       to.Version,
+		Min:      to.Min,
+		Max:      to.Max,
+		Size:     to.Size,
+		Restore:  from.Size,
+		Step:     0,
+		Drain:    0,
+		Status:   UpgradeRunning,
+	}
+
+	// the old cluster keeps serving until it has been drained
+	from.Target = ActiveState
+	from.Resize(0, from.Max, from.Size)
+
+	// the new cluster starts empty
+	to.Resize(0, to.Max, 0)
+
+	return &upgrade
+}
+
+//------------------------------------------------------------------------------
+
+// Advance adjusts the sizes of the clusters for the next step of the upgrade
+// once both clusters have converged. It returns a description of the step and
+// whether the upgrade has been completed.
+func (upgrade *Upgrade) Advance(from *Cluster, to *Cluster) (string, bool) {
+	// drain the old instances replaced by the last step
+	if upgrade.Drain > 0 {
+		drain := upgrade.Drain
+		if drain > from.Size {
+			drain = from.Size
+		}
+
+		from.Resize(0, from.Max, from.Size - drain)
+		upgrade.Drain = 0
+		Persist()
+
+		return "step " + strconv.Itoa(upgrade.Step) + ": drained " + strconv.Itoa(drain) + " instance(s) of cluster " + from.Version + " (" + strconv.Itoa(from.Size) + " remaining)", false
+	}
+
+	// all instances have been replaced
+	if to.Size >= upgrade.Size && from.Size == 0 {
+		to.Resize(upgrade.Min, upgrade.Max, upgrade.Size)
+		from.Reset()
+		upgrade.Status = UpgradeCompleted
+		Persist()
+
+		return "upgrade from cluster " + from.Version + " to cluster " + to.Version + " has been completed", true
+	}
+
+	// scale up the new cluster
+	batch := upgrade.Strategy.GetBatch(upgrade.Step, upgrade.Size)
+
+	size := to.Size + batch
+	if size > upgrade.Size {
+		size = upgrade.Size
+	}
+
+	to.Resize(0, to.Max, size)
+	upgrade.Step  = upgrade.Step + 1
+	upgrade.Drain = batch
+
+	// drain all remaining old instances after the last step
+	if size == upgrade.Size {
+		upgrade.Drain = from.Size
+	}
+	Persist()
+
+	return "step " + strconv.Itoa(upgrade.Step) + ": activating " + strconv.Itoa(size) + "/" + strconv.Itoa(upgrade.Size) + " instance(s) of cluster " + to.Version, false
+}
+
+//------------------------------------------------------------------------------
+
+// Fail pauses the upgrade or rolls it back depending on the strategy.
+func (upgrade *Upgrade) Fail(from *Cluster, to *Cluster) string {
+	if !upgrade.Strategy.aborts() {
+		upgrade.Status = UpgradePaused
+		Persist()
+
+		return "upgrade from cluster " + from.Version + " to cluster " + to.Version + " has been paused in step " + strconv.Itoa(upgrade.Step)
+	}
+
+	// restore the old cluster and remove the new cluster
+	from.Target = ActiveState
+	from.Resize(0, from.Max, upgrade.Restore)
+	to.Reset()
+
+	upgrade.Status = UpgradeAborted
+	Persist()
+
+	return "upgrade from cluster " + from.Version + " to cluster " + to.Version + " has been aborted in step " + strconv.Itoa(upgrade.Step)
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+// TestUpgrade01 tests the basic functions of a strategy.
+func TestUpgrade01(t *testing.T) {
+	if err := (Strategy{Type: RollingStrategy, Batch: 2}).Validate(); err != nil {
+		t.Errorf("<strategy>.Validate should have accepted a valid strategy:\n%s", err)
+	}
+
+	if err := (Strategy{Type: "unknown"}).Validate(); err == nil {
+		t.Errorf("<strategy>.Validate should have complained about an invalid type")
+	}
+
+	if err := (Strategy{Type: CanaryStrategy}).Validate(); err == nil {
+		t.Errorf("<strategy>.Validate should have complained about an undefined percentage")
+	}
+
+	if err := (Strategy{Type: RollingStrategy, OnFailure: "ignore"}).Validate(); err == nil {
+		t.Errorf("<strategy>.Validate should have complained about an invalid reaction to failures")
+	}
+
+	if (Strategy{Type: RollingStrategy}).GetBatch(0, 5) != 1 || (Strategy{Type: RollingStrategy, Batch: 2}).GetBatch(1, 5) != 2 {
+		t.Errorf("<strategy>.GetBatch should have delivered the batch size of a rolling upgrade")
+	}
+
+	if (Strategy{Type: BlueGreenStrategy}).GetBatch(0, 5) != 5 {
+		t.Errorf("<strategy>.GetBatch should have replaced all instances at once")
+	}
+
+	canary := Strategy{Type: CanaryStrategy, Percentage: 20}
+	if canary.GetBatch(0, 10) != 2 || canary.GetBatch(1, 10) != 10 {
+		t.Errorf("<strategy>.GetBatch should have replaced the canary first")
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// TestUpgrade02 tests a rolling upgrade between two clusters.
+func TestUpgrade02(t *testing.T) {
+	model := GetModel()
+
+	model.Load("testdata/testdata1.yaml")
+
+	element, _       := GetElement("demo", "app", "app")
+	architecture, _  := GetArchitecture("demo", "app", "V0.0.0")
+	configuration, _ := architecture.GetElement("app")
+
+	// replace the cluster by a new version
+	clusterConfiguration, _ := configuration.GetCluster("V1.0.0")
+	configuration.DeleteCluster("V1.0.0")
+	clusterConfiguration.Version = "V2.0.0"
+	configuration.AddCluster(clusterConfiguration)
+
+	configuration.Strategy = Strategy{Type: "unknown"}
+	if err := element.Update("demo", "app", "V0.0.0", configuration); err == nil {
+		t.Errorf("<element>.Update should have complained about an invalid strategy")
+	}
+
+	configuration.Strategy = Strategy{Type: RollingStrategy, Batch: 2}
+	if err := element.Update("demo", "app", "V0.0.0", configuration); err != nil {
+		t.Fatalf("<element>.Update should have updated the element:\n%s", err)
+	}
+
+	if element.Upgrade == nil || element.Upgrade.From != "V1.0.0" || element.Upgrade.To != "V2.0.0" || element.Upgrade.Status != UpgradeRunning {
+		t.Fatalf("<element>.Update should have started an upgrade")
+	}
+
+	from, _ := element.GetCluster("V1.0.0")
+	to, _   := element.GetCluster("V2.0.0")
+
+	if from.Target != ActiveState || from.Size != 3 || to.Size != 0 {
+		t.Errorf("<element>.Update should have kept the old cluster active")
+	}
+
+	// bring up two new instances, drain two old instances, ...
+	expected := [][]int{{2, 3}, {2, 1}, {3, 1}, {3, 0}}
+	for index, sizes := range expected {
+		if _, completed := element.Upgrade.Advance(from, to); completed {
+			t.Fatalf("<upgrade>.Advance should not have completed the upgrade in step %d", index)
+		}
+
+		if to.Size != sizes[0] || from.Size != sizes[1] {
+			t.Errorf("<upgrade>.Advance delivered unexpected sizes in step %d: %d/%d", index, to.Size, from.Size)
+		}
+	}
+
+	if _, completed := element.Upgrade.Advance(from, to); !completed {
+		t.Errorf("<upgrade>.Advance should have completed the upgrade")
+	}
+
+	if from.Target != InitialState || to.Min != clusterConfiguration.Min || element.Upgrade.Status != UpgradeCompleted {
+		t.Errorf("<upgrade>.Advance should have reset the old cluster")
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// TestUpgrade03 tests the failure handling of an upgrade.
+func TestUpgrade03(t *testing.T) {
+	from, _ := NewCluster("V1.0.0", ActiveState, 3, 3, 3, "")
+	to, _   := NewCluster("V2.0.0", ActiveState, 3, 3, 3, "")
+
+	upgrade := newUpgrade(Strategy{Type: RollingStrategy}, from, to)
+	upgrade.Advance(from, to)
+	upgrade.Advance(from, to)
+
+	upgrade.Fail(from, to)
+	if upgrade.Status != UpgradePaused || from.Size != 2 || to.Size != 1 {
+		t.Errorf("<upgrade>.Fail should have paused the upgrade")
+	}
+
+	upgrade.Status   = UpgradeRunning
+	upgrade.Strategy = Strategy{Type: RollingStrategy, OnFailure: "abort"}
+
+	upgrade.Fail(from, to)
+	if upgrade.Status != UpgradeAborted || from.Size != 3 || to.Target != InitialState {
+		t.Errorf("<upgrade>.Fail should have rolled back the upgrade")
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,394 @@
+package model
+
+import (
+	"sort"
+	"errors"
+	"strings"
+	"strconv"
+
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+// Validation
+// ==========
+//
+// Attributes:
+//   - Architecture
+//   - Version
+//   - Errors
+//   - Warnings
+//
+// Functions:
+//   - ValidateArchitecture
+//
+//   - validation.OK
+//   - validation.Show
+//   - validation.Error
+//------------------------------------------------------------------------------
+
+// Finding describes an issue of an architecture.
+type Finding struct {
+	Path    string `yaml:"Path"`    // path of the affected entity: element/cluster/relationship
+	Message string `yaml:"Message"` // description of the issue
+}
+
+//------------------------------------------------------------------------------
+
+// Validation describes the result of the validation of an architecture.
+type Validation struct {
+	Architecture string     `yaml:"Architecture"` // name of the architecture
+	Version      string     `yaml:"Version"`      // version of the architecture
+	Errors       []*Finding `yaml:"Errors"`       // issues which prevent a deployment
+	Warnings     []*Finding `yaml:"Warnings"`     // issues which may lead to unexpected behaviour
+}
+
+//------------------------------------------------------------------------------
+
+// ValidateArchitecture checks the consistency of an architecture and its
+// references to the components of a domain.
+func ValidateArchitecture(domain *Domain, architecture *Architecture) *Validation {
+	validation := Validation{
+		Architecture: architecture.Architecture,
+		Version:      architecture.Version,
+		Errors:       []*Finding{},
+		Warnings:     []*Finding{},
+	}
+
+	if architecture.Architecture == "" {
+		validation.addError("", "name of architecture is undefined")
+	}
+	if architecture.Version == "" {
+		validation.addError("", "version of architecture is undefined")
+	}
+
+	elementNames, _ := architecture.ListElements()
+	sort.Strings(elementNames)
+
+	if len(elementNames) == 0 {
+		validation.addWarning("", "architecture does not define any elements")
+	}
+
+	for _, elementName := range elementNames {
+		elementConfiguration, _ := architecture.GetElement(elementName)
+
+		validation.validateElement(domain, architecture, elementName, elementConfiguration)
+	}
+
+	validation.validateCycles(architecture)
+
+	// success
+	return &validation
+}
+
+//------------------------------------------------------------------------------
+
+// OK checks if the architecture is free of errors.
+func (validation *Validation) OK() bool {
+	return len(validation.Errors) == 0
+}
+
+//------------------------------------------------------------------------------
+
+// Show displays the validation result as yaml
+func (validation *Validation) Show() (string, error) {
+	return util.ConvertToYAML(validation)
+}
+
+//------------------------------------------------------------------------------
+
+// Error summarises the errors of the validation.
+func (validation *Validation) Error() error {
+	if validation.OK() {
+		return nil
+	}
+
+	messages := []string{}
+	for _, finding := range validation.Errors {
+		messages = append(messages, finding.Path + ": " + finding.Message)
+	}
+
+	return errors.New("invalid architecture:\n" + strings.Join(messages, "\n"))
+}
+
+//------------------------------------------------------------------------------
+
+// addError records an error.
+func (validation *Validation) addError(path string, message string) {
+	validation.Errors = append(validation.Errors, &Finding{Path: path, Message: message})
+}
+
+//------------------------------------------------------------------------------
+
+// addWarning records a warning.
+func (validation *Validation) addWarning(path string, message string) {
+	validation.Warnings = append(validation.Warnings, &Finding{Path: path, Message: message})
+}
+
+//------------------------------------------------------------------------------
+
+// validateElement checks an element configuration and its clusters.
+func (validation *Validation) validateElement(domain *Domain, architecture *Architecture, path string, elementConfiguration *ElementConfiguration) {
+	if elementConfiguration.Element != path {
+		validation.addError(path, "name of element does not match: " + elementConfiguration.Element)
+	}
+	if elementConfiguration.Component == "" {
+		validation.addError(path, "component of element is undefined")
+	}
+	if err := elementConfiguration.Strategy.Validate(); err != nil {
+		validation.addError(path, err.Error())
+	}
+
+	clusterNames, _ := elementConfiguration.ListClusters()
+	sort.Strings(clusterNames)
+
+	if len(clusterNames) == 0 {
+		validation.addWarning(path, "element does not define any clusters")
+	}
+
+	for _, clusterName := range clusterNames {
+		clusterConfiguration, _ := elementConfiguration.GetCluster(clusterName)
+
+		validation.validateCluster(domain, architecture, path + "/" + clusterName, elementConfiguration, clusterName, clusterConfiguration)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// validateCluster checks a cluster configuration and its relationships.
+func (validation *Validation) validateCluster(domain *Domain, architecture *Architecture, path string, elementConfiguration *ElementConfiguration, clusterName string, clusterConfiguration *ClusterConfiguration) {
+	if clusterConfiguration.Version != clusterName {
+		validation.addError(path, "version of cluster does not match: " + clusterConfiguration.Version)
+	}
+
+	// check state
+	switch clusterConfiguration.State {
+	case InitialState, InactiveState, ActiveState:
+	default:
+		validation.addError(path, "invalid target state: " + clusterConfiguration.State)
+	}
+
+	// check sizing
+	if clusterConfiguration.Min < 0 || clusterConfiguration.Min > clusterConfiguration.Max ||
+	   clusterConfiguration.Size < clusterConfiguration.Min || clusterConfiguration.Size > clusterConfiguration.Max {
+		validation.addError(path, "inconsistent sizing: min: " + strconv.Itoa(clusterConfiguration.Min) +
+			", max: " + strconv.Itoa(clusterConfiguration.Max) +
+			", size: " + strconv.Itoa(clusterConfiguration.Size))
+	}
+
+	// check policy
+	if err := clusterConfiguration.Policy.Validate(); err != nil {
+		validation.addError(path, err.Error())
+	}
+
+	// check scaling policy
+	if clusterConfiguration.Scaling != nil {
+		if err := clusterConfiguration.Scaling.Validate(); err != nil {
+			validation.addError(path, err.Error())
+		}
+	}
+
+	// check component
+	var component *Component
+	if domain != nil && elementConfiguration.Component != "" {
+		component, _ = domain.GetComponent(elementConfiguration.Component, clusterConfiguration.Version)
+		if component == nil {
+			validation.addError(path, "unknown component: " + elementConfiguration.Component + " - " + clusterConfiguration.Version)
+		}
+	}
+
+	// check parameters
+	var parameters map[string]string
+	if component != nil {
+		parameters = validation.validateParameters(path, clusterConfiguration.Configuration, component.Parameters, map[string]string{
+			"domain":    domain.Name,
+			"solution":  architecture.Architecture,
+			"version":   architecture.Version,
+			"element":   elementConfiguration.Element,
+			"component": elementConfiguration.Component,
+			"cluster":   clusterConfiguration.Version,
+			"min":       strconv.Itoa(clusterConfiguration.Min),
+			"max":       strconv.Itoa(clusterConfiguration.Max),
+			"size":      strconv.Itoa(clusterConfiguration.Size),
+		})
+	}
+
+	// check relationships
+	relationshipNames, _ := clusterConfiguration.ListRelationships()
+	sort.Strings(relationshipNames)
+
+	used := map[string]bool{}
+	for _, relationshipName := range relationshipNames {
+		relationshipConfiguration, _ := clusterConfiguration.GetRelationship(relationshipName)
+
+		used[relationshipConfiguration.Dependency] = true
+
+		validation.validateRelationship(domain, architecture, path + "/" + relationshipName, clusterConfiguration, component, parameters, relationshipConfiguration)
+	}
+
+	// check if all dependencies of the component have been satisfied
+	if component != nil {
+		dependencyNames, _ := component.ListDependencies()
+		sort.Strings(dependencyNames)
+
+		for _, dependencyName := range dependencyNames {
+			if !used[dependencyName] {
+				validation.addWarning(path, "dependency is not satisfied by a relationship: " + dependencyName)
+			}
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// validateRelationship checks a relationship configuration and its references.
+func (validation *Validation) validateRelationship(domain *Domain, architecture *Architecture, path string, clusterConfiguration *ClusterConfiguration, component *Component, parameters map[string]string, relationshipConfiguration *RelationshipConfiguration) {
+	// check type
+	if relationshipConfiguration.Type != ContextRelationship && relationshipConfiguration.Type != ServiceRelationship {
+		validation.addError(path, "invalid type of relationship: " + relationshipConfiguration.Type)
+	}
+
+	// check the referenced cluster
+	element, _ := architecture.GetElement(relationshipConfiguration.Element)
+	if element == nil {
+		validation.addError(path, "unknown element: " + relationshipConfiguration.Element)
+	} else {
+		cluster, _ := element.GetCluster(relationshipConfiguration.Version)
+		if cluster == nil {
+			validation.addError(path, "unknown cluster: " + relationshipConfiguration.Element + " - " + relationshipConfiguration.Version)
+		} else if cluster.State != ActiveState && clusterConfiguration.State != InitialState {
+			// the engine requires the referenced cluster to be active
+			validation.addWarning(path, "referenced cluster will not be active: " + relationshipConfiguration.Element + " - " + relationshipConfiguration.Version)
+		}
+	}
+
+	// check the dependency of the component
+	if component == nil {
+		return
+	}
+
+	dependency, _ := component.GetDependency(relationshipConfiguration.Dependency)
+	if dependency == nil {
+		validation.addError(path, "unknown dependency of component: " + relationshipConfiguration.Dependency)
+		return
+	}
+
+	if dependency.Type != relationshipConfiguration.Type {
+		validation.addError(path, "type of relationship does not match the type of the dependency: " + dependency.Type)
+	}
+
+	if element != nil && (dependency.Component != element.Component || dependency.Version != relationshipConfiguration.Version) {
+		validation.addWarning(path, "referenced cluster does not match the component of the dependency: " + dependency.Component + " - " + dependency.Version)
+	}
+
+	// check parameters
+	builtins := map[string]string{"relationship": relationshipConfiguration.Relationship}
+	for key, value := range parameters {
+		builtins[key] = value
+	}
+	validation.validateParameters(path, relationshipConfiguration.Configuration, dependency.Parameters, builtins)
+}
+
+//------------------------------------------------------------------------------
+
+// validateParameters checks the parameters of a configuration against the
+// parameter schema of a template and returns the builtin parameters.
+func (validation *Validation) validateParameters(path string, configuration string, schema []*Parameter, builtins map[string]string) map[string]string {
+	parameters := map[string]string{}
+	if err := util.ConvertFromYAML(configuration, &parameters); err != nil {
+		validation.addError(path, "unable to parse the parameters:\n" + err.Error())
+		return builtins
+	}
+	if len(parameters) == 0 {
+		parameters = map[string]string{}
+	}
+
+	for key, value := range builtins {
+		parameters[key] = value
+	}
+
+	if _, err := ResolveParameters(schema, parameters); err != nil {
+		for _, message := range strings.Split(err.Error(), "\n") {
+			validation.addError(path, message)
+		}
+	}
+
+	return builtins
+}
+
+//------------------------------------------------------------------------------
+
+// validateCycles checks if the context and service relationships of the
+// clusters form a cycle.
+func (validation *Validation) validateCycles(architecture *Architecture) {
+	// collect the edges of the dependency graph
+	edges := map[string][]string{}
+
+	elementNames, _ := architecture.ListElements()
+	for _, elementName := range elementNames {
+		elementConfiguration, _ := architecture.GetElement(elementName)
+
+		clusterNames, _ := elementConfiguration.ListClusters()
+		for _, clusterName := range clusterNames {
+			clusterConfiguration, _ := elementConfiguration.GetCluster(clusterName)
+			node := elementName + "/" + clusterName
+
+			edges[node] = []string{}
+
+			relationshipNames, _ := clusterConfiguration.ListRelationships()
+			for _, relationshipName := range relationshipNames {
+				relationshipConfiguration, _ := clusterConfiguration.GetRelationship(relationshipName)
+
+				if relationshipConfiguration.Type == ContextRelationship || relationshipConfiguration.Type == ServiceRelationship {
+					edges[node] = append(edges[node], relationshipConfiguration.Element + "/" + relationshipConfiguration.Version)
+				}
+			}
+			sort.Strings(edges[node])
+		}
+	}
+
+	nodes := []string{}
+	for node := range edges {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	// depth first search
+	visited := map[string]int{} // 0: unvisited, 1: on the stack, 2: finished
+	stack   := []string{}
+
+	var visit func(node string)
+	visit = func(node string) {
+		visited[node] = 1
+		stack = append(stack, node)
+
+		for _, next := range edges[node] {
+			switch visited[next] {
+			case 0:
+				if _, ok := edges[next]; ok {
+					visit(next)
+				}
+			case 1:
+				// report the cycle starting at the revisited node
+				for index := range stack {
+					if stack[index] == next {
+						cycle := append(append([]string{}, stack[index:]...), next)
+						validation.addError(next, "cyclic dependency: " + strings.Join(cycle, " -> "))
+						break
+					}
+				}
+			}
+		}
+
+		stack = stack[:len(stack) - 1]
+		visited[node] = 2
+	}
+
+	for _, node := range nodes {
+		if visited[node] == 0 {
+			visit(node)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
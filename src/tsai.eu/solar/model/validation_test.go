@@ -0,0 +1,104 @@
+package model
+
+import (
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+// TestValidation01 tests the validation of a consistent architecture.
+func TestValidation01(t *testing.T) {
+	model := GetModel()
+
+	model.Load("testdata/testdata1.yaml")
+
+	domain, _       := GetDomain("demo")
+	architecture, _ := GetArchitecture("demo", "app", "V0.0.0")
+
+	validation := ValidateArchitecture(domain, architecture)
+	if !validation.OK() || validation.Error() != nil {
+		t.Errorf("ValidateArchitecture should have accepted the architecture:\n%s", validation.Error())
+	}
+
+	if _, err := validation.Show(); err != nil {
+		t.Errorf("<validation>.Show should have displayed the validation:\n%s", err)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// TestValidation02 tests the detection of inconsistencies.
+func TestValidation02(t *testing.T) {
+	model := GetModel()
+
+	model.Load("testdata/testdata1.yaml")
+
+	domain, _       := GetDomain("demo")
+	architecture, _ := GetArchitecture("demo", "app", "V0.0.0")
+
+	// inconsistent sizing
+	element, _ := architecture.GetElement("app")
+	cluster, _ := element.GetCluster("V1.0.0")
+	cluster.Min = 4
+
+	// unknown cluster
+	relationship, _ := cluster.GetRelationship("db")
+	relationship.Version = "V9.9.9"
+
+	// mismatching dependency type
+	server, _        := architecture.GetElement("app-server")
+	serverCluster, _ := server.GetCluster("V1.0.0")
+	tenant, _        := serverCluster.GetRelationship("tenant")
+	tenant.Type = ServiceRelationship
+
+	// unknown component
+	db, _ := architecture.GetElement("db")
+	db.Component = "unknown"
+
+	validation := ValidateArchitecture(domain, architecture)
+
+	expected := map[string]bool{
+		"app/V1.0.0":               false,
+		"app/V1.0.0/db":            false,
+		"app-server/V1.0.0/tenant": false,
+		"db/V1.0.0":                false,
+	}
+	for _, finding := range validation.Errors {
+		expected[finding.Path] = true
+	}
+
+	for path, found := range expected {
+		if !found {
+			t.Errorf("ValidateArchitecture should have reported an error for: %s", path)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// TestValidation03 tests the detection of cyclic dependencies.
+func TestValidation03(t *testing.T) {
+	architecture, _ := NewArchitecture("app", "V1.0.0", "")
+
+	for _, name := range []string{"a", "b"} {
+		element, _ := NewElementConfiguration(name, "component", "")
+		cluster, _ := NewClusterConfiguration("V1.0.0", ActiveState, 1, 1, 1, "")
+
+		target := "b"
+		if name == "b" {
+			target = "a"
+		}
+
+		relationship, _ := NewRelationshipConfiguration("peer", "peer", ContextRelationship, target, "V1.0.0", "")
+		cluster.AddRelationship(relationship)
+		element.AddCluster(cluster)
+		architecture.AddElement(element)
+	}
+
+	validation := ValidateArchitecture(nil, architecture)
+	if len(validation.Errors) != 1 || validation.Errors[0].Message != "cyclic dependency: a/V1.0.0 -> b/V1.0.0 -> a/V1.0.0" {
+		t.Errorf("ValidateArchitecture should have reported the cyclic dependency:\n%s", validation.Error())
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,126 @@
+package monitor
+
+import (
+  "time"
+  "strconv"
+
+  "tsai.eu/solar/model"
+  "tsai.eu/solar/engine"
+  "tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// Autoscaler is the actor recorded in the audit log for scaling decisions.
+const Autoscaler string = "autoscaler"
+
+//------------------------------------------------------------------------------
+
+// checkScaling adjusts the size of all clusters with a scaling policy to the
+// latest metrics reported for them.
+func checkScaling() {
+  // loop over all domains
+  domainNames, _ := model.GetDomains()
+  for _, domainName := range domainNames {
+    domain, _ := model.GetDomain(domainName)
+
+    // loop over all solutions
+    solutionNames, _ := domain.ListSolutions()
+    for _, solutionName := range solutionNames {
+      solution, _ := domain.GetSolution(solutionName)
+
+      // loop over all elements
+      elementNames, _ := solution.ListElements()
+      for _, elementName := range elementNames {
+        element, _ := solution.GetElement(elementName)
+
+        // loop over all clusters
+        clusterNames, _ := element.ListClusters()
+        for _, clusterName := range clusterNames {
+          cluster, _ := element.GetCluster(clusterName)
+
+          if cluster != nil && cluster.Scaling != nil {
+            scaleCluster(domain, solution, elementName, cluster)
+          }
+        } // end of loop over all clusters
+      } // end of loop over all elements
+    } // end of loop over all solutions
+  } // end of loop over all domains
+}
+
+//------------------------------------------------------------------------------
+
+// scaleCluster evaluates the scaling policy of a cluster and triggers a
+// cluster task if its size needs to be adjusted.
+func scaleCluster(domain *model.Domain, solution *model.Solution, elementName string, cluster *model.Cluster) {
+  scaling := cluster.Scaling
+
+  // check if the metric has been reported since the latest decision
+  metric, ok := cluster.GetMetric(scaling.Metric)
+  if !ok || !metric.Fresh(cluster.Scaled) {
+    return
+  }
+  value := metric.Value
+
+  // respect the cooldown after the latest decision
+  if time.Since(time.Unix(0, cluster.Scaled)) < scaling.GetCooldown() {
+    return
+  }
+
+  // only scale clusters which are not being updated
+  if runningClusterTasks(domain, solution.Solution, elementName, cluster.Version) {
+    return
+  }
+
+  // determine the desired size
+  size := scaling.Evaluate(value, cluster.Size, cluster.Min, cluster.Max)
+  if size == cluster.Size {
+    return
+  }
+
+  path     := domain.Name + "/" + solution.Solution + "/" + elementName + "/" + cluster.Version
+  decision := "autoscaling: " + scaling.Metric + "=" + strconv.FormatFloat(value, 'f', -1, 64) +
+              ", size: " + strconv.Itoa(cluster.Size) + " -> " + strconv.Itoa(size)
+
+  // resize the cluster
+  before := model.AuditState(path)
+
+  cluster.Scaled = time.Now().UnixNano()
+  cluster.Resize(cluster.Min, cluster.Max, size)
+
+  model.Audit(Autoscaler, model.AuditSourceMonitor, "Cluster scaling", path, before, model.AuditState(path))
+
+  // create task and start it by signalling an event which records the decision
+  task, err := engine.NewClusterTask(domain.Name, "", solution.Solution, solution.Version, elementName, cluster.Version)
+  if err != nil {
+    util.LogError("main", "MON", "unable to scale cluster: '" + path + "'\n" + err.Error())
+    return
+  }
+
+  util.LogInfo(task.UUID, "MON", "starting task to scale cluster: '" + path + "' (" + decision + ")")
+
+  engine.GetEventQueue().Push(model.NewEvent(domain.Name, task.UUID, model.EventTypeTaskExecution, "", decision))
+}
+
+//------------------------------------------------------------------------------
+
+// runningClusterTasks checks if there are any currently running tasks
+// concerning a cluster, its element or its solution.
+func runningClusterTasks(domain *model.Domain, solutionName string, elementName string, clusterName string) bool {
+  // go through task list and find matching tasks
+  taskNames, _ := domain.ListTasks()
+  for _, taskName := range taskNames {
+    task, _ := domain.GetTask(taskName)
+
+    if task.GetSolution() == solutionName &&
+       (task.GetElement() == "" || task.GetElement() == elementName) &&
+       (task.GetCluster() == "" || task.GetCluster() == clusterName) &&
+       (task.GetStatus() == model.TaskStatusInitial || task.GetStatus() == model.TaskStatusExecuting) {
+      return true
+    }
+  }
+
+  return false
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,120 @@
+package monitor
+
+import (
+  "time"
+
+  "tsai.eu/solar/model"
+  "tsai.eu/solar/engine"
+  "tsai.eu/solar/msg"
+  "tsai.eu/solar/util"
+  ctrl "tsai.eu/solar/controller"
+)
+
+//------------------------------------------------------------------------------
+
+// DriftDetector is the actor recorded in the audit log for remediated drifts.
+const DriftDetector string = "driftdetector"
+
+//------------------------------------------------------------------------------
+
+// checkDrift compares the configurations reported by the controllers of the
+// active instances of all components with a drift detection with their
+// desired configuration.
+func checkDrift() {
+  scheduleChecks("drift", func(component *model.Component) time.Duration {
+    return component.Drift.GetInterval()
+  }, detectDrift)
+}
+
+//------------------------------------------------------------------------------
+
+// detectDrift requests the status of an instance from its controller and
+// records the difference between the desired configuration and the reported
+// configuration. The configuration of the instance in the model is not
+// modified unless the drift is remediated.
+func detectDrift(domainName string, solutionName string, version string, elementName string, clusterName string, instanceName string, component *model.Component) {
+  path := domainName + "/" + solutionName + "/" + elementName + "/" + clusterName + "/" + instanceName
+
+  // determine the controller of the instance
+  controller, err := ctrl.GetController(domainName, component.Controller)
+  if err != nil {
+    util.LogWarn("main", "MON", "unable to check drift of instance: '" + path + "'\n" + err.Error())
+    return
+  }
+
+  // determine the desired state of the instance
+  targetState, err := model.GetTargetState(domainName, solutionName, version, elementName, clusterName, instanceName)
+  if err != nil {
+    util.LogWarn("main", "MON", "unable to check drift of instance: '" + path + "'\n" + err.Error())
+    return
+  }
+
+  // request the current state of the instance
+  currentState, err := controller.Status(targetState)
+  if err != nil || currentState == nil || currentState.State != model.ActiveState || currentState.Configuration == "" {
+    return
+  }
+
+  // compare the configurations without the values of secrets
+  desired  := util.Redact(targetState.Configuration)
+  reported := util.Redact(currentState.Configuration)
+
+  diff := ""
+  if reported != desired {
+    diff = util.Diff(desired, reported)
+  }
+
+  recordDrift(domainName, solutionName, version, elementName, clusterName, instanceName, diff, reported, component.Drift)
+}
+
+//------------------------------------------------------------------------------
+
+// recordDrift records the drift of an instance, informs the message bus about
+// detected and resolved drifts and remediates the drift if required.
+func recordDrift(domainName string, solutionName string, version string, elementName string, clusterName string, instanceName string, diff string, reported string, detection model.DriftDetection) {
+  path := domainName + "/" + solutionName + "/" + elementName + "/" + clusterName + "/" + instanceName
+
+  instance, err := model.GetInstance(domainName, solutionName, elementName, clusterName, instanceName)
+  if err != nil || instance.State != model.ActiveState {
+    return
+  }
+
+  // the instance may have been changed by a task in the meantime
+  domain, err := model.GetDomain(domainName)
+  if err != nil || runningClusterTasks(domain, solutionName, elementName, clusterName) {
+    return
+  }
+
+  if instance.SetDrift(diff) {
+    if diff == "" {
+      util.LogInfo("main", "MON", "configuration of instance: '" + path + "' has converged")
+      msg.Notify("Drift", path + "/resolved")
+    } else {
+      util.LogWarn("main", "MON", "configuration of instance: '" + path + "' has drifted:\n" + diff)
+      msg.Notify("Drift", path + "/detected")
+    }
+  }
+
+  if diff == "" || !detection.Remediate {
+    return
+  }
+
+  // adopt the reported configuration which triggers the reconfiguration of
+  // the instance by the cluster task
+  before := model.AuditState(path)
+  instance.Configuration = reported
+  model.Persist()
+  model.Audit(DriftDetector, model.AuditSourceMonitor, "Instance drift remediation", path, before, model.AuditState(path))
+
+  task, err := engine.NewClusterTask(domainName, "", solutionName, version, elementName, clusterName)
+  if err != nil {
+    util.LogError("main", "MON", "unable to remediate drift of instance: '" + path + "'\n" + err.Error())
+    return
+  }
+
+  util.LogInfo(task.UUID, "MON", "starting task to remediate drift of instance: '" + path + "'")
+
+  engine.GetEventQueue().Push(model.NewEvent(domainName, task.UUID, model.EventTypeTaskExecution, "", "drift remediation: " + path))
+}
+
+//------------------------------------------------------------------------------
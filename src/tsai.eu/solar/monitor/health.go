@@ -0,0 +1,164 @@
+package monitor
+
+import (
+  "sync"
+  "time"
+
+  "tsai.eu/solar/model"
+  "tsai.eu/solar/msg"
+  "tsai.eu/solar/util"
+  ctrl "tsai.eu/solar/controller"
+)
+
+//------------------------------------------------------------------------------
+
+// HealthCheck is the actor recorded in the audit log for failed health checks.
+const HealthCheck string = "healthcheck"
+
+//------------------------------------------------------------------------------
+
+var failures     = map[string]int{}          // consecutive failed probes of the active instances by path
+var replacements = map[string][]time.Time{}  // times of the replacements of instances by cluster path
+var probesX      sync.Mutex                  // mutex for failures and replacements
+
+//------------------------------------------------------------------------------
+
+// checkHealth probes the active instances of all components with a health
+// check via the status operation of their controller.
+func checkHealth() {
+  scheduleChecks("health", func(component *model.Component) time.Duration {
+    return component.Health.GetInterval()
+  }, probeInstance)
+}
+
+//------------------------------------------------------------------------------
+
+// probeInstance requests the status of an instance from its controller and
+// marks the instance as failed if it is considered to be unhealthy.
+func probeInstance(domainName string, solutionName string, version string, elementName string, clusterName string, instanceName string, component *model.Component) {
+  clusterPath := domainName + "/" + solutionName + "/" + elementName + "/" + clusterName
+  path        := clusterPath + "/" + instanceName
+
+  // determine the controller of the instance
+  controller, err := ctrl.GetController(domainName, component.Controller)
+  if err != nil {
+    // an unavailable controller does not indicate a failure of the instance
+    util.LogWarn("main", "MON", "unable to probe instance: '" + path + "'\n" + err.Error())
+    recordProbe(path, clusterPath, true, component.Health)
+    return
+  }
+
+  // determine the desired state of the instance
+  targetState, err := model.GetTargetState(domainName, solutionName, version, elementName, clusterName, instanceName)
+  if err != nil {
+    util.LogWarn("main", "MON", "unable to probe instance: '" + path + "'\n" + err.Error())
+    recordProbe(path, clusterPath, true, component.Health)
+    return
+  }
+
+  // request the current state of the instance
+  currentState, err := controller.Status(targetState)
+
+  healthy, conclusive, reason := assessProbe(currentState, err)
+  if !conclusive {
+    util.LogInfo("main", "MON", "health check of instance: '" + path + "' is inconclusive: " + reason)
+    return
+  }
+  if !healthy {
+    util.LogWarn("main", "MON", "health check of instance: '" + path + "' has failed: " + reason)
+  }
+
+  // replace the instance if it has failed repeatedly
+  if recordProbe(path, clusterPath, healthy, component.Health) {
+    failInstance(domainName, solutionName, elementName, clusterName, instanceName)
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// assessProbe evaluates the current state reported by the controller of an
+// instance. A pending asynchronous operation of the controller neither
+// indicates a healthy nor a failed instance.
+func assessProbe(currentState *model.CurrentState, err error) (healthy bool, conclusive bool, reason string) {
+  switch {
+  case err != nil:
+    return false, true, err.Error()
+  case currentState != nil && currentState.Operation != "":
+    return false, false, "operation: " + currentState.Operation + " is pending"
+  case currentState == nil || currentState.State != model.ActiveState:
+    return false, true, "instance is not active"
+  }
+
+  return true, true, ""
+}
+
+//------------------------------------------------------------------------------
+
+// recordProbe records the result of a probe and decides if the instance needs
+// to be replaced. Instances are only replaced after the configured number of
+// consecutive failed probes and if the max. number of replacements of their
+// cluster within the period has not been reached.
+func recordProbe(path string, clusterPath string, healthy bool, health model.Health) bool {
+  probesX.Lock()
+  defer probesX.Unlock()
+
+  // a successful probe resets the failure count
+  if healthy {
+    delete(failures, path)
+    return false
+  }
+
+  failures[path]++
+  if failures[path] < health.GetThreshold() {
+    return false
+  }
+
+  // forget replacements outside of the period
+  recent := []time.Time{}
+  for _, replaced := range replacements[clusterPath] {
+    if time.Since(replaced) < health.GetPeriod() {
+      recent = append(recent, replaced)
+    }
+  }
+  replacements[clusterPath] = recent
+
+  if len(recent) >= health.GetReplacements() {
+    util.LogWarn("main", "MON", "instance: '" + path + "' is unhealthy but the max. number of replacements of its cluster has been reached")
+    return false
+  }
+
+  // success
+  replacements[clusterPath] = append(recent, time.Now())
+  delete(failures, path)
+  return true
+}
+
+//------------------------------------------------------------------------------
+
+// failInstance flags an instance as failed which triggers its replacement by
+// the reconciliation of its solution.
+func failInstance(domainName string, solutionName string, elementName string, clusterName string, instanceName string) {
+  path := domainName + "/" + solutionName + "/" + elementName + "/" + clusterName + "/" + instanceName
+
+  instance, err := model.GetInstance(domainName, solutionName, elementName, clusterName, instanceName)
+  if err != nil || instance.State != model.ActiveState {
+    return
+  }
+
+  // the instance may have been changed by a task in the meantime
+  domain, err := model.GetDomain(domainName)
+  if err != nil || runningClusterTasks(domain, solutionName, elementName, clusterName) {
+    return
+  }
+
+  util.LogWarn("main", "MON", "instance: '" + path + "' is unhealthy and will be replaced")
+
+  before := model.AuditState(path)
+  instance.SetState(model.FailureState)
+  model.Audit(HealthCheck, model.AuditSourceMonitor, "Instance health check", path, before, model.AuditState(path))
+
+  msg.Notify("Instance", domainName + "/" + elementName + "/" + clusterName + "/" + instanceName + "/" + model.FailureState)
+  model.PublishState(model.NotificationInstance, path, model.FailureState)
+}
+
+//------------------------------------------------------------------------------
@@ -11,9 +11,11 @@ import (
 
 //------------------------------------------------------------------------------
 
-// Monitor validates solutions and triggers tasks to converge to the desired target state.
+// Monitor validates solutions and triggers tasks to converge to the desired target state,
+// replaces unhealthy instances, detects configuration drift and adjusts the size of clusters
+// to their metrics.
 type Monitor struct {
-  Channel  chan model.Event      // the channel for event notification
+  Queue   *engine.EventQueue     // the queue for event notification
   Ticker  *time.Ticker           // ticker
   Active   bool                  // indicates if the monitoring loop should be active
 }
@@ -24,7 +26,7 @@ type Monitor struct {
 func Start(ctx context.Context) (*Monitor) {
 	// create the monitor
 	monitor := Monitor{
-    Channel: engine.GetEventChannel(),
+    Queue:   engine.GetEventQueue(),
     Ticker:  time.NewTicker(100 * time.Millisecond),
     Active:  false,
 	}
@@ -53,6 +55,9 @@ func (m *Monitor) Run(ctx context.Context) {
     case <- m.Ticker.C:
       if m.Active {
         checkSolutions()
+        checkHealth()
+        checkDrift()
+        checkScaling()
       }
     }
   }
@@ -62,7 +67,7 @@ func (m *Monitor) Run(ctx context.Context) {
 
 // checkDomains checks if there are any inconsistent domains
 func checkSolutions() {
-  channel := engine.GetEventChannel()
+  queue := engine.GetEventQueue()
 
   // loop over all domains
   domainNames, _ := model.GetDomains()
@@ -90,7 +95,7 @@ func checkSolutions() {
             util.LogInfo(task.UUID, "MON", "starting task to reconcile element: '" + element.Element + "' in solution: '" + solution.Solution + "'")
 
             // trigger the task
-            channel <- model.NewEvent(domainName, task.UUID, model.EventTypeTaskExecution, "", "Solution: " + solutionName + "/Element: " + elementName)
+            queue.Push(model.NewEvent(domainName, task.UUID, model.EventTypeTaskExecution, "", "Solution: " + solutionName + "/Element: " + elementName))
 
             // exit from elements loop
             break
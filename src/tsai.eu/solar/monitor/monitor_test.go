@@ -4,6 +4,7 @@ import (
   "testing"
   "context"
   "time"
+  "sync/atomic"
 
   "tsai.eu/solar/model"
 )
@@ -28,3 +29,267 @@ func TestMonitor001(t *testing.T) {
 }
 
 //------------------------------------------------------------------------------
+
+// TestMonitor002 tests the scaling of a cluster based on a reported metric
+func TestMonitor002(t *testing.T) {
+  filename := "testdata/testdata1.yaml"
+
+  // load model
+  m := model.GetModel()
+  m.Load(filename)
+
+  cluster, err := model.GetCluster("demo", "app", "app", "V1.0.0")
+  if err != nil {
+    t.Fatalf("cluster should have been available:\n%s", err)
+  }
+
+  cluster.Resize(1, 5, 3)
+  cluster.Scaling = &model.Scaling{Metric: "cpu", Upper: 80, Lower: 30}
+
+  // without a reported metric the size remains unchanged
+  checkScaling()
+  if cluster.Size != 3 {
+    t.Errorf("checkScaling should not have resized the cluster without a metric: %d", cluster.Size)
+  }
+
+  // scale out
+  cluster.SetMetric("cpu", 90)
+  checkScaling()
+  if cluster.Size != 4 || cluster.Scaled == 0 {
+    t.Errorf("checkScaling should have scaled out the cluster: %d", cluster.Size)
+  }
+
+  // the cooldown prevents further decisions
+  cluster.SetMetric("cpu", 10)
+  checkScaling()
+  if cluster.Size != 4 {
+    t.Errorf("checkScaling should have respected the cooldown: %d", cluster.Size)
+  }
+
+  // metrics reported before the latest decision are not evaluated again
+  cluster.Scaled = time.Now().Add(-time.Hour).UnixNano()
+  cluster.Metrics["cpu"] = model.Measurement{Value: 90, Reported: cluster.Scaled - 1}
+  checkScaling()
+  if cluster.Size != 4 {
+    t.Errorf("checkScaling should have ignored a metric reported before the latest decision: %d", cluster.Size)
+  }
+
+  // outdated metrics are ignored
+  cluster.Metrics["cpu"] = model.Measurement{Value: 90, Reported: time.Now().Add(-2 * model.MaxMetricAge).UnixNano()}
+  checkScaling()
+  if cluster.Size != 4 {
+    t.Errorf("checkScaling should have ignored an outdated metric: %d", cluster.Size)
+  }
+
+  // the decision is recorded in the audit log
+  found := false
+  for _, entry := range model.GetAudit("demo", 0, 0) {
+    if entry.Actor == Autoscaler && entry.Path == "demo/app/app/V1.0.0" {
+      found = true
+    }
+  }
+  if !found {
+    t.Errorf("checkScaling should have recorded the decision in the audit log")
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// TestMonitor003 tests the health checks of active instances
+func TestMonitor003(t *testing.T) {
+  filename := "testdata/testdata1.yaml"
+
+  // load model
+  m := model.GetModel()
+  m.Load(filename)
+
+  // forget earlier probes
+  probesX.Lock()
+  failures     = map[string]int{}
+  replacements = map[string][]time.Time{}
+  probesX.Unlock()
+
+  // flapping protection and replacement rate
+  health := model.Health{Interval: "10ms", Threshold: 2, Replacements: 1, Period: "1h"}
+
+  if recordProbe("demo/x/y/V1/a", "demo/x/y/V1", false, health) {
+    t.Errorf("recordProbe should not have replaced an instance after a single failed probe")
+  }
+  if recordProbe("demo/x/y/V1/a", "demo/x/y/V1", true, health) || recordProbe("demo/x/y/V1/a", "demo/x/y/V1", false, health) {
+    t.Errorf("recordProbe should have reset the failures after a successful probe")
+  }
+  if !recordProbe("demo/x/y/V1/a", "demo/x/y/V1", false, health) {
+    t.Errorf("recordProbe should have replaced an instance after consecutive failed probes")
+  }
+  recordProbe("demo/x/y/V1/b", "demo/x/y/V1", false, health)
+  if recordProbe("demo/x/y/V1/b", "demo/x/y/V1", false, health) {
+    t.Errorf("recordProbe should have respected the max. number of replacements")
+  }
+
+  // pending asynchronous operations are inconclusive
+  if _, conclusive, _ := assessProbe(&model.CurrentState{Operation: "op-1"}, nil); conclusive {
+    t.Errorf("assessProbe should not have judged an instance with a pending operation")
+  }
+  if healthy, conclusive, _ := assessProbe(&model.CurrentState{State: model.ActiveState}, nil); !healthy || !conclusive {
+    t.Errorf("assessProbe should have judged an active instance as healthy")
+  }
+  if healthy, _, _ := assessProbe(&model.CurrentState{State: model.InactiveState}, nil); healthy {
+    t.Errorf("assessProbe should have judged an inactive instance as failed")
+  }
+
+  // healthy instances remain active
+  component, _ := model.GetComponent2("demo", "app", "app", "V1.0.0")
+  component.Health = health
+
+  cluster, _ := model.GetCluster("demo", "app", "app", "V1.0.0")
+  instanceNames, _ := cluster.ListInstances()
+  if len(instanceNames) == 0 {
+    t.Fatalf("cluster should have had instances")
+  }
+  instance, _ := cluster.GetInstance(instanceNames[0])
+
+  for i := 0; i < 5; i++ {
+    checkHealth()
+    time.Sleep(20 * time.Millisecond)
+  }
+  if instance.State != model.ActiveState {
+    t.Errorf("checkHealth should not have flagged a healthy instance: %s", instance.State)
+  }
+
+  // unhealthy instances are flagged as failed
+  failInstance("demo", "app", "app", "V1.0.0", instanceNames[0])
+  if instance.State != model.FailureState {
+    t.Errorf("failInstance should have flagged the instance as failed: %s", instance.State)
+  }
+
+  found := false
+  for _, entry := range model.GetAudit("demo", 0, 0) {
+    if entry.Actor == HealthCheck && entry.Path == "demo/app/app/V1.0.0/" + instanceNames[0] {
+      found = true
+    }
+  }
+  if !found {
+    t.Errorf("failInstance should have recorded the failure in the audit log")
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// TestMonitor004 tests the detection and remediation of configuration drift.
+func TestMonitor004(t *testing.T) {
+  filename := "testdata/testdata1.yaml"
+
+  // load model
+  m := model.GetModel()
+  m.Load(filename)
+
+  component, _ := model.GetComponent2("demo", "app", "app", "V1.0.0")
+  cluster, _   := model.GetCluster("demo", "app", "app", "V1.0.0")
+
+  instanceNames, _ := cluster.ListInstances()
+  if len(instanceNames) == 0 {
+    t.Fatalf("cluster should have had instances")
+  }
+  instance, _ := cluster.GetInstance(instanceNames[0])
+  instance.SetState(model.ActiveState)
+
+  // drift is recorded without modifying the configuration of the instance
+  configuration := instance.Configuration
+
+  recordDrift("demo", "app", "V0.0.0", "app", "V1.0.0", instanceNames[0], "- Port: 80\n+ Port: 8080\n", "Port: 8080", component.Drift)
+
+  drifts, err := model.GetDrift("demo", "app")
+  if err != nil || len(drifts) != 1 || drifts[0].Instance != instanceNames[0] || drifts[0].Detected == "" {
+    t.Fatalf("recordDrift should have recorded the drift of the instance: %v", drifts)
+  }
+
+  if instance.Configuration != configuration {
+    t.Errorf("recordDrift should not have adopted the reported configuration")
+  }
+
+  // the internal controller reports the desired configuration
+  detectDrift("demo", "app", "V0.0.0", "app", "V1.0.0", instanceNames[0], component)
+
+  if drifts, _ = model.GetDrift("demo", "app"); len(drifts) != 0 {
+    t.Errorf("detectDrift should have resolved the drift of the instance: %v", drifts)
+  }
+
+  // drift is remediated by reconfiguring the instance
+  recordDrift("demo", "app", "V0.0.0", "app", "V1.0.0", instanceNames[0], "- Port: 80\n+ Port: 8080\n", "Port: 8080", model.DriftDetection{Interval: "1m", Remediate: true})
+
+  if instance.Configuration != "Port: 8080" || len(cluster.Outdated()) == 0 {
+    t.Errorf("recordDrift should have adopted the reported configuration to reconfigure the instance")
+  }
+
+  found := false
+  for _, entry := range model.GetAudit("demo", 0, 0) {
+    if entry.Actor == DriftDetector && entry.Path == "demo/app/app/V1.0.0/" + instanceNames[0] {
+      found = true
+    }
+  }
+  if !found {
+    t.Errorf("recordDrift should have recorded the remediation in the audit log")
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// TestMonitor005 tests the scheduling of periodic checks of active instances.
+func TestMonitor005(t *testing.T) {
+  filename := "testdata/testdata1.yaml"
+
+  // load model
+  m := model.GetModel()
+  m.Load(filename)
+
+  cluster, _ := model.GetCluster("demo", "app", "app", "V1.0.0")
+  instanceNames, _ := cluster.ListInstances()
+  if len(instanceNames) == 0 {
+    t.Fatalf("cluster should have had instances")
+  }
+  instance, _ := cluster.GetInstance(instanceNames[0])
+  instance.SetState(model.ActiveState)
+
+  interval := func(component *model.Component) time.Duration {
+    return 10 * time.Millisecond
+  }
+
+  // a check is not started again while the previous one is running
+  started := int32(0)
+  release := make(chan bool)
+  blocked := func(domainName string, solutionName string, version string, elementName string, clusterName string, instanceName string, component *model.Component) {
+    if instanceName == instanceNames[0] {
+      atomic.AddInt32(&started, 1)
+      <-release
+    }
+  }
+
+  for i := 0; i < 5; i++ {
+    scheduleChecks("test", interval, blocked)
+    time.Sleep(20 * time.Millisecond)
+  }
+  close(release)
+
+  if n := atomic.LoadInt32(&started); n != 1 {
+    t.Errorf("scheduleChecks should have started a single check of the instance: %d", n)
+  }
+
+  // checks of different kinds are scheduled independently
+  counted := int32(0)
+  count   := func(domainName string, solutionName string, version string, elementName string, clusterName string, instanceName string, component *model.Component) {
+    if instanceName == instanceNames[0] {
+      atomic.AddInt32(&counted, 1)
+    }
+  }
+
+  for i := 0; i < 5; i++ {
+    scheduleChecks("other", interval, count)
+    time.Sleep(20 * time.Millisecond)
+  }
+
+  if n := atomic.LoadInt32(&counted); n < 2 {
+    t.Errorf("scheduleChecks should have repeated the checks of the instance: %d", n)
+  }
+}
+
+//------------------------------------------------------------------------------
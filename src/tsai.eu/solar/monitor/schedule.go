@@ -0,0 +1,126 @@
+package monitor
+
+import (
+  "sync"
+  "time"
+
+  "tsai.eu/solar/model"
+)
+
+//------------------------------------------------------------------------------
+
+// check records the periodic checks of an active instance.
+type check struct {
+  Checked time.Time // time of the latest check
+  Running bool      // indicates if a check is being executed
+}
+
+// checkFunc checks an active instance of a cluster.
+type checkFunc func(domainName string, solutionName string, version string, elementName string, clusterName string, instanceName string, component *model.Component)
+
+var checks  = map[string]*check{} // checks of the active instances by kind and path
+var checksX sync.Mutex            // mutex for checks
+
+//------------------------------------------------------------------------------
+
+// scheduleChecks starts the checks of a kind (e.g. health or drift) of the
+// active instances of all components which are due. The interval of the checks
+// is determined by the component, components without an interval are not
+// checked. Instances are not checked while their cluster is being updated.
+func scheduleChecks(kind string, interval func(component *model.Component) time.Duration, run checkFunc) {
+  // loop over all domains
+  domainNames, _ := model.GetDomains()
+  for _, domainName := range domainNames {
+    domain, _ := model.GetDomain(domainName)
+
+    // loop over all solutions
+    solutionNames, _ := domain.ListSolutions()
+    for _, solutionName := range solutionNames {
+      solution, _ := domain.GetSolution(solutionName)
+
+      // loop over all elements
+      elementNames, _ := solution.ListElements()
+      for _, elementName := range elementNames {
+        element, _ := solution.GetElement(elementName)
+
+        // loop over all clusters
+        clusterNames, _ := element.ListClusters()
+        for _, clusterName := range clusterNames {
+          cluster, _ := element.GetCluster(clusterName)
+
+          component, err := model.GetComponent2(domainName, solutionName, elementName, clusterName)
+          if cluster == nil || err != nil || interval(component) == 0 {
+            continue
+          }
+
+          if runningClusterTasks(domain, solutionName, elementName, clusterName) {
+            continue
+          }
+
+          scheduleCluster(kind, domainName, solution, elementName, cluster, component, interval(component), run)
+        } // end of loop over all clusters
+      } // end of loop over all elements
+    } // end of loop over all solutions
+  } // end of loop over all domains
+}
+
+//------------------------------------------------------------------------------
+
+// scheduleCluster starts the checks of a kind of the active instances of a
+// cluster which are due. The first check of an instance is due one interval
+// after the instance has become active, a check is not started again as long
+// as the previous one is running.
+func scheduleCluster(kind string, domainName string, solution *model.Solution, elementName string, cluster *model.Cluster, component *model.Component, interval time.Duration, run checkFunc) {
+  clusterPath := domainName + "/" + solution.Solution + "/" + elementName + "/" + cluster.Version
+
+  instanceNames, _ := cluster.ListInstances()
+  for _, instanceName := range instanceNames {
+    instance, _ := cluster.GetInstance(instanceName)
+    key         := kind + ":" + clusterPath + "/" + instanceName
+
+    checksX.Lock()
+
+    // only active instances are checked
+    if instance == nil || instance.State != model.ActiveState || instance.Target != model.ActiveState {
+      delete(checks, key)
+      checksX.Unlock()
+      continue
+    }
+
+    c, found := checks[key]
+    if !found {
+      checks[key] = &check{Checked: time.Now()}
+      checksX.Unlock()
+      continue
+    }
+
+    if c.Running || time.Since(c.Checked) < interval {
+      checksX.Unlock()
+      continue
+    }
+
+    c.Running = true
+    c.Checked = time.Now()
+    checksX.Unlock()
+
+    go func(instanceName string) {
+      defer finishCheck(key)
+
+      run(domainName, solution.Solution, solution.Version, elementName, cluster.Version, instanceName, component)
+    }(instanceName)
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// finishCheck allows the next check of an instance to be started.
+func finishCheck(key string) {
+  checksX.Lock()
+  defer checksX.Unlock()
+
+  if c, found := checks[key]; found {
+    c.Running = false
+  }
+}
+
+//------------------------------------------------------------------------------
@@ -4,6 +4,7 @@ import (
   "sync"
   "context"
   "errors"
+  "strconv"
   "strings"
   "time"
 
@@ -232,7 +233,7 @@ func (msg *MSG) listen() {
         if len(names) == 4 {
           element, err := model.GetElement(names[0], names[1], names[2])
           if err == nil {
-            element.SetState( names[3] )
+            update(entity, strings.Join(names[0:3], "/"), names[3], func() { element.SetState( names[3] ) })
           }
         }
       case "Cluster":
@@ -241,7 +242,7 @@ func (msg *MSG) listen() {
         if len(names) == 5 {
           cluster, err := model.GetCluster(names[0], names[1], names[2], names[3])
           if err == nil {
-            cluster.SetState( names[4] )
+            update(entity, strings.Join(names[0:4], "/"), names[4], func() { cluster.SetState( names[4] ) })
           }
         }
       case "Instance":
@@ -250,7 +251,17 @@ func (msg *MSG) listen() {
         if len(names) == 6 {
           instance, err := model.GetInstance(names[0], names[1], names[2], names[3], names[4])
           if err == nil {
-            instance.SetState( names[5] )
+            update(entity, strings.Join(names[0:5], "/"), names[5], func() { instance.SetState( names[5] ) })
+          }
+        }
+      case "Metric":
+        names := strings.Split(value, "/")
+
+        if len(names) == 6 {
+          cluster, err := model.GetCluster(names[0], names[1], names[2], names[3])
+          metric, convErr := strconv.ParseFloat(names[5], 64)
+          if err == nil && convErr == nil {
+            cluster.SetMetric(names[4], metric)
           }
         }
     }
@@ -259,6 +270,22 @@ func (msg *MSG) listen() {
 
 //------------------------------------------------------------------------------
 
+// update applies a state update received from the message bus, records the
+// modification in the audit log and informs the subscribers.
+func update(entity string, path string, state string, apply func()) {
+  before := model.AuditState(path)
+
+  apply()
+
+  after := model.AuditState(path)
+  if after != before {
+    model.Audit("monitoring", model.AuditSourceKafka, entity + " state", path, before, after)
+    model.PublishState(entity, path, state)
+  }
+}
+
+//------------------------------------------------------------------------------
+
 // Notify writes data to the message bus
 func Notify(key string, value string)  {
   // only publish if a message connection was established
@@ -288,7 +315,7 @@ func notify(key string, value string)  {
       ctx,
       kafka.Message{
         Key:   []byte(key),
-        Value: []byte(value)},
+        Value: []byte(util.Redact(value))},
     )
 }
 
@@ -0,0 +1,102 @@
+package store
+
+import (
+	"os"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+
+	"tsai.eu/solar/model"
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// FileStore persists snapshots of the model in a single yaml file.
+type FileStore struct {
+	Path string // location of the snapshot file
+}
+
+//------------------------------------------------------------------------------
+
+// NewFileStore creates a file based store at a given location.
+func NewFileStore(path string) (*FileStore, error) {
+	if path == "" {
+		return nil, errors.New("path of file store is undefined")
+	}
+
+	// create the directory if necessary
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return nil, errors.New("unable to create directory of file store:\n" + err.Error())
+	}
+
+	// success
+	return &FileStore{Path: path}, nil
+}
+
+//------------------------------------------------------------------------------
+
+// Load restores the model from the snapshot file if one exists.
+func (store *FileStore) Load(m *model.Model) error {
+	// nothing to restore
+	if _, err := os.Stat(store.Path); os.IsNotExist(err) {
+		return nil
+	}
+
+	// read the snapshot
+	data, err := util.LoadFile(store.Path)
+	if err != nil {
+		return errors.New("unable to read file store:\n" + err.Error())
+	}
+
+	err = m.Load2(data)
+	if err != nil {
+		return errors.New("unable to restore model from file store:\n" + err.Error())
+	}
+
+	// success
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// Save writes a snapshot of the model to the file store. The snapshot is first
+// written to a temporary file which then replaces the previous snapshot so that
+// a crash never leaves a partially written store behind.
+func (store *FileStore) Save(m *model.Model) error {
+	data, err := util.ConvertToYAML(m.Snapshot())
+	if err != nil {
+		return errors.New("unable to serialise model:\n" + err.Error())
+	}
+
+	// write the temporary file
+	file, err := ioutil.TempFile(filepath.Dir(store.Path), filepath.Base(store.Path) + ".*")
+	if err != nil {
+		return errors.New("unable to write file store:\n" + err.Error())
+	}
+
+	_, err = file.WriteString(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return errors.New("unable to write file store:\n" + err.Error())
+	}
+
+	// replace the previous snapshot
+	err = os.Rename(file.Name(), store.Path)
+	if err != nil {
+		os.Remove(file.Name())
+		return errors.New("unable to write file store:\n" + err.Error())
+	}
+
+	// success
+	return nil
+}
+
+//------------------------------------------------------------------------------
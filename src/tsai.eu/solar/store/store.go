@@ -0,0 +1,100 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"tsai.eu/solar/model"
+	"tsai.eu/solar/util"
+)
+
+//------------------------------------------------------------------------------
+
+// Store writes all modifications of the model through to a storage backend.
+type Store struct {
+	Backend model.Store      // the storage backend
+	Channel chan bool        // the channel for modification notifications
+}
+
+//------------------------------------------------------------------------------
+
+// Start restores the model from the configured store and keeps persisting
+// its modifications. Persistence is disabled if no store has been configured.
+func Start(ctx context.Context) (*Store, error) {
+	// determine location of the store
+	configuration, _ := util.GetConfiguration()
+	if configuration == nil || configuration.STORE.Path == "" {
+		util.LogInfo("main", "STORE", "store inactive")
+		return nil, nil
+	}
+
+	backend, err := NewFileStore(configuration.STORE.Path)
+	if err != nil {
+		util.LogError("main", "STORE", err.Error())
+		return nil, err
+	}
+
+	return Open(ctx, backend)
+}
+
+//------------------------------------------------------------------------------
+
+// Open restores the model from a storage backend and keeps persisting its
+// modifications until the context is closed.
+func Open(ctx context.Context, backend model.Store) (*Store, error) {
+	if backend == nil {
+		return nil, errors.New("storage backend is undefined")
+	}
+
+	// create the store
+	store := Store{
+		Backend: backend,
+		Channel: model.GetModifications(),
+	}
+
+	// restore the model
+	err := backend.Load(model.GetModel())
+	if err != nil {
+		util.LogError("main", "STORE", err.Error())
+		return nil, err
+	}
+
+	// start persisting modifications
+	go store.Run(ctx)
+
+	util.LogInfo("main", "STORE", "store active")
+
+	// success
+	return &store, nil
+}
+
+//------------------------------------------------------------------------------
+
+// Run writes the model to the storage backend whenever it has been modified.
+func (store *Store) Run(ctx context.Context) {
+	for {
+		select {
+		// write final snapshot when context has been shut down
+		case <-ctx.Done():
+			store.Flush()
+			return
+		// write snapshot after modification
+		case <-store.Channel:
+			store.Flush()
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// Flush writes the current model to the storage backend.
+func (store *Store) Flush() error {
+	err := store.Backend.Save(model.GetModel())
+	if err != nil {
+		util.LogError("main", "STORE", "unable to persist model:\n" + err.Error())
+	}
+
+	return err
+}
+
+//------------------------------------------------------------------------------
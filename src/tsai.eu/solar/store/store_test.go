@@ -0,0 +1,92 @@
+package store
+
+import (
+  "testing"
+  "context"
+  "os"
+  "time"
+
+  "tsai.eu/solar/model"
+)
+
+//------------------------------------------------------------------------------
+
+// TestStore01 tests the file store.
+func TestStore01(t *testing.T) {
+  filename := "store/model.yaml"
+
+  // cleanup routine
+  defer func() {os.RemoveAll("store")}()
+
+  _, err := NewFileStore("")
+  if err == nil {
+    t.Errorf("NewFileStore should have complained about an undefined path")
+  }
+
+  backend, err := NewFileStore(filename)
+  if err != nil {
+    t.Fatalf("NewFileStore should have created a file store:\n%s", err)
+  }
+
+  // loading a non existing store is not an error
+  m, _ := model.NewModel()
+  err = backend.Load(m)
+  if err != nil {
+    t.Errorf("<store>.Load should have ignored a missing file store:\n%s", err)
+  }
+
+  // save and restore a model
+  m.Load("testdata/testdata1.yaml")
+
+  err = backend.Save(m)
+  if err != nil {
+    t.Fatalf("<store>.Save should have written the model:\n%s", err)
+  }
+
+  m2, _ := model.NewModel()
+  err = backend.Load(m2)
+  if err != nil {
+    t.Fatalf("<store>.Load should have restored the model:\n%s", err)
+  }
+
+  if _, err = m2.GetDomain("demo"); err != nil {
+    t.Errorf("<store>.Load should have restored the domain 'demo'")
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// TestStore02 tests the write through of model modifications.
+func TestStore02(t *testing.T) {
+  filename := "store/model.yaml"
+
+  // cleanup routine
+  defer func() {os.RemoveAll("store")}()
+
+  backend, _ := NewFileStore(filename)
+
+  ctx, cancel := context.WithCancel(context.Background())
+
+  _, err := Open(ctx, backend)
+  if err != nil {
+    t.Fatalf("Open should have attached the store:\n%s", err)
+  }
+
+  // modify the model
+  domain, _ := model.NewDomain("store")
+  model.GetModel().AddDomain(domain)
+
+  // wait until the modification has been written
+  time.Sleep(100 * time.Millisecond)
+  cancel()
+  time.Sleep(100 * time.Millisecond)
+
+  m, _ := model.NewModel()
+  backend.Load(m)
+
+  if _, err = m.GetDomain("store"); err != nil {
+    t.Errorf("modification of the model should have been persisted")
+  }
+}
+
+//------------------------------------------------------------------------------
@@ -20,6 +20,53 @@ type MsgConfiguration struct {
 type CoreConfiguration struct {
   Identifier  string
   LogLevel    string
+  URL         string // url of the REST API used by controllers to report asynchronous operations (no callbacks if empty)
+}
+
+//------------------------------------------------------------------------------
+
+// StoreConfiguration holds all configuration information for the persistence of the model
+type StoreConfiguration struct {
+  Path string // location of the model store (persistence is disabled if empty)
+}
+
+//------------------------------------------------------------------------------
+
+// QueueConfiguration holds all configuration information for the event queue of the engine
+type QueueConfiguration struct {
+  Size int    // maximum number of buffered events
+  Log  string // location of the write ahead log (the log is disabled if empty)
+}
+
+//------------------------------------------------------------------------------
+
+// SecretsConfiguration holds all configuration information for the secret store
+type SecretsConfiguration struct {
+  Key string // base64 encoded 32 byte key used to encrypt secrets (a key is generated next to the store if empty)
+}
+
+//------------------------------------------------------------------------------
+
+// AuditConfiguration holds all configuration information for the audit log
+type AuditConfiguration struct {
+  Path string // location of the audit log file (entries are only kept in memory if empty)
+}
+
+//------------------------------------------------------------------------------
+
+// TokenConfiguration defines a static API token and the roles granted by it
+type TokenConfiguration struct {
+  Token   string            // value of the bearer token
+  Subject string            // name of the user or system owning the token
+  Roles   map[string]string // role (viewer, operator, admin) per domain, "*" applies to all domains
+}
+
+//------------------------------------------------------------------------------
+
+// AuthConfiguration holds all configuration information for the authentication of API requests
+type AuthConfiguration struct {
+  Key    string               // key used to validate JSON web tokens signed with HS256
+  Tokens []TokenConfiguration // static API tokens
 }
 
 //------------------------------------------------------------------------------
@@ -28,6 +75,11 @@ type CoreConfiguration struct {
 type Configuration struct {
   MSG         MsgConfiguration
   CORE        CoreConfiguration
+  STORE       StoreConfiguration
+  QUEUE       QueueConfiguration
+  SECRETS     SecretsConfiguration
+  AUTH        AuthConfiguration // authentication is disabled if neither a key nor tokens are defined
+  AUDIT       AuditConfiguration
   CONTROLLERS []string // list of controller tags of the format "image-name:version"
 }
 
@@ -71,7 +123,12 @@ func readConfiguration(path string) (*Configuration, error) {
 
   // set default values
   viper.SetDefault("MSG",         map[string]string{"Notifications": "notifications", "Monitoring": "monitoring", "Address": "127.0.0.1:9092"})
-  viper.SetDefault("CORE",        map[string]string{"Identifier": "solar", "LogLevel": ""})
+  viper.SetDefault("CORE",        map[string]string{"Identifier": "solar", "LogLevel": "", "URL": ""})
+  viper.SetDefault("STORE",       map[string]string{"Path": ""})
+  viper.SetDefault("QUEUE",       map[string]interface{}{"Size": 1000, "Log": ""})
+  viper.SetDefault("SECRETS",     map[string]string{"Key": ""})
+  viper.SetDefault("AUTH",        map[string]interface{}{"Key": "", "Tokens": []interface{}{}})
+  viper.SetDefault("AUDIT",       map[string]string{"Path": ""})
   viper.SetDefault("CONTROLLERS", []string{})
 
   // read configuration (ignore any errors)
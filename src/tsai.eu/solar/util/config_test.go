@@ -70,7 +70,7 @@ func TestConfig02(t *testing.T) {
   // read configuration
   configuration, err := ReadConfiguration(".")
   if err == nil {
-    t.Errorf("ReadConfiguration should have reported an error but has responded with:\n%s", configuration)
+    t.Errorf("ReadConfiguration should have reported an error but has responded with:\n%v", configuration)
   }
 }
 
@@ -0,0 +1,92 @@
+package util
+
+import (
+  "strings"
+)
+
+//------------------------------------------------------------------------------
+
+// maxDiffSize limits the effort spent on comparing large documents.
+const maxDiffSize = 4000000
+
+//------------------------------------------------------------------------------
+
+// Diff compares two texts line by line and returns the removed lines prefixed
+// with "- " and the added lines prefixed with "+ ".
+func Diff(before string, after string) string {
+  if before == after {
+    return ""
+  }
+
+  a := splitLines(before)
+  b := splitLines(after)
+
+  // fall back to a complete replacement for very large documents
+  if len(a) * len(b) > maxDiffSize {
+    return prefixLines(a, "- ") + prefixLines(b, "+ ")
+  }
+
+  // determine the length of the longest common subsequences
+  lcs := make([][]int, len(a) + 1)
+  for i := range lcs {
+    lcs[i] = make([]int, len(b) + 1)
+  }
+  for i := len(a) - 1; i >= 0; i-- {
+    for j := len(b) - 1; j >= 0; j-- {
+      switch {
+      case a[i] == b[j]:
+        lcs[i][j] = lcs[i+1][j+1] + 1
+      case lcs[i+1][j] >= lcs[i][j+1]:
+        lcs[i][j] = lcs[i+1][j]
+      default:
+        lcs[i][j] = lcs[i][j+1]
+      }
+    }
+  }
+
+  // collect the differences
+  var result strings.Builder
+
+  i, j := 0, 0
+  for i < len(a) && j < len(b) {
+    switch {
+    case a[i] == b[j]:
+      i++
+      j++
+    case lcs[i+1][j] >= lcs[i][j+1]:
+      result.WriteString("- " + a[i] + "\n")
+      i++
+    default:
+      result.WriteString("+ " + b[j] + "\n")
+      j++
+    }
+  }
+  result.WriteString(prefixLines(a[i:], "- "))
+  result.WriteString(prefixLines(b[j:], "+ "))
+
+  // success
+  return result.String()
+}
+
+//------------------------------------------------------------------------------
+
+// splitLines splits a text into lines.
+func splitLines(text string) []string {
+  if text == "" {
+    return []string{}
+  }
+  return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+//------------------------------------------------------------------------------
+
+// prefixLines prefixes and joins lines.
+func prefixLines(lines []string, prefix string) string {
+  result := ""
+  for _, line := range lines {
+    result += prefix + line + "\n"
+  }
+  return result
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,30 @@
+package util
+
+import (
+  "testing"
+)
+
+//------------------------------------------------------------------------------
+
+// TestDiff01 tests the line based comparison of texts.
+func TestDiff01(t *testing.T) {
+  tests := []struct {
+    before string
+    after  string
+    diff   string
+  }{
+    {"a\nb\n",    "a\nb\n",    ""},
+    {"",          "a\n",       "+ a\n"},
+    {"a\n",       "",          "- a\n"},
+    {"a\nb\nc\n", "a\nx\nc\n", "- b\n+ x\n"},
+    {"a\nb\n",    "a\nb\nc\n", "+ c\n"},
+  }
+
+  for index, test := range tests {
+    if diff := Diff(test.before, test.after); diff != test.diff {
+      t.Errorf("%d: Diff should have returned:\n%s\ninstead of:\n%s", index, test.diff, diff)
+    }
+  }
+}
+
+//------------------------------------------------------------------------------
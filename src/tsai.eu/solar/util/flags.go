@@ -1,25 +1,154 @@
 package util
 
 import (
+	"os"
 	"flag"
+	"sync"
+	"time"
+	"strings"
+	"strconv"
 )
 
-var debug *bool
+var debug   *bool
+var remote  *string
+var token   *string
+var format  *string
+var wait    *bool
+var timeout *time.Duration
+var args    []string
+
+var flagsOnce sync.Once
 
 //------------------------------------------------------------------------------
 
-// ParseCommandLineOptions parses the options of the CLI
+// ParseCommandLineOptions parses the options of the CLI. Options may follow
+// the arguments of a command, e.g. "solar domain list --output json".
 func ParseCommandLineOptions() {
-	debug = flag.Bool("debug", false, "turns on debug logging")
+	// define the options once
+	flagsOnce.Do(func() {
+		debug   = flag.Bool("debug", false, "turns on debug logging")
+		remote  = flag.String("remote", "", "url of a running server which is managed via its REST API, e.g. http://host:port")
+		token   = flag.String("token", "", "bearer token for the remote server (default $SOLAR_TOKEN)")
+		format  = flag.String("output", "yaml", "format of the results of a command: yaml, json or table")
+		wait    = flag.Bool("wait", false, "follow the task started by a command until it has completed or failed")
+		timeout = flag.Duration("timeout", 0, "maximum time to wait for the task, e.g. 10m (default unlimited)")
+	})
+
+	options, arguments := splitCommandLine(os.Args[1:])
+
+	flag.CommandLine.Parse(options)
 
-	flag.Parse()
+	args = append(arguments, flag.Args()...)
+}
+
+//------------------------------------------------------------------------------
+
+// splitCommandLine separates the options from the arguments of a command.
+// Negative numbers are arguments.
+func splitCommandLine(commandLine []string) (options []string, arguments []string) {
+	for index := 0; index < len(commandLine); index++ {
+		value := commandLine[index]
+
+		// all remaining values are arguments
+		if value == "--" {
+			return options, append(arguments, commandLine[index+1:]...)
+		}
+
+		// arguments
+		if !strings.HasPrefix(value, "-") || value == "-" {
+			arguments = append(arguments, value)
+			continue
+		}
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			arguments = append(arguments, value)
+			continue
+		}
+
+		// options and their values
+		options = append(options, value)
+
+		name := strings.TrimLeft(value, "-")
+		if strings.Contains(name, "=") {
+			continue
+		}
+
+		option := flag.CommandLine.Lookup(name)
+		if option == nil {
+			continue
+		}
+		if boolean, ok := option.Value.(interface{ IsBoolFlag() bool }); ok && boolean.IsBoolFlag() {
+			continue
+		}
+		if index + 1 < len(commandLine) {
+			index++
+			options = append(options, commandLine[index])
+		}
+	}
+
+	return options, arguments
 }
 
 //------------------------------------------------------------------------------
 
 // Debug indicates if debug mode has been requested
 func Debug() bool {
-	return *debug
+	return debug != nil && *debug
+}
+
+//------------------------------------------------------------------------------
+
+// Remote provides the url of the remote server (empty if the model is managed locally)
+func Remote() string {
+	if remote == nil {
+		return ""
+	}
+	return *remote
+}
+
+//------------------------------------------------------------------------------
+
+// Token provides the bearer token for the remote server. The environment
+// variable SOLAR_TOKEN is used if the option has not been defined.
+func Token() string {
+	if token == nil || *token == "" {
+		return os.Getenv("SOLAR_TOKEN")
+	}
+	return *token
+}
+
+//------------------------------------------------------------------------------
+
+// Format provides the requested format of the results of a command
+func Format() string {
+	if format == nil {
+		return "yaml"
+	}
+	return *format
+}
+
+//------------------------------------------------------------------------------
+
+// Wait indicates if the task started by a command should be followed
+func Wait() bool {
+	return wait != nil && *wait
+}
+
+//------------------------------------------------------------------------------
+
+// Timeout provides the maximum time to wait for a task (0 = unlimited)
+func Timeout() time.Duration {
+	if timeout == nil {
+		return 0
+	}
+	return *timeout
+}
+
+//------------------------------------------------------------------------------
+
+// Args provides the arguments of a command which should be executed
+// non-interactively (empty for the interactive shell)
+func Args() []string {
+	return args
 }
 
 //------------------------------------------------------------------------------
@@ -1,6 +1,9 @@
 package util
 
 import (
+  "os"
+  "flag"
+  "strings"
   "testing"
 )
 
@@ -12,6 +15,38 @@ func TestFlags01(t *testing.T) {
   if Debug() == true {
     t.Errorf("Default debug flag should be false")
   }
+  if Remote() != "" {
+    t.Errorf("Default remote flag should be empty")
+  }
+  if Format() != "yaml" || Wait() || Timeout() != 0 {
+    t.Errorf("Default output should be yaml without waiting")
+  }
+
+  // the token is read from the environment and not shown as default value
+  os.Setenv("SOLAR_TOKEN", "secret-token")
+  defer os.Unsetenv("SOLAR_TOKEN")
+
+  if flag.Lookup("token").DefValue != "" || Token() != "secret-token" {
+    t.Errorf("Default token should be taken from the environment")
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// TestFlags02 tests the separation of options and arguments.
+func TestFlags02(t *testing.T) {
+  ParseCommandLineOptions()
+
+  commandLine := []string{"architecture", "deploy", "--wait", "demo", "--timeout", "10m", "--output=json", "-1", "--", "--debug"}
+
+  options, arguments := splitCommandLine(commandLine)
+
+  if strings.Join(options, " ") != "--wait --timeout 10m --output=json" {
+    t.Errorf("Options should have been separated: %v", options)
+  }
+  if strings.Join(arguments, " ") != "architecture deploy demo -1 --debug" {
+    t.Errorf("Arguments should have been separated: %v", arguments)
+  }
 }
 
 //------------------------------------------------------------------------------
@@ -50,7 +50,7 @@ func LogPanic(context string, module string, info string) {
   log.Panic().
     Str("Context", context).
     Str("Module", module).
-    Msg(info)
+    Msg(Redact(info))
 }
 
 //------------------------------------------------------------------------------
@@ -60,7 +60,7 @@ func LogFatal(context string, module string, info string) {
   log.Fatal().
     Str("Context", context).
     Str("Module", module).
-    Msg(info)
+    Msg(Redact(info))
 }
 
 //------------------------------------------------------------------------------
@@ -70,7 +70,7 @@ func LogError(context string, module string, info string) {
   log.Error().
     Str("Context", context).
     Str("Module", module).
-    Msg(info)
+    Msg(Redact(info))
 }
 
 //------------------------------------------------------------------------------
@@ -80,7 +80,7 @@ func LogWarn(context string, module string, info string) {
   log.Warn().
     Str("Context", context).
     Str("Module", module).
-    Msg(info)
+    Msg(Redact(info))
 }
 
 //------------------------------------------------------------------------------
@@ -90,7 +90,7 @@ func LogInfo(context string, module string, info string) {
   log.Info().
     Str("Context", context).
     Str("Module", module).
-    Msg(info)
+    Msg(Redact(info))
 }
 
 //------------------------------------------------------------------------------
@@ -100,7 +100,7 @@ func LogDebug(context string, module string, info string) {
   log.Debug().
     Str("Context", context).
     Str("Module", module).
-    Msg(info)
+    Msg(Redact(info))
 }
 
 //------------------------------------------------------------------------------
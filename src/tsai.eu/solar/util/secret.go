@@ -0,0 +1,211 @@
+package util
+
+import (
+  "io"
+  "os"
+  "sync"
+  "errors"
+  "strings"
+  "io/ioutil"
+  "path/filepath"
+  "crypto/aes"
+  "crypto/rand"
+  "crypto/cipher"
+  "encoding/base64"
+)
+
+//------------------------------------------------------------------------------
+
+// Redacted replaces the values of secrets in any output.
+const Redacted string = "********"
+
+//------------------------------------------------------------------------------
+
+// MinSecretLength is the min. length of the value of a secret. Shorter values
+// would be redacted wherever they appear as part of another text.
+const MinSecretLength int = 8
+
+// KeyFile is the name of the file next to the model store which holds the
+// generated key if no key has been configured.
+const KeyFile string = "solar.key"
+
+//------------------------------------------------------------------------------
+
+var secretKey    []byte
+var secretKeyErr error
+
+var secretKeyInit sync.Once
+
+var redactions  = map[string]bool{}
+var redactionsX = sync.RWMutex{}
+
+//------------------------------------------------------------------------------
+
+// getSecretKey determines the key used to encrypt secrets. If no key has been
+// configured a key is generated and stored next to the model store, so that
+// stored secrets survive a restart. Without a store a random key is used.
+func getSecretKey() ([]byte, error) {
+  secretKeyInit.Do(func() {
+    configuration, _ := GetConfiguration()
+
+    // configured key
+    if configuration != nil && configuration.SECRETS.Key != "" {
+      secretKey, secretKeyErr = decodeSecretKey(configuration.SECRETS.Key)
+      return
+    }
+
+    // generated key
+    secretKey = make([]byte, 32)
+    if _, secretKeyErr = io.ReadFull(rand.Reader, secretKey); secretKeyErr != nil {
+      return
+    }
+
+    if configuration == nil || configuration.STORE.Path == "" {
+      LogWarn("CORE", "util", "no secret key and no store configured - secrets will not survive a restart")
+      return
+    }
+
+    secretKey, secretKeyErr = loadSecretKey(filepath.Join(filepath.Dir(configuration.STORE.Path), KeyFile), secretKey)
+  })
+
+  return secretKey, secretKeyErr
+}
+
+//------------------------------------------------------------------------------
+
+// loadSecretKey reads the key from a key file. The provided key is written to
+// the file if it does not exist yet.
+func loadSecretKey(filename string, key []byte) ([]byte, error) {
+  data, err := ioutil.ReadFile(filename)
+  if err == nil {
+    return decodeSecretKey(strings.TrimSpace(string(data)))
+  }
+
+  if !os.IsNotExist(err) {
+    return nil, errors.New("unable to read secret key:\n" + err.Error())
+  }
+
+  err = os.MkdirAll(filepath.Dir(filename), 0755)
+  if err == nil {
+    err = ioutil.WriteFile(filename, []byte(base64.StdEncoding.EncodeToString(key) + "\n"), 0600)
+  }
+  if err != nil {
+    return nil, errors.New("unable to store secret key:\n" + err.Error())
+  }
+
+  LogInfo("CORE", "util", "secret key generated: " + filename)
+
+  // success
+  return key, nil
+}
+
+//------------------------------------------------------------------------------
+
+// decodeSecretKey decodes a base64 encoded 32 byte key.
+func decodeSecretKey(value string) ([]byte, error) {
+  key, err := base64.StdEncoding.DecodeString(value)
+  if err != nil || len(key) != 32 {
+    LogError("CORE", "util", "invalid secret key: a base64 encoded 32 byte key is required")
+    return nil, errors.New("invalid secret key: a base64 encoded 32 byte key is required")
+  }
+
+  // success
+  return key, nil
+}
+
+//------------------------------------------------------------------------------
+
+// Encrypt encrypts a value and returns the base64 encoded cipher text.
+func Encrypt(value string) (string, error) {
+  key, err := getSecretKey()
+  if err != nil {
+    return "", err
+  }
+
+  block, err := aes.NewCipher(key)
+  if err != nil {
+    return "", err
+  }
+
+  gcm, err := cipher.NewGCM(block)
+  if err != nil {
+    return "", err
+  }
+
+  nonce := make([]byte, gcm.NonceSize())
+  if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+    return "", err
+  }
+
+  ciphertext := gcm.Seal(nonce, nonce, []byte(value), nil)
+
+  // success
+  return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+//------------------------------------------------------------------------------
+
+// Decrypt decrypts a base64 encoded cipher text.
+func Decrypt(value string) (string, error) {
+  ciphertext, err := base64.StdEncoding.DecodeString(value)
+  if err != nil {
+    return "", err
+  }
+
+  key, err := getSecretKey()
+  if err != nil {
+    return "", err
+  }
+
+  block, err := aes.NewCipher(key)
+  if err != nil {
+    return "", err
+  }
+
+  gcm, err := cipher.NewGCM(block)
+  if err != nil {
+    return "", err
+  }
+
+  if len(ciphertext) < gcm.NonceSize() {
+    return "", errors.New("invalid cipher text")
+  }
+
+  plaintext, err := gcm.Open(nil, ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():], nil)
+  if err != nil {
+    return "", errors.New("unable to decrypt secret")
+  }
+
+  // success
+  return string(plaintext), nil
+}
+
+//------------------------------------------------------------------------------
+
+// AddRedaction registers the value of a secret which needs to be removed from
+// any output. Values shorter than the min. length of a secret are ignored.
+func AddRedaction(value string) {
+  if len(value) < MinSecretLength {
+    return
+  }
+
+  redactionsX.Lock()
+  redactions[value] = true
+  redactionsX.Unlock()
+}
+
+//------------------------------------------------------------------------------
+
+// Redact replaces the values of all resolved secrets within a text.
+func Redact(text string) string {
+  redactionsX.RLock()
+  defer redactionsX.RUnlock()
+
+  for value := range redactions {
+    text = strings.Replace(text, value, Redacted, -1)
+  }
+
+  return text
+}
+
+//------------------------------------------------------------------------------
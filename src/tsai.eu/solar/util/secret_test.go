@@ -0,0 +1,65 @@
+package util
+
+import (
+  "testing"
+  "path/filepath"
+)
+
+//------------------------------------------------------------------------------
+
+// TestSecret01 tests the encryption and redaction of secrets.
+func TestSecret01(t *testing.T) {
+  encrypted, err := Encrypt("TestSecret01-value")
+  if err != nil {
+    t.Fatalf("Encrypt should have encrypted the value:\n%s", err)
+  }
+
+  if encrypted == "TestSecret01-value" {
+    t.Errorf("Encrypt should not have returned the plain text")
+  }
+
+  decrypted, err := Decrypt(encrypted)
+  if err != nil || decrypted != "TestSecret01-value" {
+    t.Errorf("Decrypt should have restored the value")
+  }
+
+  if _, err = Decrypt("invalid"); err == nil {
+    t.Errorf("Decrypt should have complained about an invalid cipher text")
+  }
+
+  AddRedaction(decrypted)
+  if Redact("token: TestSecret01-value") != "token: " + Redacted {
+    t.Errorf("Redact should have removed the value of the secret")
+  }
+
+  // short values would corrupt any output
+  AddRedaction("a")
+  if Redact("Port: 80\nName: app") != "Port: 80\nName: app" {
+    t.Errorf("Redact should have ignored a value shorter than the min. length of a secret")
+  }
+}
+
+//------------------------------------------------------------------------------
+
+// TestSecret02 tests the generation and persistence of the secret key.
+func TestSecret02(t *testing.T) {
+  filename := filepath.Join(t.TempDir(), "data", KeyFile)
+
+  generated := []byte("0123456789abcdef0123456789abcdef")
+
+  key, err := loadSecretKey(filename, generated)
+  if err != nil || string(key) != string(generated) {
+    t.Fatalf("loadSecretKey should have stored the generated key:\n%v", err)
+  }
+
+  key, err = loadSecretKey(filename, []byte("fedcba9876543210fedcba9876543210"))
+  if err != nil || string(key) != string(generated) {
+    t.Errorf("loadSecretKey should have restored the stored key:\n%v", err)
+  }
+
+  if _, err = decodeSecretKey("short"); err == nil {
+    t.Errorf("decodeSecretKey should have complained about an invalid key")
+  }
+}
+
+//------------------------------------------------------------------------------
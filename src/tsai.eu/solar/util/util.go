@@ -1,6 +1,7 @@
 package util
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -69,6 +70,47 @@ func convertYAMLToJSON(inputObj interface{}) (interface{}, error) {
 
 //------------------------------------------------------------------------------
 
+// ConvertJSONToYAML converts JSON to YAML
+func ConvertJSONToYAML(input []byte) ([]byte, error) {
+	var jsonData interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	decoder.UseNumber()
+
+	err := decoder.Decode(&jsonData)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(convertJSONNumbers(jsonData))
+}
+
+// convertJSONNumbers preserves integers which would otherwise be converted
+// to floating point numbers.
+func convertJSONNumbers(inputObj interface{}) interface{} {
+	switch input := inputObj.(type) {
+	case map[string]interface{}:
+		for key, value := range input {
+			input[key] = convertJSONNumbers(value)
+		}
+		return input
+	case []interface{}:
+		for index, value := range input {
+			input[index] = convertJSONNumbers(value)
+		}
+		return input
+	case json.Number:
+		if value, err := input.Int64(); err == nil {
+			return value
+		}
+		value, _ := input.Float64()
+		return value
+	default:
+		return inputObj
+	}
+}
+
+//------------------------------------------------------------------------------
+
 // LoadFile reads string from a file
 func LoadFile(filename string) (data string, err error) {
 	bytes, err := ioutil.ReadFile(filename)
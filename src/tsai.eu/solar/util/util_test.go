@@ -57,6 +57,26 @@ func TestConvertYAMLToJSON(t *testing.T) {
 
 //------------------------------------------------------------------------------
 
+// TestConvertJSONToYAML tests the ConvertJSONToYAML function.
+func TestConvertJSONToYAML(t *testing.T) {
+  input1 := `{"a": 1000000, "b": "test", "c": [2, 3.5]}`
+
+  output, err := ConvertJSONToYAML( []byte(input1) )
+  if err != nil {
+    t.Fatalf("ConvertJSONToYAML should have been able to convert input 1")
+  }
+
+  if string(output) != "a: 1000000\nb: test\nc:\n- 2\n- 3.5\n" {
+    t.Errorf("ConvertJSONToYAML should have preserved the integers:\n%s", output)
+  }
+
+  if _, err = ConvertJSONToYAML( []byte("{") ); err == nil {
+    t.Errorf("ConvertJSONToYAML should have complained about invalid JSON")
+  }
+}
+
+//------------------------------------------------------------------------------
+
 // TestSaveAndLoadFile tests the SaveFile and LoadFile functions.
 func TestLoadFile(t *testing.T) {
   filename1 := "./util_test.yaml"